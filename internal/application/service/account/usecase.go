@@ -3,23 +3,29 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/exernia/botjanweb/internal/application/service"
 	"github.com/exernia/botjanweb/internal/domain"
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
 )
 
 // UseCase implements account management business logic.
 type UseCase struct {
-	repo usecase.AccountRepositoryPort
+	repo           usecase.AccountRepositoryPort
+	expiryWarnDays int // #listakun berakhir: accounts ending within N days
 }
 
 // New creates a new account use case.
-func New(repo usecase.AccountRepositoryPort) *UseCase {
+func New(repo usecase.AccountRepositoryPort, expiryWarnDays int) *UseCase {
 	return &UseCase{
-		repo: repo,
+		repo:           repo,
+		expiryWarnDays: expiryWarnDays,
 	}
 }
 
@@ -37,65 +43,130 @@ func (uc *UseCase) AddAccount(ctx context.Context, cmd *entity.AddAkunCommand) e
 
 // addAkunGoogle adds a new Google account.
 func (uc *UseCase) addAkunGoogle(ctx context.Context, cmd *entity.AddAkunCommand) error {
-	// Create account entity
-	now := time.Now()
-	expiry := now.AddDate(1, 0, 0) // Add 1 year
-
-	akun := &entity.AkunGoogle{
-		Email:           cmd.Email,
-		Sandi:           cmd.Sandi,
-		TanggalAktivasi: now,
-		TanggalBerakhir: expiry.Format("2006-01-02"), // Format as YYYY-MM-DD
-		StatusDibuat:    "",
-		YTPremium:       "",
-	}
-
-	// Add to sheet
-	if err := uc.repo.AddAkunGoogle(ctx, akun); err != nil {
+	if err := uc.repo.AddAkunGoogle(ctx, entity.NewAkunGoogle(cmd, time.Now())); err != nil {
 		return fmt.Errorf("gagal menambah akun: %w", err)
 	}
-
 	return nil
 }
 
 // addAkunChatGPT adds a new ChatGPT account.
 func (uc *UseCase) addAkunChatGPT(ctx context.Context, cmd *entity.AddAkunCommand) error {
-	// Create account entity
-	akun := &entity.AkunChatGPT{
-		Email:           cmd.Email,
-		Sandi:           cmd.Sandi,
-		Workspace:       cmd.Workspace,
-		Status:          "",
-		TanggalAktivasi: time.Now(),
-		TanggalKenaBan:  "",
-	}
-
-	// Add to sheet
-	if err := uc.repo.AddAkunChatGPT(ctx, akun); err != nil {
+	if err := uc.repo.AddAkunChatGPT(ctx, entity.NewAkunChatGPT(cmd, time.Now())); err != nil {
 		return fmt.Errorf("gagal menambah akun: %w", err)
 	}
-
 	return nil
 }
 
-// ListAccounts fetches all accounts based on the command filter.
-func (uc *UseCase) ListAccounts(ctx context.Context, cmd *entity.ListAkunCommand) (*entity.AccountListResult, error) {
+// GetCredential returns the decrypted login of an account (#sandi).
+// Returns domain.ErrAccountNotFound if the email is in neither account sheet.
+func (uc *UseCase) GetCredential(ctx context.Context, email string) (*entity.AccountCredential, error) {
+	cred, err := uc.repo.GetAccountCredential(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("gagal mengambil sandi: %w", err)
+	}
+	return cred, nil
+}
+
+// ListAccounts fetches all accounts, applies the #listakun filters and sorting,
+// and returns the requested page. Out-of-range pages return the last page.
+func (uc *UseCase) ListAccounts(ctx context.Context, cmd *entity.ListAkunCommand, now time.Time) (*entity.AccountListPage, error) {
 	result, err := uc.repo.GetAccountListResult(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil daftar akun: %w", err)
 	}
 
-	// Filter if specific type is requested
-	switch cmd.Tipe {
-	case entity.AccountTypeGoogle:
-		result.ChatGPTAccounts = nil
-		result.TotalChatGPT = 0
-		result.AvailableChatGPT = 0
-	case entity.AccountTypeChatGPT:
-		result.GoogleAccounts = nil
-		result.TotalGoogle = 0
-		result.AvailableGoogle = 0
+	page := &entity.AccountListPage{
+		TotalGoogle:      result.TotalGoogle,
+		TotalChatGPT:     result.TotalChatGPT,
+		AvailableGoogle:  result.AvailableGoogle,
+		AvailableChatGPT: result.AvailableChatGPT,
+	}
+
+	var items []entity.AccountListItem
+	if cmd.Tipe == "" || cmd.Tipe == entity.AccountTypeGoogle {
+		for i := range result.GoogleAccounts {
+			items = append(items, uc.googleItem(&result.GoogleAccounts[i], now))
+		}
+	}
+	if cmd.Tipe == "" || cmd.Tipe == entity.AccountTypeChatGPT {
+		for i := range result.ChatGPTAccounts {
+			items = append(items, chatGPTItem(&result.ChatGPTAccounts[i]))
+		}
+	}
+
+	matched := items[:0]
+	for i := range items {
+		if matchesListFilters(&items[i], cmd) {
+			matched = append(matched, items[i])
+		}
 	}
 
-	return result, nil
+	if cmd.SortByExpiry {
+		// Soonest end first; accounts without an end date last (stable within type order)
+		sort.SliceStable(matched, func(i, j int) bool {
+			a, b := matched[i].End, matched[j].End
+			if a.IsZero() || b.IsZero() {
+				return !a.IsZero() && b.IsZero()
+			}
+			return a.Before(b)
+		})
+	}
+
+	page.Matched = len(matched)
+	page.Pages = max(1, (len(matched)+constants.ListAkunPageSize-1)/constants.ListAkunPageSize)
+	page.Page = min(max(cmd.Page, 1), page.Pages)
+
+	start := (page.Page - 1) * constants.ListAkunPageSize
+	end := min(start+constants.ListAkunPageSize, len(matched))
+	page.Items = matched[start:end]
+
+	return page, nil
+}
+
+// googleItem builds a list item from an Akun Google row.
+func (uc *UseCase) googleItem(a *entity.AkunGoogle, now time.Time) entity.AccountListItem {
+	health, _ := a.Health(now, uc.expiryWarnDays)
+	item := entity.AccountListItem{
+		Tipe:      entity.AccountTypeGoogle,
+		Email:     a.Email,
+		Group:     a.StatusDibuat,
+		Note:      a.Keterangan,
+		Available: a.IsAvailable(),
+		Health:    health,
+		EndText:   a.TanggalBerakhir,
+	}
+	if end, ok := a.EndDate(); ok {
+		item.End = end
+	}
+	return item
+}
+
+// chatGPTItem builds a list item from an Akun ChatGPT row.
+func chatGPTItem(a *entity.AkunChatGPT) entity.AccountListItem {
+	health, _ := a.Health()
+	return entity.AccountListItem{
+		Tipe:      entity.AccountTypeChatGPT,
+		Email:     a.Email,
+		Group:     a.Workspace,
+		Note:      a.Status,
+		Available: a.IsAvailable(),
+		Health:    health,
+	}
+}
+
+// matchesListFilters applies the state, family/workspace and email filters.
+func matchesListFilters(item *entity.AccountListItem, cmd *entity.ListAkunCommand) bool {
+	if !cmd.Filter.Match(item) {
+		return false
+	}
+	if cmd.Group != "" && !strings.Contains(strings.ToLower(item.Group), strings.ToLower(cmd.Group)) {
+		return false
+	}
+	if cmd.Search != "" && !strings.Contains(strings.ToLower(item.Email), strings.ToLower(cmd.Search)) {
+		return false
+	}
+	return true
 }
@@ -0,0 +1,168 @@
+// Package catalog implements the product price list use case.
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+)
+
+var _ usecase.SlotLimitPort = (*UseCase)(nil)
+
+// UseCase implements price list lookup and nominal validation.
+// The catalog is cached in memory and reloaded after CatalogCacheMinutes.
+type UseCase struct {
+	source usecase.CatalogPort
+	ttl    time.Duration
+
+	mu       sync.RWMutex
+	items    []entity.CatalogItem
+	loadedAt time.Time
+}
+
+// New creates a new catalog use case.
+func New(source usecase.CatalogPort) *UseCase {
+	return &UseCase{
+		source: source,
+		ttl:    constants.CatalogCacheMinutes * time.Minute,
+	}
+}
+
+// List returns all catalog entries, reloading from source when the cache is stale.
+func (uc *UseCase) List(ctx context.Context) ([]entity.CatalogItem, error) {
+	uc.mu.RLock()
+	if uc.items != nil && time.Since(uc.loadedAt) < uc.ttl {
+		items := uc.items
+		uc.mu.RUnlock()
+		return items, nil
+	}
+	uc.mu.RUnlock()
+
+	items, err := uc.source.GetCatalog(ctx)
+	if err != nil {
+		// Serve stale cache rather than blocking orders on a sheet hiccup
+		uc.mu.RLock()
+		stale := uc.items
+		uc.mu.RUnlock()
+		if stale != nil {
+			return stale, nil
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrCatalogNotLoaded, err)
+	}
+
+	uc.mu.Lock()
+	uc.items = items
+	uc.loadedAt = time.Now()
+	uc.mu.Unlock()
+
+	return items, nil
+}
+
+// Refresh drops the cache so the next lookup reloads from source.
+func (uc *UseCase) Refresh() {
+	uc.mu.Lock()
+	uc.items = nil
+	uc.mu.Unlock()
+}
+
+// Find returns the catalog entry for a product and paket.
+// Falls back to the product's default entry (empty Paket). Returns nil if not listed.
+func (uc *UseCase) Find(ctx context.Context, product entity.Product, paket string) (*entity.CatalogItem, error) {
+	items, err := uc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fallback *entity.CatalogItem
+	for i := range items {
+		if items[i].Produk != product {
+			continue
+		}
+		if paket != "" && items[i].MatchesPaket(paket) {
+			return &items[i], nil
+		}
+		if items[i].Paket == "" && fallback == nil {
+			fallback = &items[i]
+		}
+	}
+	return fallback, nil
+}
+
+// ResolveAmount validates cmd.Amount against the catalog, or auto-fills it when empty.
+// On success cmd.Amount holds the final nominal. Payments are matched by amount
+// only, so callers reserve it (PaymentUseCase.ReserveAmount) before issuing a
+// QRIS: an auto-filled list price gets a unique code, a manual nominal (also one
+// without a catalog entry) must not be waiting on another unpaid QRIS.
+func (uc *UseCase) ResolveAmount(ctx context.Context, cmd *entity.QrisCommand) (*entity.PriceCheck, error) {
+	result := &entity.PriceCheck{Amount: cmd.Amount}
+
+	item, err := uc.Find(ctx, entity.Product(cmd.Produk), cmd.Paket)
+	if err != nil {
+		// Catalog unavailable: only accept orders with a manual nominal
+		if cmd.Amount > 0 {
+			result.IsValid = true
+			return result, nil
+		}
+		result.ErrorMessage = "Katalog harga tidak bisa dimuat, isi field Nominal secara manual"
+		return result, err
+	}
+	result.Item = item
+
+	if item == nil {
+		if cmd.Amount > 0 {
+			result.IsValid = true
+			return result, nil
+		}
+		result.ErrorMessage = fmt.Sprintf("Harga %s belum ada di katalog, isi field Nominal secara manual", describe(cmd))
+		return result, domain.ErrPriceNotListed
+	}
+
+	// Auto-fill from list price
+	if cmd.Amount <= 0 {
+		cmd.Amount = item.Harga
+		result.Amount = item.Harga
+		result.AutoFilled = true
+		result.IsValid = true
+		return result, nil
+	}
+
+	minHarga, maxHarga := item.AmountRange(constants.PriceTolerancePercent)
+	if cmd.Amount < minHarga || cmd.Amount > maxHarga {
+		result.ErrorMessage = fmt.Sprintf("Nominal %s tidak sesuai harga %s (%s, rentang %s - %s)",
+			formatter.FormatRupiah(cmd.Amount), describe(cmd),
+			formatter.FormatRupiah(item.Harga), formatter.FormatRupiah(minHarga), formatter.FormatRupiah(maxHarga))
+		return result, domain.ErrAmountOutOfRange
+	}
+
+	result.IsValid = true
+	return result, nil
+}
+
+// SlotLimit returns the catalog slot limit for a product, or fallback if not set.
+func (uc *UseCase) SlotLimit(ctx context.Context, product entity.Product, fallback int) int {
+	items, err := uc.List(ctx)
+	if err != nil {
+		return fallback
+	}
+	for _, item := range items {
+		if item.Produk == product && item.MaxSlots > 0 {
+			return item.MaxSlots
+		}
+	}
+	return fallback
+}
+
+// describe returns "Produk Paket" for messages.
+func describe(cmd *entity.QrisCommand) string {
+	if cmd.Paket != "" {
+		return fmt.Sprintf("%s %s", cmd.Produk, cmd.Paket)
+	}
+	return cmd.Produk
+}
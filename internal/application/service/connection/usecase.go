@@ -0,0 +1,99 @@
+// Package connection implements fallback alerts for WhatsApp connection problems.
+package connection
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/logger"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// UseCase turns connection state changes into alerts on a channel that does
+// not depend on WhatsApp. It alerts immediately on logout, once when
+// reconnecting keeps failing, and once more when the connection recovers.
+type UseCase struct {
+	alerts     usecase.AlertPort
+	pairingURL string
+	logger     *log.Logger
+
+	mu        sync.Mutex
+	downSince time.Time // Start of the current outage (zero when connected)
+	alerted   bool      // An alert was sent for the current outage
+	loggedOut bool      // Logout alert already sent
+}
+
+// New creates a new connection alert use case.
+// pairingURL is the pairing page without token (empty if the web server is disabled).
+func New(alerts usecase.AlertPort, pairingURL string) *UseCase {
+	return &UseCase{
+		alerts:     alerts,
+		pairingURL: pairingURL,
+		logger:     logger.Alert,
+	}
+}
+
+// OnStateChange handles a connection state change from the WhatsApp client.
+// Alerts are sent in the background so the WhatsApp event loop never blocks.
+func (uc *UseCase) OnStateChange(status entity.ConnectionStatus) {
+	subject, body, ok := uc.nextAlert(status)
+	if !ok {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*constants.AlertTimeoutSeconds*time.Second)
+		defer cancel()
+		if err := uc.alerts.SendAlert(ctx, subject, body); err != nil {
+			uc.logger.Printf("⚠️ Failed to send connection alert: %v", err)
+		}
+	}()
+}
+
+// nextAlert updates the outage state and returns the alert to send, if any.
+func (uc *UseCase) nextAlert(status entity.ConnectionStatus) (subject, body string, ok bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	switch status.State {
+	case entity.ConnConnected:
+		wasAlerted, downSince := uc.alerted, uc.downSince
+		uc.downSince, uc.alerted, uc.loggedOut = time.Time{}, false, false
+		if !wasAlerted {
+			return "", "", false
+		}
+		subject, body = template.BuildConnectionRecoveredAlert(status, downSince)
+		return subject, body, true
+
+	case entity.ConnLoggedOut:
+		uc.markDown(status)
+		if uc.loggedOut {
+			return "", "", false
+		}
+		uc.alerted, uc.loggedOut = true, true
+		subject, body = template.BuildLoggedOutAlert(status, uc.pairingURL)
+		return subject, body, true
+
+	case entity.ConnReconnecting:
+		uc.markDown(status)
+		if uc.alerted || status.Attempts < constants.ConnectionAlertAttempts {
+			return "", "", false
+		}
+		uc.alerted = true
+		subject, body = template.BuildReconnectFailingAlert(status)
+		return subject, body, true
+	}
+	return "", "", false
+}
+
+// markDown records the start of an outage.
+func (uc *UseCase) markDown(status entity.ConnectionStatus) {
+	if uc.downSince.IsZero() {
+		uc.downSince = status.Since
+	}
+}
@@ -0,0 +1,90 @@
+// Package duplicate implements duplicate customer detection for #qris orders.
+package duplicate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// UseCase checks an order email against existing member rows of the target
+// product sheet and unpaid QRIS, so one email is not added twice.
+type UseCase struct {
+	members  usecase.MemberLookupPort
+	pendings usecase.PendingLookupPort
+}
+
+// New creates a new duplicate check use case.
+func New(members usecase.MemberLookupPort, pendings usecase.PendingLookupPort) *UseCase {
+	return &UseCase{
+		members:  members,
+		pendings: pendings,
+	}
+}
+
+// Check looks up cmd.Email in the product sheet and pending payments.
+//   - Unpaid QRIS for the same product → blocked (Pending), except the one cmd.Replaces
+//   - Active row under another Family/Workspace/Head → blocked (Conflict)
+//   - Row under the same owner (or product without slots) → renewal of that row,
+//     cmd.RenewalRow/ExpiresAt are set so payment extends it instead of adding a row
+//   - Ended rows under another owner → allowed, listed in Expired
+func (uc *UseCase) Check(ctx context.Context, cmd *entity.QrisCommand, now time.Time) (*entity.DuplicateCheck, error) {
+	check := &entity.DuplicateCheck{}
+	email := strings.TrimSpace(cmd.Email)
+	if email == "" {
+		return check, nil
+	}
+
+	product, err := entity.ParseProduct(cmd.Produk)
+	if err != nil {
+		return check, nil
+	}
+
+	for _, p := range uc.pendings.FindPendingByEmail(email) {
+		if strings.EqualFold(p.Produk, string(product)) && p.MessageID != cmd.Replaces {
+			check.Pending = p
+			return check, nil
+		}
+	}
+
+	rows, err := uc.members.ListMembers(ctx, product)
+	if err != nil {
+		return check, fmt.Errorf("failed to read %s members: %w", product, err)
+	}
+
+	info := product.Info()
+	owner := cmd.SlotOwner()
+	for i := range rows {
+		m := rows[i]
+		if !strings.EqualFold(m.Email, email) {
+			continue
+		}
+
+		sameOwner := !info.HasSlots() || owner == "" || strings.EqualFold(m.Owner, owner)
+		switch {
+		case sameOwner:
+			// Keep the latest row (rows are in sheet order)
+			check.Renewal = &m
+		case m.IsActiveAt(now):
+			check.Conflict = &m
+		default:
+			check.Expired = append(check.Expired, m)
+		}
+	}
+
+	if check.Conflict != nil {
+		check.Renewal = nil
+		return check, nil
+	}
+
+	// Only products with an end date column can be extended
+	if check.Renewal != nil && info.DurationDays > 0 {
+		cmd.RenewalRow = check.Renewal.Row
+		cmd.ExpiresAt = check.Renewal.EndsAt
+	}
+	return check, nil
+}
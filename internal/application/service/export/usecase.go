@@ -0,0 +1,228 @@
+// Package export implements exporting orders and inventory as CSV/XLSX files (#export).
+package export
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/tabular"
+)
+
+// UseCase builds export files from the sheets repository.
+type UseCase struct {
+	repo     usecase.ExportPort
+	capacity usecase.SlotCapacityPort
+}
+
+// New creates a new export use case.
+func New(repo usecase.ExportPort, capacity usecase.SlotCapacityPort) *UseCase {
+	return &UseCase{repo: repo, capacity: capacity}
+}
+
+// table is an export before encoding.
+type table struct {
+	name   string // Worksheet name / file name prefix
+	header []string
+	rows   [][]string
+}
+
+// Export reads the requested data set and encodes it as CSV or XLSX.
+// now is used for the file name.
+func (uc *UseCase) Export(ctx context.Context, cmd *entity.ExportCommand, now time.Time) (*entity.ExportFile, error) {
+	var (
+		t   *table
+		err error
+	)
+	switch cmd.Kind {
+	case entity.ExportOrders:
+		t, err = uc.orders(ctx, cmd)
+	case entity.ExportSlots:
+		t, err = uc.slots(ctx, cmd)
+	case entity.ExportKode:
+		t, err = uc.codes(ctx, cmd)
+	case entity.ExportAkun:
+		t, err = uc.accounts(ctx, cmd)
+	default:
+		return nil, fmt.Errorf("unknown export kind: %s", cmd.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file := &entity.ExportFile{
+		FileName: fileName(cmd, now),
+		Rows:     len(t.rows),
+	}
+	if cmd.Format == entity.ExportFormatXLSX {
+		file.FileName += ".xlsx"
+		file.MimeType = tabular.MimeXLSX
+		file.Data, err = tabular.WriteXLSX(t.name, t.header, t.rows)
+	} else {
+		file.FileName += ".csv"
+		file.MimeType = tabular.MimeCSV
+		file.Data, err = tabular.WriteCSV(t.header, t.rows)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export: %w", err)
+	}
+	return file, nil
+}
+
+// fileName builds e.g. "orders-gemini-2026-10_20261018" (without extension).
+func fileName(cmd *entity.ExportCommand, now time.Time) string {
+	parts := []string{string(cmd.Kind)}
+	if cmd.Product != "" {
+		parts = append(parts, strings.ToLower(string(cmd.Product)))
+	}
+	if cmd.AccountType != "" {
+		parts = append(parts, strings.ToLower(string(cmd.AccountType)))
+	}
+	if cmd.AvailableOnly {
+		parts = append(parts, "tersedia")
+	}
+	if cmd.Range.Label != "" {
+		parts = append(parts, strings.ReplaceAll(cmd.Range.Label, "..", "_"))
+	}
+	return strings.Join(parts, "-") + "_" + now.Format("20060102")
+}
+
+// formatDate formats a sheet date as YYYY-MM-DD, or "" if unknown.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// availability returns the export label of an availability flag.
+func availability(available bool) string {
+	if available {
+		return "Tersedia"
+	}
+	return "Tidak Tersedia"
+}
+
+// orders exports order rows of one or all product sheets, filtered by Tanggal Pesanan.
+func (uc *UseCase) orders(ctx context.Context, cmd *entity.ExportCommand) (*table, error) {
+	products := entity.AllProducts()
+	if cmd.Product != "" {
+		products = []entity.Product{cmd.Product}
+	}
+
+	t := &table{
+		name: "Orders",
+		header: []string{"Produk", "Nama", "Email", "Family/Workspace/Head", "Paket", "Kode Redeem",
+			"Tanggal Pesanan", "Tanggal Berakhir", "Nominal", "Kanal", "Akun"},
+	}
+	for _, product := range products {
+		orders, err := uc.repo.ListOrders(ctx, product)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s orders: %w", product, err)
+		}
+		for _, o := range orders {
+			if !cmd.Range.Contains(o.TanggalPesanan) {
+				continue
+			}
+			t.rows = append(t.rows, []string{
+				o.Produk, o.Nama, o.Email, o.Family, o.Paket, o.KodeRedeem,
+				formatDate(o.TanggalPesanan), formatDate(o.TanggalBerakhir),
+				strconv.Itoa(o.Amount), o.Kanal, o.Akun,
+			})
+		}
+	}
+	return t, nil
+}
+
+// slots exports slot availability of one or all products with slots.
+func (uc *UseCase) slots(ctx context.Context, cmd *entity.ExportCommand) (*table, error) {
+	var products []entity.Product
+	if cmd.Product != "" {
+		if !cmd.Product.Info().HasSlots() {
+			return nil, fmt.Errorf("produk %s tidak punya slot", cmd.Product)
+		}
+		products = []entity.Product{cmd.Product}
+	} else {
+		for _, p := range entity.AllProducts() {
+			if p.Info().HasSlots() {
+				products = append(products, p)
+			}
+		}
+	}
+
+	t := &table{
+		name:   "Slots",
+		header: []string{"Produk", "Family/Workspace/Head", "Total Slot", "Terpakai", "Tersedia"},
+	}
+	for _, product := range products {
+		result, err := uc.repo.GetSlotAvailability(ctx, string(product), uc.capacity.SlotCapacity(ctx, product), cmd.AvailableOnly)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s slots: %w", product, err)
+		}
+		for _, s := range result.Slots {
+			t.rows = append(t.rows, []string{
+				string(product), s.Name,
+				strconv.Itoa(s.TotalSlots), strconv.Itoa(s.UsedSlots), strconv.Itoa(s.AvailableSlot),
+			})
+		}
+	}
+	return t, nil
+}
+
+// codes exports the Kode Perplexity sheet.
+func (uc *UseCase) codes(ctx context.Context, cmd *entity.ExportCommand) (*table, error) {
+	result, err := uc.repo.GetRedeemCodeAvailability(ctx, cmd.AvailableOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redeem codes: %w", err)
+	}
+
+	t := &table{
+		name:   "Kode Perplexity",
+		header: []string{"No", "Email", "Kode Redeem", "Tanggal Aktivasi", "Tanggal Berakhir", "Customer", "Keterangan", "Status"},
+	}
+	for i := range result.Codes {
+		c := &result.Codes[i]
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(c.No), c.Email, c.KodeRedeem, c.TanggalAktivasi, c.TanggalBerakhir,
+			c.Customer, c.Keterangan, availability(c.IsAvailable()),
+		})
+	}
+	return t, nil
+}
+
+// accounts exports Akun Google / Akun ChatGPT. Passwords are never exported.
+func (uc *UseCase) accounts(ctx context.Context, cmd *entity.ExportCommand) (*table, error) {
+	result, err := uc.repo.GetAccountListResult(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	t := &table{
+		name: "Akun",
+		header: []string{"Tipe", "Email", "Family/Workspace", "Tanggal Aktivasi",
+			"Tanggal Berakhir/Kena Ban", "Keterangan/Status", "Ketersediaan"},
+	}
+	if cmd.AccountType == "" || cmd.AccountType == entity.AccountTypeGoogle {
+		for i := range result.GoogleAccounts {
+			a := &result.GoogleAccounts[i]
+			t.rows = append(t.rows, []string{
+				string(entity.AccountTypeGoogle), a.Email, a.StatusDibuat, formatDate(a.TanggalAktivasi),
+				a.TanggalBerakhir, a.Keterangan, availability(a.IsAvailable()),
+			})
+		}
+	}
+	if cmd.AccountType == "" || cmd.AccountType == entity.AccountTypeChatGPT {
+		for i := range result.ChatGPTAccounts {
+			a := &result.ChatGPTAccounts[i]
+			t.rows = append(t.rows, []string{
+				string(entity.AccountTypeChatGPT), a.Email, a.Workspace, formatDate(a.TanggalAktivasi),
+				a.TanggalKenaBan, a.Status, availability(a.IsAvailable()),
+			})
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,202 @@
+// Package importer implements bulk import of accounts and redeem codes from CSV/XLSX files (#import).
+package importer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+	"github.com/exernia/botjanweb/pkg/helper/tabular"
+	"github.com/exernia/botjanweb/pkg/logger"
+)
+
+// UseCase validates an uploaded file as a dry run and appends the valid rows
+// after the admin confirms. The preview is kept per chat until confirmed,
+// cancelled or expired.
+type UseCase struct {
+	accounts  usecase.AccountRepositoryPort
+	inventory usecase.InventoryPort
+	logger    *log.Logger
+
+	mu       sync.Mutex
+	previews map[string]*entity.ImportPreview // Chat ID -> preview waiting for confirmation
+}
+
+// New creates a new import use case.
+func New(accounts usecase.AccountRepositoryPort, inventory usecase.InventoryPort) *UseCase {
+	return &UseCase{
+		accounts:  accounts,
+		inventory: inventory,
+		logger:    logger.Import,
+		previews:  make(map[string]*entity.ImportPreview),
+	}
+}
+
+// Preview reads and validates doc without writing anything. Rows that fail the
+// #addakun / #inputkode rules, repeat an earlier row or already exist in the
+// sheet are listed as invalid. If there are valid rows the preview is kept for
+// chatID until Commit or Cancel.
+func (uc *UseCase) Preview(ctx context.Context, chatID string, kind entity.ImportKind, doc *entity.Document) (*entity.ImportPreview, error) {
+	rows, err := tabular.Read(doc.FileName, doc.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	items := parser.ParseImportRows(kind, rows)
+	if len(items) == 0 {
+		return nil, domain.ErrImportNoRows
+	}
+	if len(items) > constants.ImportMaxRows {
+		return nil, domain.ErrImportTooManyRows
+	}
+
+	existing, err := uc.existingKeys(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &entity.ImportPreview{Kind: kind, FileName: doc.FileName, CreatedAt: time.Now()}
+	seen := make(map[string]int) // Key -> first row number in the file
+	for _, item := range items {
+		if item.Error == "" {
+			key := importKey(&item)
+			if first, ok := seen[key]; ok {
+				item.Error = fmt.Sprintf("duplikat dengan baris %d", first)
+			} else if existing[key] {
+				item.Error = fmt.Sprintf("sudah ada di sheet %s", kind.Label())
+			} else {
+				seen[key] = item.Number
+			}
+		}
+
+		if item.Error != "" {
+			preview.Invalid = append(preview.Invalid, item)
+		} else {
+			preview.Valid = append(preview.Valid, item)
+		}
+	}
+
+	uc.mu.Lock()
+	if len(preview.Valid) > 0 {
+		uc.previews[chatID] = preview
+	} else {
+		delete(uc.previews, chatID)
+	}
+	uc.mu.Unlock()
+
+	uc.logger.Printf("📥 Preview %s (%s): %d valid, %d invalid",
+		doc.FileName, kind, len(preview.Valid), len(preview.Invalid))
+	return preview, nil
+}
+
+// importKey returns the duplicate key of a row: the redeem code for codes,
+// the email for accounts.
+func importKey(item *entity.ImportRow) string {
+	if item.Code != nil {
+		return strings.ToLower(item.Code.KodeRedeem)
+	}
+	return strings.ToLower(item.Email())
+}
+
+// existingKeys returns the duplicate keys already present in the target sheet.
+func (uc *UseCase) existingKeys(ctx context.Context, kind entity.ImportKind) (map[string]bool, error) {
+	keys := make(map[string]bool)
+
+	if kind == entity.ImportKode {
+		codes, err := uc.inventory.GetRedeemCodeAvailability(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get redeem codes: %w", err)
+		}
+		for _, code := range codes.Codes {
+			keys[strings.ToLower(code.KodeRedeem)] = true
+		}
+		return keys, nil
+	}
+
+	accounts, err := uc.accounts.GetAccountListResult(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	if kind == entity.ImportAkunGoogle {
+		for _, akun := range accounts.GoogleAccounts {
+			keys[strings.ToLower(akun.Email)] = true
+		}
+	} else {
+		for _, akun := range accounts.ChatGPTAccounts {
+			keys[strings.ToLower(akun.Email)] = true
+		}
+	}
+	return keys, nil
+}
+
+// Commit appends the valid rows of the pending preview for chatID in a single
+// sheet update. If the write fails the preview is kept so it can be retried.
+func (uc *UseCase) Commit(ctx context.Context, chatID string) (*entity.ImportPreview, error) {
+	uc.mu.Lock()
+	preview, ok := uc.previews[chatID]
+	delete(uc.previews, chatID)
+	uc.mu.Unlock()
+
+	if !ok || time.Since(preview.CreatedAt) > constants.ImportPreviewMinutes*time.Minute {
+		return nil, domain.ErrNoImportPreview
+	}
+
+	if err := uc.write(ctx, preview); err != nil {
+		uc.mu.Lock()
+		if _, replaced := uc.previews[chatID]; !replaced {
+			uc.previews[chatID] = preview
+		}
+		uc.mu.Unlock()
+		return nil, err
+	}
+
+	uc.logger.Printf("📥 Imported %d row(s) from %s into %s", len(preview.Valid), preview.FileName, preview.Kind.Label())
+	return preview, nil
+}
+
+// write appends the valid rows of preview to the target sheet.
+func (uc *UseCase) write(ctx context.Context, preview *entity.ImportPreview) error {
+	now := time.Now()
+
+	switch preview.Kind {
+	case entity.ImportAkunGoogle:
+		akuns := make([]entity.AkunGoogle, 0, len(preview.Valid))
+		for _, item := range preview.Valid {
+			akuns = append(akuns, *entity.NewAkunGoogle(item.Account, now))
+		}
+		return uc.accounts.AddAkunGoogleBatch(ctx, akuns)
+
+	case entity.ImportAkunChatGPT:
+		akuns := make([]entity.AkunChatGPT, 0, len(preview.Valid))
+		for _, item := range preview.Valid {
+			akuns = append(akuns, *entity.NewAkunChatGPT(item.Account, now))
+		}
+		return uc.accounts.AddAkunChatGPTBatch(ctx, akuns)
+
+	case entity.ImportKode:
+		codes := make([]entity.RedeemCodeInfo, 0, len(preview.Valid))
+		for _, item := range preview.Valid {
+			codes = append(codes, entity.RedeemCodeInfo{Email: item.Code.Email, KodeRedeem: item.Code.KodeRedeem})
+		}
+		return uc.inventory.AddRedeemCodes(ctx, codes)
+	}
+
+	return fmt.Errorf("unknown import kind: %s", preview.Kind)
+}
+
+// Cancel drops the pending preview for chatID. Returns false if there was none.
+func (uc *UseCase) Cancel(chatID string) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	_, ok := uc.previews[chatID]
+	delete(uc.previews, chatID)
+	return ok
+}
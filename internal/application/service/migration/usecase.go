@@ -0,0 +1,244 @@
+// Package migration implements moving members off a banned ChatGPT workspace (#pindah).
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"github.com/exernia/botjanweb/pkg/helper/validator"
+	"github.com/exernia/botjanweb/pkg/logger"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// UseCase plans and applies member migrations for banned ChatGPT workspaces.
+// A plan is proposed first and kept per chat; members are only moved after
+// the admin confirms it.
+type UseCase struct {
+	repo      usecase.MigrationPort
+	capacity  usecase.SlotCapacityPort
+	messaging usecase.MessagingPort
+	logger    *log.Logger
+
+	mu    sync.Mutex
+	plans map[string]*entity.MigrationPlan // Chat ID -> plan waiting for confirmation
+}
+
+// New creates a new migration use case.
+// Messaging is set later via SetMessaging (WhatsApp client is created in Run).
+func New(repo usecase.MigrationPort, capacity usecase.SlotCapacityPort) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		capacity: capacity,
+		logger:   logger.Migration,
+		plans:    make(map[string]*entity.MigrationPlan),
+	}
+}
+
+// SetMessaging sets the messaging service used to notify moved customers.
+func (uc *UseCase) SetMessaging(m usecase.MessagingPort) {
+	uc.messaging = m
+}
+
+// BannedWorkspaces returns banned ChatGPT workspaces that still have members,
+// most members first.
+func (uc *UseCase) BannedWorkspaces(ctx context.Context) ([]entity.AccountIssue, error) {
+	accounts, err := uc.repo.GetAkunChatGPTList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ChatGPT accounts: %w", err)
+	}
+	members, err := uc.repo.ListMembers(ctx, entity.ProductChatGPT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ChatGPT members: %w", err)
+	}
+
+	var banned []entity.AccountIssue
+	for i := range accounts {
+		akun := &accounts[i]
+		health, detail := akun.Health()
+		if health != entity.HealthBanned {
+			continue
+		}
+		count := 0
+		for _, m := range members {
+			if akun.MatchesOwner(m.Owner) {
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+		banned = append(banned, entity.AccountIssue{
+			Type:    entity.AccountTypeChatGPT,
+			Email:   akun.Email,
+			Name:    akun.Workspace,
+			Health:  health,
+			Detail:  detail,
+			Members: count,
+		})
+	}
+
+	sort.SliceStable(banned, func(i, j int) bool {
+		return banned[i].Members > banned[j].Members
+	})
+	return banned, nil
+}
+
+// Plan proposes target workspaces for all members of a banned workspace and
+// keeps the plan for chatID until Confirm or Cancel. Targets are healthy
+// workspaces with free slots, fullest availability first.
+func (uc *UseCase) Plan(ctx context.Context, chatID, workspace string) (*entity.MigrationPlan, error) {
+	accounts, err := uc.repo.GetAkunChatGPTList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ChatGPT accounts: %w", err)
+	}
+
+	var source *entity.AkunChatGPT
+	for i := range accounts {
+		if accounts[i].MatchesOwner(workspace) {
+			source = &accounts[i]
+			break
+		}
+	}
+	if source == nil {
+		return nil, domain.ErrWorkspaceNotFound
+	}
+	// Only banned workspaces are emptied; locked or full ones keep their members
+	if !source.IsBanned() {
+		return nil, domain.ErrWorkspaceNotBanned
+	}
+
+	members, err := uc.repo.ListMembers(ctx, entity.ProductChatGPT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ChatGPT members: %w", err)
+	}
+
+	maxSlots := uc.capacity.SlotCapacity(ctx, entity.ProductChatGPT)
+	slots, err := uc.repo.GetSlotAvailability(ctx, string(entity.ProductChatGPT), maxSlots, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get slot availability: %w", err)
+	}
+
+	plan := &entity.MigrationPlan{Source: *source, CreatedAt: time.Now()}
+	targets := uc.targets(accounts, source, slots)
+
+	t := 0
+	for _, m := range members {
+		if !source.MatchesOwner(m.Owner) {
+			continue
+		}
+		for t < len(targets) && targets[t].free == 0 {
+			t++
+		}
+		if t == len(targets) {
+			plan.Unassigned = append(plan.Unassigned, m)
+			continue
+		}
+		plan.Moves = append(plan.Moves, entity.MemberMove{Member: m, Target: targets[t].akun})
+		targets[t].free--
+	}
+
+	if len(plan.Moves) > 0 {
+		uc.mu.Lock()
+		uc.plans[chatID] = plan
+		uc.mu.Unlock()
+	}
+
+	uc.logger.Printf("🔀 Plan for %s: %d move(s), %d unassigned", source.Email, len(plan.Moves), len(plan.Unassigned))
+	return plan, nil
+}
+
+// target is a candidate workspace with its free slot count.
+type target struct {
+	akun entity.AkunChatGPT
+	free int
+}
+
+// targets matches slot availability rows to healthy accounts, most free slots first.
+// Slot rows that don't belong to a known healthy account are skipped.
+func (uc *UseCase) targets(accounts []entity.AkunChatGPT, source *entity.AkunChatGPT, slots *entity.SlotAvailabilityResult) []target {
+	var result []target
+	for _, slot := range slots.Slots {
+		if slot.AvailableSlot <= 0 || source.MatchesOwner(slot.Name) {
+			continue
+		}
+		for i := range accounts {
+			if health, _ := accounts[i].Health(); health == entity.HealthOK && accounts[i].MatchesOwner(slot.Name) {
+				result = append(result, target{akun: accounts[i], free: slot.AvailableSlot})
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].free > result[j].free
+	})
+	return result
+}
+
+// Confirm applies the pending plan for chatID: updates each member row to its
+// target workspace and messages customers that have a WA number.
+func (uc *UseCase) Confirm(ctx context.Context, chatID string) (*entity.MigrationResult, error) {
+	uc.mu.Lock()
+	plan, ok := uc.plans[chatID]
+	delete(uc.plans, chatID)
+	uc.mu.Unlock()
+
+	if !ok || time.Since(plan.CreatedAt) > constants.MigrationPlanMinutes*time.Minute {
+		return nil, domain.ErrNoMigrationPlan
+	}
+
+	result := &entity.MigrationResult{Source: plan.Source}
+	for _, move := range plan.Moves {
+		m := move.Member
+		if err := uc.repo.UpdateMemberOwner(ctx, entity.ProductChatGPT, m.Row, m.Email, move.Target.Email); err != nil {
+			uc.logger.Printf("❌ Failed to move %s: %v", m.Email, err)
+			result.Failed = append(result.Failed, move)
+			continue
+		}
+		result.Moved = append(result.Moved, move)
+
+		if uc.notify(ctx, move) {
+			result.Notified++
+		} else {
+			result.Unnotified = append(result.Unnotified, move)
+		}
+	}
+
+	uc.logger.Printf("🔀 Migrated %s: %d moved, %d failed, %d notified",
+		plan.Source.Email, len(result.Moved), len(result.Failed), result.Notified)
+	return result, nil
+}
+
+// notify messages the customer about their new workspace.
+// Returns false if the member has no WA number or the message failed
+// (a queued message is still delivered, so it counts as notified).
+func (uc *UseCase) notify(ctx context.Context, move entity.MemberMove) bool {
+	if uc.messaging == nil || !validator.ValidatePhone(move.Member.Akun) {
+		return false
+	}
+	chatID := formatter.NormalizePhone(move.Member.Akun) + "@s.whatsapp.net"
+	if err := uc.messaging.SendTextTo(ctx, chatID, template.BuildMigrationNotice(move)); err != nil && !errors.Is(err, domain.ErrMessageQueued) {
+		uc.logger.Printf("⚠️ Failed to notify %s: %v", move.Member.Email, err)
+		return false
+	}
+	return true
+}
+
+// Cancel drops the pending plan for chatID. Returns false if there was none.
+func (uc *UseCase) Cancel(chatID string) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	_, ok := uc.plans[chatID]
+	delete(uc.plans, chatID)
+	return ok
+}
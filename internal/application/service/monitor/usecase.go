@@ -0,0 +1,258 @@
+// Package monitor implements the account health monitor for Akun Google / Akun ChatGPT.
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/logger"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// noticeKind is the notice store kind of reported issues (value = health level).
+const noticeKind = "account-health"
+
+// UseCase scans the account sheets periodically and alerts the group when an
+// account becomes banned, locked or close to its end date. Issues already
+// reported are remembered (in the notice store, so across restarts too) and the
+// group is only alerted on new/worsened issues.
+type UseCase struct {
+	accounts  usecase.AccountMonitorPort
+	notices   usecase.NoticeStorePort
+	messaging usecase.MessagingPort
+	logger    *log.Logger
+
+	interval time.Duration // Time between scans
+	warnDays int           // Expiring = ends within warnDays
+
+	mu       sync.Mutex
+	seen     map[string]entity.AccountHealth // Issue key -> last reported health
+	loaded   bool                            // seen was loaded from the notice store
+	stopChan chan struct{}
+	running  bool
+}
+
+// New creates a new account monitor use case.
+// Messaging is set later via SetMessaging (WhatsApp client is created in Run).
+func New(accounts usecase.AccountMonitorPort, notices usecase.NoticeStorePort, interval time.Duration, warnDays int) *UseCase {
+	return &UseCase{
+		accounts: accounts,
+		notices:  notices,
+		logger:   logger.Monitor,
+		interval: interval,
+		warnDays: warnDays,
+		seen:     make(map[string]entity.AccountHealth),
+	}
+}
+
+// SetMessaging sets the messaging service (must be called before Start).
+func (uc *UseCase) SetMessaging(m usecase.MessagingPort) {
+	uc.messaging = m
+}
+
+// Start runs a scan immediately and then every interval.
+func (uc *UseCase) Start() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.running {
+		return
+	}
+	uc.running = true
+	uc.stopChan = make(chan struct{})
+	stop := uc.stopChan
+
+	go func() {
+		uc.logger.Printf("🩺 Account monitor started (every %v, warn H-%d)", uc.interval, uc.warnDays)
+		ticker := time.NewTicker(uc.interval)
+		defer ticker.Stop()
+
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			if err := uc.RunOnce(ctx, time.Now()); err != nil {
+				uc.logger.Printf("❌ Account scan failed: %v", err)
+			}
+			cancel()
+
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler.
+func (uc *UseCase) Stop() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.running {
+		uc.running = false
+		close(uc.stopChan)
+		uc.logger.Println("🛑 Account monitor stopped")
+	}
+}
+
+// RunOnce scans both account sheets and alerts the group if there are new issues.
+func (uc *UseCase) RunOnce(ctx context.Context, now time.Time) error {
+	if uc.messaging == nil {
+		return fmt.Errorf("messaging not set")
+	}
+
+	if err := uc.loadSeen(ctx); err != nil {
+		return err
+	}
+
+	issues, err := uc.Scan(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	newCount := uc.markNew(ctx, issues)
+	uc.logger.Printf("🩺 Account scan: %d issue(s), %d new", len(issues), newCount)
+	if newCount == 0 {
+		return nil
+	}
+
+	if _, err := uc.messaging.SendTextToGroup(ctx, template.BuildAccountHealthAlert(issues, newCount)); err != nil && !errors.Is(err, domain.ErrMessageQueued) {
+		return fmt.Errorf("failed to send account alert: %w", err)
+	}
+	return nil
+}
+
+// Scan returns all unhealthy accounts, most urgent first
+// (banned > locked > expiring, then by paying members).
+func (uc *UseCase) Scan(ctx context.Context, now time.Time) ([]entity.AccountIssue, error) {
+	googleAccounts, err := uc.accounts.GetAkunGoogleList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Google accounts: %w", err)
+	}
+	chatgptAccounts, err := uc.accounts.GetAkunChatGPTList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ChatGPT accounts: %w", err)
+	}
+
+	var issues []entity.AccountIssue
+
+	geminiMembers := uc.memberCounts(ctx, entity.ProductGemini)
+	for i := range googleAccounts {
+		akun := &googleAccounts[i]
+		health, detail := akun.Health(now, uc.warnDays)
+		if health == entity.HealthOK {
+			continue
+		}
+		members := geminiMembers[strings.ToLower(akun.Email)]
+		if members == 0 && akun.StatusDibuat != "" {
+			members = geminiMembers[strings.ToLower(akun.StatusDibuat)]
+		}
+		issues = append(issues, entity.AccountIssue{
+			Type:    entity.AccountTypeGoogle,
+			Email:   akun.Email,
+			Name:    akun.StatusDibuat,
+			Health:  health,
+			Detail:  detail,
+			Members: members,
+		})
+	}
+
+	chatgptMembers := uc.memberCounts(ctx, entity.ProductChatGPT)
+	for i := range chatgptAccounts {
+		akun := &chatgptAccounts[i]
+		health, detail := akun.Health()
+		if health == entity.HealthOK {
+			continue
+		}
+		issues = append(issues, entity.AccountIssue{
+			Type:    entity.AccountTypeChatGPT,
+			Email:   akun.Email,
+			Name:    akun.Workspace,
+			Health:  health,
+			Detail:  detail,
+			Members: chatgptMembers[strings.ToLower(akun.Email)],
+		})
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Health != issues[j].Health {
+			return issues[i].Health > issues[j].Health
+		}
+		return issues[i].Members > issues[j].Members
+	})
+	return issues, nil
+}
+
+// memberCounts returns member counts per owner, or an empty map if the sheet can't be read
+// (the alert is still useful without counts).
+func (uc *UseCase) memberCounts(ctx context.Context, product entity.Product) map[string]int {
+	counts, err := uc.accounts.CountMembersByOwner(ctx, product)
+	if err != nil {
+		uc.logger.Printf("⚠️ Failed to count %s members: %v", product, err)
+		return map[string]int{}
+	}
+	return counts
+}
+
+// loadSeen restores the issues reported before the last restart (once).
+func (uc *UseCase) loadSeen(ctx context.Context) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.loaded {
+		return nil
+	}
+
+	stored, err := uc.notices.ListNotices(ctx, noticeKind)
+	if err != nil {
+		return fmt.Errorf("failed to load reported issues: %w", err)
+	}
+	for key, value := range stored {
+		if health, err := strconv.Atoi(value); err == nil {
+			uc.seen[key] = entity.AccountHealth(health)
+		}
+	}
+	uc.loaded = true
+	return nil
+}
+
+// markNew flags issues that are new or worse than last reported, remembers the
+// current state and forgets accounts that recovered. Returns the number of new issues.
+func (uc *UseCase) markNew(ctx context.Context, issues []entity.AccountIssue) int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	current := make(map[string]entity.AccountHealth, len(issues))
+	newCount := 0
+	for i := range issues {
+		key := issues[i].Key()
+		prev, ok := uc.seen[key]
+		if !ok || issues[i].Health > prev {
+			issues[i].IsNew = true
+			newCount++
+		}
+		current[key] = issues[i].Health
+		if !ok || issues[i].Health != prev {
+			if err := uc.notices.SetNotice(ctx, noticeKind, key, strconv.Itoa(int(issues[i].Health))); err != nil {
+				uc.logger.Printf("⚠️ Failed to record issue %s: %v", key, err)
+			}
+		}
+	}
+	for key := range uc.seen {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		if err := uc.notices.DeleteNotice(ctx, noticeKind, key); err != nil {
+			uc.logger.Printf("⚠️ Failed to forget issue %s: %v", key, err)
+		}
+	}
+	uc.seen = current
+	return newCount
+}
@@ -0,0 +1,140 @@
+// Package order implements the step-by-step #order wizard sessions.
+package order
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/logger"
+)
+
+// UseCase keeps one #order wizard session per sender and chat. Sessions live
+// in memory and end when finished, cancelled or unanswered for OrderWizardMinutes.
+type UseCase struct {
+	timeout time.Duration
+	logger  *log.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*session // sessionKey -> session
+}
+
+// session is a running wizard. mu is held while one answer is handled:
+// messages are handled concurrently, and two quick replies must not both
+// answer the same question.
+type session struct {
+	mu sync.Mutex
+	s  *entity.OrderSession
+}
+
+// New creates a new order wizard use case.
+func New() *UseCase {
+	return &UseCase{
+		timeout:  constants.OrderWizardMinutes * time.Minute,
+		logger:   logger.Order,
+		sessions: make(map[string]*session),
+	}
+}
+
+// sessionKey identifies a sender's session in a chat.
+func sessionKey(chatID, phone string) string {
+	return chatID + "|" + phone
+}
+
+// Start begins a new session, replacing a running one of the same sender and chat.
+// produk may be empty: the wizard then asks for the product first.
+// The session is returned locked; the caller must call release.
+func (uc *UseCase) Start(chatID, phone string, produk entity.Product, defaultKanal string) (s *entity.OrderSession, release func()) {
+	s = &entity.OrderSession{
+		ChatID:       chatID,
+		SenderPhone:  phone,
+		Cmd:          &entity.QrisCommand{IsFormMode: true, Kanal: defaultKanal},
+		ProductAsked: produk == "",
+		DefaultKanal: defaultKanal,
+		UpdatedAt:    time.Now(),
+	}
+	if produk != "" {
+		s.SetProduct(produk)
+	}
+
+	running := &session{s: s}
+	running.mu.Lock()
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.sweep()
+	uc.sessions[sessionKey(chatID, phone)] = running
+	uc.logger.Printf("📝 Order wizard started: %s in %s (%s)", phone, chatID, produk)
+	return s, running.mu.Unlock
+}
+
+// Get returns the running session of a sender in a chat and extends its timeout.
+// The session is returned locked, so the answer can be applied and the wizard
+// advanced before the next message of the sender; the caller must call release.
+// Returns nil if there is none (or it ended while waiting for the lock), or
+// ErrOrderSessionExpired (once) if it timed out.
+func (uc *UseCase) Get(chatID, phone string) (s *entity.OrderSession, release func(), err error) {
+	key := sessionKey(chatID, phone)
+
+	uc.mu.Lock()
+	running, ok := uc.sessions[key]
+	if !ok {
+		uc.mu.Unlock()
+		return nil, nil, nil
+	}
+	if time.Since(running.s.UpdatedAt) > uc.timeout {
+		delete(uc.sessions, key)
+		uc.mu.Unlock()
+		return nil, nil, domain.ErrOrderSessionExpired
+	}
+	uc.mu.Unlock()
+
+	running.mu.Lock()
+
+	// The previous answer may have finished, cancelled or restarted the wizard
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.sessions[key] != running {
+		running.mu.Unlock()
+		return nil, nil, nil
+	}
+	running.s.UpdatedAt = time.Now()
+	return running.s, running.mu.Unlock, nil
+}
+
+// End removes the session of a sender in a chat. Returns false if there was none.
+func (uc *UseCase) End(chatID, phone string) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	key := sessionKey(chatID, phone)
+	if _, ok := uc.sessions[key]; !ok {
+		return false
+	}
+	delete(uc.sessions, key)
+	return true
+}
+
+// Finish removes a session returned by Get, unless it was replaced by a new #order.
+func (uc *UseCase) Finish(s *entity.OrderSession) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	key := sessionKey(s.ChatID, s.SenderPhone)
+	if running, ok := uc.sessions[key]; ok && running.s == s {
+		delete(uc.sessions, key)
+	}
+}
+
+// sweep drops sessions that timed out without another message (caller holds mu).
+// A session locked by its handler may be dropped too; Get then ignores it.
+func (uc *UseCase) sweep() {
+	for key, running := range uc.sessions {
+		if time.Since(running.s.UpdatedAt) > uc.timeout {
+			delete(uc.sessions, key)
+		}
+	}
+}
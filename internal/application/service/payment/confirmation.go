@@ -2,8 +2,14 @@ package payment
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/exernia/botjanweb/internal/domain"
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"github.com/exernia/botjanweb/pkg/helper/validator"
 	"github.com/exernia/botjanweb/pkg/logger"
 	"github.com/exernia/botjanweb/presentation/template"
 )
@@ -16,6 +22,7 @@ var confirmationLogger = logger.Confirmation
 type ConfirmationService struct {
 	notifier NotificationPort
 	sheets   SheetsPort
+	stock    RedeemStockPort // Optional low-stock check after claims
 }
 
 // NewConfirmationService creates a new payment confirmation service.
@@ -26,6 +33,11 @@ func NewConfirmationService(notifier NotificationPort, sheets SheetsPort) *Confi
 	}
 }
 
+// SetRedeemStock sets the redeem code stock check run after each claim.
+func (s *ConfirmationService) SetRedeemStock(stock RedeemStockPort) {
+	s.stock = stock
+}
+
 // ConfirmPayment handles the complete payment confirmation workflow.
 // This is called when a payment is matched with a pending QRIS.
 func (s *ConfirmationService) ConfirmPayment(ctx context.Context, pending *entity.PendingPayment, notif *entity.DANANotification) error {
@@ -52,8 +64,11 @@ func (s *ConfirmationService) ConfirmPayment(ctx context.Context, pending *entit
 		}
 	}
 
-	// 4. Save order to Google Sheets (if enabled and valid)
-	if err := s.saveOrderToSheets(ctx, pending); err != nil {
+	// 4. Claim redeem code (for Perplexity only)
+	redeemCode := s.claimRedeemCode(ctx, pending)
+
+	// 5. Save order to Google Sheets (if enabled and valid)
+	if err := s.saveOrderToSheets(ctx, pending, redeemCode); err != nil {
 		confirmationLogger.Printf("❌ Failed to save to Sheets: %v", err)
 		// Send error notification
 		s.notifySheetError(ctx, pending, err.Error())
@@ -63,6 +78,18 @@ func (s *ConfirmationService) ConfirmPayment(ctx context.Context, pending *entit
 		s.notifySheetSuccess(ctx, pending)
 	}
 
+	// 6. Deliver redeem code privately (for Perplexity only)
+	if redeemCode != nil {
+		if err := s.deliverRedeemCode(ctx, pending, redeemCode); err != nil {
+			confirmationLogger.Printf("❌ Failed to deliver redeem code: %v", err)
+		}
+	}
+
+	// 7. Count voucher usage (only confirmed payments consume quota)
+	if pending.Voucher != "" {
+		s.recordVoucherUsage(ctx, pending, notif)
+	}
+
 	return nil
 }
 
@@ -70,7 +97,12 @@ func (s *ConfirmationService) ConfirmPayment(ctx context.Context, pending *entit
 func (s *ConfirmationService) sendPaymentConfirmation(ctx context.Context, pending *entity.PendingPayment, notif *entity.DANANotification) error {
 	confirmMsg := template.BuildPaymentConfirmation(pending, notif)
 
-	if err := s.notifier.SendPaymentConfirmation(ctx, pending, confirmMsg); err != nil {
+	err := s.notifier.SendPaymentConfirmation(ctx, pending, confirmMsg)
+	if errors.Is(err, domain.ErrMessageQueued) {
+		confirmationLogger.Printf("⏳ Confirmation to %s queued, not sent yet", pending.ChatID)
+		return nil
+	}
+	if err != nil {
 		return err
 	}
 
@@ -84,7 +116,12 @@ func (s *ConfirmationService) revokeQRISImage(ctx context.Context, pending *enti
 		return nil
 	}
 
-	if err := s.notifier.RevokeQRISImage(ctx, pending.ChatID, pending.MessageID); err != nil {
+	err := s.notifier.RevokeQRISImage(ctx, pending.QrisChatID(), pending.MessageID)
+	if errors.Is(err, domain.ErrMessageQueued) {
+		confirmationLogger.Printf("⏳ Revoke of QRIS image %s queued, not sent yet", pending.MessageID)
+		return nil
+	}
+	if err != nil {
 		return err
 	}
 
@@ -96,7 +133,12 @@ func (s *ConfirmationService) revokeQRISImage(ctx context.Context, pending *enti
 func (s *ConfirmationService) sendGroupNotification(ctx context.Context, pending *entity.PendingPayment, amount int) error {
 	groupNotif := template.BuildSelfQrisPaymentNotification(pending, amount)
 
-	if err := s.notifier.SendGroupNotification(ctx, groupNotif, pending.GroupNotifMsgID); err != nil {
+	err := s.notifier.SendGroupNotification(ctx, pending.GroupJID, groupNotif, pending.GroupNotifMsgID)
+	if errors.Is(err, domain.ErrMessageQueued) {
+		confirmationLogger.Printf("⏳ Self-QRIS payment notification queued, not sent yet")
+		return nil
+	}
+	if err != nil {
 		return err
 	}
 
@@ -108,8 +150,92 @@ func (s *ConfirmationService) sendGroupNotification(ctx context.Context, pending
 	return nil
 }
 
+// claimRedeemCode claims the next available redeem code for redeem-code products (Perplexity).
+// Returns nil for other products or if no code could be claimed (group is notified).
+func (s *ConfirmationService) claimRedeemCode(ctx context.Context, pending *entity.PendingPayment) *entity.RedeemCodeInfo {
+	if s.sheets == nil || !entity.Product(pending.Produk).Info().RedeemCode {
+		return nil
+	}
+
+	code, err := s.sheets.ClaimRedeemCode(ctx, pending.Email)
+	if err != nil {
+		confirmationLogger.Printf("❌ Failed to claim redeem code: %v", err)
+		alert := template.BuildRedeemCodeUnavailableNotification(pending, err.Error())
+		if err := s.notifier.SendGroupNotification(ctx, pending.GroupJID, alert, pending.GroupNotifMsgID); err != nil {
+			confirmationLogger.Printf("⚠️ Failed to send redeem code alert to group: %v", err)
+		}
+		return nil
+	}
+
+	confirmationLogger.Printf("🎫 Redeem code claimed for %s (row %d)", pending.Email, code.No)
+	if s.stock != nil {
+		s.stock.CheckStock(ctx)
+	}
+	return code
+}
+
+// deliverRedeemCode sends the claimed redeem code to the customer privately.
+// Self-QRIS: the customer chat. Group order: the Akun field if it is a phone number,
+// otherwise the sender who created the QRIS.
+func (s *ConfirmationService) deliverRedeemCode(ctx context.Context, pending *entity.PendingPayment, code *entity.RedeemCodeInfo) error {
+	recipient := pending.SenderJID
+	if pending.IsSelfQris {
+		recipient = pending.ChatID
+	} else if validator.ValidatePhone(pending.Akun) {
+		recipient = formatter.NormalizePhone(pending.Akun)
+	}
+	if recipient == "" {
+		return fmt.Errorf("no recipient for redeem code (row %d)", code.No)
+	}
+
+	err := s.notifier.SendDirectMessage(ctx, recipient, template.BuildRedeemCodeDelivery(pending, code))
+	switch {
+	case errors.Is(err, domain.ErrMessageQueued):
+		confirmationLogger.Printf("⏳ Redeem code to %s queued, not sent yet", recipient)
+	case err != nil:
+		return fmt.Errorf("failed to send redeem code to %s: %w", recipient, err)
+	default:
+		confirmationLogger.Printf("🎫 Redeem code sent to %s", recipient)
+	}
+
+	notice := template.BuildRedeemCodeSentNotification(pending, code, recipient)
+	if err := s.notifier.SendGroupNotification(ctx, pending.GroupJID, notice, pending.GroupNotifMsgID); err != nil {
+		confirmationLogger.Printf("⚠️ Failed to send redeem code notice to group: %v", err)
+	}
+	return nil
+}
+
+// recordVoucherUsage records the confirmed voucher use. On failure the group is
+// asked to record it manually so quota and per-customer limits stay correct.
+func (s *ConfirmationService) recordVoucherUsage(ctx context.Context, pending *entity.PendingPayment, notif *entity.DANANotification) {
+	if s.sheets == nil {
+		return
+	}
+
+	usage := &entity.VoucherUsage{
+		Kode:     pending.Voucher,
+		Email:    pending.Email,
+		Produk:   pending.Produk,
+		Nama:     pending.Nama,
+		Amount:   notif.Amount,
+		Discount: pending.Discount,
+		Tanggal:  notif.Timestamp,
+	}
+	if err := s.sheets.RecordVoucherUsage(ctx, usage); err != nil {
+		confirmationLogger.Printf("❌ Failed to record voucher usage: %v", err)
+		alert := template.BuildVoucherUsageErrorNotification(pending, err.Error())
+		if err := s.notifier.SendGroupNotification(ctx, pending.GroupJID, alert, pending.GroupNotifMsgID); err != nil {
+			confirmationLogger.Printf("⚠️ Failed to send voucher alert to group: %v", err)
+		}
+		return
+	}
+
+	confirmationLogger.Printf("🎟️ Voucher %s recorded for %s", pending.Voucher, pending.Email)
+}
+
 // saveOrderToSheets saves order to Google Sheets if enabled and valid.
-func (s *ConfirmationService) saveOrderToSheets(ctx context.Context, pending *entity.PendingPayment) error {
+// redeemCode is written into the order row for redeem-based products (may be nil).
+func (s *ConfirmationService) saveOrderToSheets(ctx context.Context, pending *entity.PendingPayment, redeemCode *entity.RedeemCodeInfo) error {
 	// Skip if Sheets not enabled or no product data
 	if s.sheets == nil || pending.Produk == "" {
 		return nil
@@ -121,8 +247,18 @@ func (s *ConfirmationService) saveOrderToSheets(ctx context.Context, pending *en
 		return nil
 	}
 
+	// Renewal: extend the existing member row instead of adding a new one
+	if pending.RenewalRow > 0 {
+		days := entity.Product(pending.Produk).Info().SubscriptionDays(pending.Paket)
+		end := entity.RenewalEnd(pending.ExpiresAt, time.Now(), days)
+		return s.sheets.ExtendSubscription(ctx, pending.Produk, pending.RenewalRow, pending.Email, end)
+	}
+
 	// Create order and save
 	order := entity.NewOrderFromPending(pending)
+	if redeemCode != nil {
+		order.KodeRedeem = redeemCode.KodeRedeem
+	}
 	if err := s.sheets.LogOrder(ctx, order); err != nil {
 		return err
 	}
@@ -145,7 +281,7 @@ func (s *ConfirmationService) notifySheetError(ctx context.Context, pending *ent
 		confirmationLogger.Printf("📢 Error notification sent to group")
 	}
 
-	if err := s.notifier.SendGroupNotification(ctx, errorNotif, replyToMsgID); err != nil {
+	if err := s.notifier.SendGroupNotification(ctx, pending.GroupJID, errorNotif, replyToMsgID); err != nil {
 		confirmationLogger.Printf("⚠️ Failed to send error notification to group: %v", err)
 	}
 
@@ -3,6 +3,7 @@ package payment
 
 import (
 	"context"
+	"time"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
 )
@@ -17,12 +18,30 @@ type NotificationPort interface {
 	// RevokeQRISImage revokes (deletes) the QRIS image message
 	RevokeQRISImage(ctx context.Context, chatID, messageID string) error
 
-	// SendGroupNotification sends notification to group
-	SendGroupNotification(ctx context.Context, message string, replyToMessageID string) error
+	// SendGroupNotification sends notification to a group (empty groupJID = payment group)
+	SendGroupNotification(ctx context.Context, groupJID, message, replyToMessageID string) error
+
+	// SendDirectMessage sends a private message to a recipient (chat JID or phone number)
+	SendDirectMessage(ctx context.Context, recipient, message string) error
 }
 
 // SheetsPort defines the interface for Google Sheets operations.
 type SheetsPort interface {
 	// LogOrder saves order to Google Sheets
 	LogOrder(ctx context.Context, order *entity.Order) error
+
+	// ClaimRedeemCode claims the next available redeem code for a customer
+	ClaimRedeemCode(ctx context.Context, customerEmail string) (*entity.RedeemCodeInfo, error)
+
+	// RecordVoucherUsage records a confirmed voucher use and increments its usage count
+	RecordVoucherUsage(ctx context.Context, usage *entity.VoucherUsage) error
+
+	// ExtendSubscription updates Tanggal Berakhir of an existing member row (renewals)
+	ExtendSubscription(ctx context.Context, produk string, row int, email string, end time.Time) error
+}
+
+// RedeemStockPort checks the redeem code stock after a code was claimed.
+type RedeemStockPort interface {
+	// CheckStock alerts the group if available codes fell below the threshold
+	CheckStock(ctx context.Context)
 }
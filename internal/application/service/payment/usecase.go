@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/exernia/botjanweb/pkg/constants"
@@ -21,18 +22,102 @@ var danaAmountRegex = regexp.MustCompile(`Rp\s?([\d.]+)`)
 // UseCase implements PaymentUseCase.
 type UseCase struct {
 	store usecase.PendingStorePort
+
+	// #qris messages that got a QRIS, kept while they can still be edited
+	mu     sync.Mutex
+	issued map[string]time.Time
+
+	// Amounts handed out by ReserveAmount whose QRIS is not registered yet
+	reserved map[int]time.Time
 }
 
 // New creates a new payment use case.
 func New(store usecase.PendingStorePort) *UseCase {
 	return &UseCase{
-		store: store,
+		store:    store,
+		issued:   make(map[string]time.Time),
+		reserved: make(map[int]time.Time),
 	}
 }
 
 // RegisterPending adds a pending payment to the store.
 func (uc *UseCase) RegisterPending(pending *entity.PendingPayment) {
 	uc.store.Add(pending)
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	now := time.Now()
+	uc.sweep(now)
+	uc.issued[issuedKey(pending.ChatID, pending.OriginalMessageID)] = now
+	delete(uc.reserved, pending.Amount)
+}
+
+// ReserveAmount returns base plus the smallest unique code (0..maxCode) that
+// no unpaid QRIS waits for. Payments are matched by amount only, so a shared
+// amount would confirm the wrong order. The amount stays reserved until its
+// QRIS is registered (or AmountReservationMinutes pass).
+func (uc *UseCase) ReserveAmount(base, maxCode int) (int, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-constants.AmountReservationMinutes * time.Minute)
+	for amount, at := range uc.reserved {
+		if at.Before(cutoff) {
+			delete(uc.reserved, amount)
+		}
+	}
+
+	for code := 0; code <= maxCode; code++ {
+		amount := base + code
+		if _, taken := uc.reserved[amount]; taken || uc.store.HasAmount(amount) {
+			continue
+		}
+		uc.reserved[amount] = now
+		return amount, nil
+	}
+	return 0, domain.ErrAmountInUse
+}
+
+// FindPendingForMessage returns the unpaid QRIS created by a #qris message.
+// issued reports whether the message got a QRIS within the edit window, so a
+// missing pending means it was already paid rather than never created.
+func (uc *UseCase) FindPendingForMessage(chatID, messageID string) (pending *entity.PendingPayment, issued bool) {
+	if pending = uc.store.FindByOriginal(chatID, messageID); pending != nil {
+		return pending, true
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.sweep(time.Now())
+	_, issued = uc.issued[issuedKey(chatID, messageID)]
+	return nil, issued
+}
+
+// CancelPending removes an unpaid QRIS so it can no longer be matched.
+// Returns nil if it was paid (matched) in the meantime.
+func (uc *UseCase) CancelPending(pending *entity.PendingPayment) *entity.PendingPayment {
+	return uc.store.Remove(pending.MessageID)
+}
+
+// sweep forgets messages that can no longer be edited. Caller holds mu.
+func (uc *UseCase) sweep(now time.Time) {
+	cutoff := now.Add(-constants.MessageEditWindowMinutes * time.Minute)
+	for key, at := range uc.issued {
+		if at.Before(cutoff) {
+			delete(uc.issued, key)
+		}
+	}
+}
+
+// issuedKey identifies a #qris message.
+func issuedKey(chatID, messageID string) string {
+	return chatID + "/" + messageID
+}
+
+// FindPendingByEmail returns pending payments for a customer email.
+func (uc *UseCase) FindPendingByEmail(email string) []*entity.PendingPayment {
+	return uc.store.FindByEmail(email)
 }
 
 // GetPendingCount returns total pending payments.
@@ -3,6 +3,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
 )
@@ -19,6 +20,9 @@ type QrisUseCase interface {
 type PaymentUseCase interface {
 	// RegisterPending adds a pending payment to the store.
 	RegisterPending(pending *entity.PendingPayment)
+	// ReserveAmount returns base plus the smallest unique code (0..maxCode) no
+	// other unpaid QRIS waits for, held until the QRIS is registered.
+	ReserveAmount(base, maxCode int) (int, error)
 	// GetPendingCount returns total pending payments.
 	GetPendingCount() int
 }
@@ -31,6 +35,8 @@ type MessagingPort interface {
 	SendText(ctx context.Context, text string) error
 	// SendTextTo sends a text message to a specific chat.
 	SendTextTo(ctx context.Context, chatID string, text string) error
+	// SendSensitiveTextTo sends a text message that is not persisted in the outbound queue.
+	SendSensitiveTextTo(ctx context.Context, chatID string, text string) error
 	// SendTextReply sends a reply to a specific message.
 	SendTextReply(ctx context.Context, chatID, text, quotedMsgID, quotedSenderID string) error
 	// SendImage sends an image with caption, returns message ID.
@@ -39,10 +45,16 @@ type MessagingPort interface {
 	SendImageTo(ctx context.Context, chatID string, imageData []byte, caption string) (string, error)
 	// SendTextToGroup sends a text message to the configured group, returns message ID.
 	SendTextToGroup(ctx context.Context, text string) (string, error)
+	// SendTextToGroupJID sends a text message to a specific group, returns message ID.
+	SendTextToGroupJID(ctx context.Context, groupJID, text string) (string, error)
 	// SendTextReplyToGroup sends a reply to a specific message in the configured group.
 	SendTextReplyToGroup(ctx context.Context, text, quotedMsgID string) error
 	// SendImageToGroup sends an image to the configured group.
 	SendImageToGroup(ctx context.Context, imageData []byte, caption string) (string, error)
+	// SendDocument sends a file as a document message to a specific chat, returns message ID.
+	SendDocument(ctx context.Context, chatID string, data []byte, fileName, mimeType, caption string) (string, error)
+	// RevokeMessage deletes a message for everyone (senderID empty for the bot's own messages).
+	RevokeMessage(ctx context.Context, chatID, senderID, messageID string) error
 	// GetOwnID returns the bot's own ID.
 	GetOwnID() string
 	// GetGroupJID returns the configured group JID.
@@ -63,6 +75,14 @@ type PendingStorePort interface {
 	Add(p *entity.PendingPayment)
 	// Match finds and removes a pending payment by amount (FIFO).
 	Match(amount int) *entity.PendingPayment
+	// HasAmount reports whether an unpaid QRIS already waits for this amount.
+	HasAmount(amount int) bool
+	// FindByEmail returns pending payments for a customer email (not removed).
+	FindByEmail(email string) []*entity.PendingPayment
+	// FindByOriginal returns the pending payment created by a #qris message (not removed).
+	FindByOriginal(chatID, originalMessageID string) *entity.PendingPayment
+	// Remove removes a pending payment by its QRIS message ID (nil if already matched).
+	Remove(messageID string) *entity.PendingPayment
 	// Count returns total pending payments.
 	Count() int
 	// StartCleanup starts background cleanup routine.
@@ -79,22 +99,103 @@ type TransactionLogPort interface {
 	LogOrder(ctx context.Context, order *entity.Order) error
 }
 
-// FamilyValidatorPort defines family validation operations.
-type FamilyValidatorPort interface {
-	// ValidateFamily checks if a family email exists in Akun Google sheet.
-	// Returns true if found, false otherwise.
-	ValidateFamily(ctx context.Context, familyEmail string) (bool, error)
-	// CountFamilySlots counts how many slots are used for a family in Gemini sheet.
-	CountFamilySlots(ctx context.Context, family string) (int, error)
+// SlotOwnerPort defines slot owner lookups (family, workspace, head) for any product with slots.
+// Sheets and columns come from the product registry.
+type SlotOwnerPort interface {
+	// SlotOwnerExists checks if the slot owner is listed in the product's account sheet.
+	SlotOwnerExists(ctx context.Context, product entity.Product, owner string) (bool, error)
+	// CountSlots counts how many slots are used for the owner in the product sheet.
+	CountSlots(ctx context.Context, product entity.Product, owner string) (int, error)
 }
 
-// WorkspaceValidatorPort defines workspace validation operations for ChatGPT.
-type WorkspaceValidatorPort interface {
-	// ValidateWorkspaceEmail checks if workspace owner email exists in Akun ChatGPT sheet (column A).
-	// Returns true if found, false otherwise.
-	ValidateWorkspaceEmail(ctx context.Context, ownerEmail string) (bool, error)
-	// CountWorkspaceSlots counts how many slots are used for an owner email in ChatGPT sheet (column D).
-	CountWorkspaceSlots(ctx context.Context, ownerEmail string) (int, error)
+// CatalogPort defines price list loading operations.
+type CatalogPort interface {
+	// GetCatalog returns all price list entries (from Harga sheet or catalog file).
+	GetCatalog(ctx context.Context) ([]entity.CatalogItem, error)
+}
+
+// SlotLimitPort provides per-product slot limits (e.g. from the catalog).
+type SlotLimitPort interface {
+	// SlotLimit returns the slot limit for a product, or fallback if not configured.
+	SlotLimit(ctx context.Context, product entity.Product, fallback int) int
+}
+
+// SlotCapacityPort resolves the slot limit per owner (catalog override or product default).
+type SlotCapacityPort interface {
+	// SlotCapacity returns how many members one family/workspace/head of the product holds.
+	SlotCapacity(ctx context.Context, product entity.Product) int
+}
+
+// VoucherPort defines voucher lookup operations.
+type VoucherPort interface {
+	// GetVouchers returns all vouchers (from Voucher sheet).
+	GetVouchers(ctx context.Context) ([]entity.Voucher, error)
+	// CountVoucherUsage counts confirmed uses of a voucher by a customer email.
+	CountVoucherUsage(ctx context.Context, kode, email string) (int, error)
+}
+
+// PriceLookupPort finds the list price for a product and paket.
+type PriceLookupPort interface {
+	// Find returns the catalog entry for a product/paket, or nil if not listed.
+	Find(ctx context.Context, product entity.Product, paket string) (*entity.CatalogItem, error)
+}
+
+// SubscriptionPort defines member end date lookups.
+type SubscriptionPort interface {
+	// ListSubscriptions returns all members with a Tanggal Berakhir (all product sheets).
+	ListSubscriptions(ctx context.Context) ([]entity.Subscription, error)
+}
+
+// AccountMonitorPort defines account sheet reads for the account health monitor.
+type AccountMonitorPort interface {
+	// GetAkunGoogleList fetches all Google accounts from Akun Google sheet.
+	GetAkunGoogleList(ctx context.Context) ([]entity.AkunGoogle, error)
+	// GetAkunChatGPTList fetches all ChatGPT accounts from Akun ChatGPT sheet.
+	GetAkunChatGPTList(ctx context.Context) ([]entity.AkunChatGPT, error)
+	// CountMembersByOwner counts member rows per slot owner in a product sheet.
+	CountMembersByOwner(ctx context.Context, product entity.Product) (map[string]int, error)
+}
+
+// MigrationPort defines the sheet operations for moving members off a banned workspace.
+type MigrationPort interface {
+	// GetAkunChatGPTList fetches all ChatGPT accounts from Akun ChatGPT sheet.
+	GetAkunChatGPTList(ctx context.Context) ([]entity.AkunChatGPT, error)
+	// GetSlotAvailability returns slot availability for families/workspaces holding maxSlots each.
+	GetSlotAvailability(ctx context.Context, product string, maxSlots int, availableOnly bool) (*entity.SlotAvailabilityResult, error)
+	// ListMembers returns all member rows of a product sheet.
+	ListMembers(ctx context.Context, product entity.Product) ([]entity.WorkspaceMember, error)
+	// UpdateMemberOwner writes a new owner (column D) for a member row.
+	UpdateMemberOwner(ctx context.Context, product entity.Product, row int, email, owner string) error
+}
+
+// MemberLookupPort reads member rows from the product sheets.
+type MemberLookupPort interface {
+	// ListMembers returns all member rows of a product sheet.
+	ListMembers(ctx context.Context, product entity.Product) ([]entity.WorkspaceMember, error)
+}
+
+// PendingLookupPort finds unpaid QRIS by customer email.
+type PendingLookupPort interface {
+	// FindPendingByEmail returns pending payments for a customer email.
+	FindPendingByEmail(email string) []*entity.PendingPayment
+}
+
+// ExportPort defines the sheet reads used by #export.
+type ExportPort interface {
+	// ListOrders returns all order rows of a product sheet.
+	ListOrders(ctx context.Context, product entity.Product) ([]entity.Order, error)
+	// GetSlotAvailability returns slot availability for families/workspaces holding maxSlots each.
+	GetSlotAvailability(ctx context.Context, product string, maxSlots int, availableOnly bool) (*entity.SlotAvailabilityResult, error)
+	// GetRedeemCodeAvailability returns Perplexity redeem codes.
+	GetRedeemCodeAvailability(ctx context.Context, availableOnly bool) (*entity.RedeemCodeResult, error)
+	// GetAccountListResult fetches all accounts and returns a summary with availability counts.
+	GetAccountListResult(ctx context.Context) (*entity.AccountListResult, error)
+}
+
+// AlertPort sends operator alerts over a channel independent of WhatsApp (webhook, email).
+type AlertPort interface {
+	// SendAlert delivers an alert with a short subject and a plain-text body.
+	SendAlert(ctx context.Context, subject, body string) error
 }
 
 // AccountRepositoryPort defines account management operations.
@@ -103,19 +204,61 @@ type AccountRepositoryPort interface {
 	AddAkunGoogle(ctx context.Context, akun *entity.AkunGoogle) error
 	// AddAkunChatGPT adds a new ChatGPT account to Akun ChatGPT sheet.
 	AddAkunChatGPT(ctx context.Context, akun *entity.AkunChatGPT) error
+	// AddAkunGoogleBatch adds several Google accounts in a single sheet update.
+	AddAkunGoogleBatch(ctx context.Context, akuns []entity.AkunGoogle) error
+	// AddAkunChatGPTBatch adds several ChatGPT accounts in a single sheet update.
+	AddAkunChatGPTBatch(ctx context.Context, akuns []entity.AkunChatGPT) error
 	// GetAccountListResult fetches all accounts and returns a summary with availability counts.
 	GetAccountListResult(ctx context.Context) (*entity.AccountListResult, error)
+	// GetAccountCredential finds an account by email and returns it with the password decrypted.
+	GetAccountCredential(ctx context.Context, email string) (*entity.AccountCredential, error)
 }
 
 // InventoryPort defines inventory checking operations.
 type InventoryPort interface {
 	// GetSlotAvailability returns slot availability for families/workspaces.
 	// product: "ChatGPT" or "Gemini"
+	// maxSlots: slot limit per owner (see SlotCapacityPort)
 	// availableOnly: if true, only return items with available slots
-	GetSlotAvailability(ctx context.Context, product string, availableOnly bool) (*entity.SlotAvailabilityResult, error)
+	GetSlotAvailability(ctx context.Context, product string, maxSlots int, availableOnly bool) (*entity.SlotAvailabilityResult, error)
 	// GetRedeemCodeAvailability returns available Perplexity redeem codes.
 	// availableOnly: if true, only return codes not yet activated
 	GetRedeemCodeAvailability(ctx context.Context, availableOnly bool) (*entity.RedeemCodeResult, error)
 	// AddRedeemCode adds a new redeem code to Kode Perplexity sheet.
 	AddRedeemCode(ctx context.Context, email, kodeRedeem string) error
+	// AddRedeemCodes adds several redeem codes (Email + KodeRedeem) in a single sheet update.
+	AddRedeemCodes(ctx context.Context, codes []entity.RedeemCodeInfo) error
+	// AssignRedeemCode marks a code as used by a customer (activation date + customer).
+	AssignRedeemCode(ctx context.Context, kode, customer string, activated time.Time) (*entity.RedeemCodeInfo, error)
+	// SetRedeemCodeDates sets the activation and end date of a code (zero end = unchanged).
+	SetRedeemCodeDates(ctx context.Context, kode string, activated, ends time.Time) (*entity.RedeemCodeInfo, error)
+	// MarkRedeemCodeInvalid flags a code as invalid so it is never claimed.
+	MarkRedeemCodeInvalid(ctx context.Context, kode, reason string) (*entity.RedeemCodeInfo, error)
+	// ReleaseRedeemCode clears usage, dates and flags so the code is available again.
+	ReleaseRedeemCode(ctx context.Context, kode string) (*entity.RedeemCodeInfo, error)
+	// ListMembers returns all member rows of a product sheet.
+	ListMembers(ctx context.Context, product entity.Product) ([]entity.WorkspaceMember, error)
+}
+
+// RoleStorePort persists role assignments (PostgreSQL or in-memory).
+type RoleStorePort interface {
+	// GetRole returns the assignment of a normalized phone, or nil if none.
+	GetRole(ctx context.Context, phone string) (*entity.RoleAssignment, error)
+	// SetRole creates or replaces an assignment.
+	SetRole(ctx context.Context, a entity.RoleAssignment) error
+	// DeleteRole removes an assignment (no error if missing).
+	DeleteRole(ctx context.Context, phone string) error
+	// ListRoles returns all assignments.
+	ListRoles(ctx context.Context) ([]entity.RoleAssignment, error)
+}
+
+// NoticeStorePort remembers which reminders and alerts were sent, so a restart
+// doesn't repeat them (PostgreSQL or in-memory).
+type NoticeStorePort interface {
+	// ListNotices returns the recorded keys of a kind with their values.
+	ListNotices(ctx context.Context, kind string) (map[string]string, error)
+	// SetNotice records or replaces a key.
+	SetNotice(ctx context.Context, kind, key, value string) error
+	// DeleteNotice forgets a key (no error if missing).
+	DeleteNotice(ctx context.Context, kind, key string) error
 }
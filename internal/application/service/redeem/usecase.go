@@ -0,0 +1,128 @@
+// Package redeem implements the Perplexity redeem code lifecycle (#kode) and low-stock alerts.
+package redeem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/logger"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// UseCase updates redeem codes in the Kode Perplexity sheet and alerts the
+// group once when the available stock falls below the threshold. The alert
+// is re-armed after the stock is back at or above the threshold.
+type UseCase struct {
+	inventory usecase.InventoryPort
+	messaging usecase.MessagingPort
+	logger    *log.Logger
+
+	threshold int // Alert when available codes < threshold (0 = disabled)
+
+	mu      sync.Mutex
+	alerted bool // Low-stock alert already sent for the current shortage
+}
+
+// New creates a new redeem code use case.
+// Messaging is set later via SetMessaging (WhatsApp client is created in Run).
+func New(inventory usecase.InventoryPort, threshold int) *UseCase {
+	return &UseCase{
+		inventory: inventory,
+		logger:    logger.Redeem,
+		threshold: threshold,
+	}
+}
+
+// SetMessaging sets the messaging service used for low-stock alerts.
+func (uc *UseCase) SetMessaging(m usecase.MessagingPort) {
+	uc.messaging = m
+}
+
+// Assign marks a code as used by a customer today and checks the stock.
+func (uc *UseCase) Assign(ctx context.Context, kode, customer string) (*entity.RedeemCodeInfo, error) {
+	code, err := uc.inventory.AssignRedeemCode(ctx, kode, customer, time.Now())
+	if err != nil {
+		return code, err
+	}
+	uc.CheckStock(ctx)
+	return code, nil
+}
+
+// SetDates sets the activation and (optional) end date of a code.
+func (uc *UseCase) SetDates(ctx context.Context, kode string, activated, ends time.Time) (*entity.RedeemCodeInfo, error) {
+	code, err := uc.inventory.SetRedeemCodeDates(ctx, kode, activated, ends)
+	if err != nil {
+		return code, err
+	}
+	uc.CheckStock(ctx)
+	return code, nil
+}
+
+// MarkInvalid flags a code as invalid and checks the stock.
+func (uc *UseCase) MarkInvalid(ctx context.Context, kode, reason string) (*entity.RedeemCodeInfo, error) {
+	code, err := uc.inventory.MarkRedeemCodeInvalid(ctx, kode, reason)
+	if err != nil {
+		return code, err
+	}
+	uc.CheckStock(ctx)
+	return code, nil
+}
+
+// Release makes a code available again.
+func (uc *UseCase) Release(ctx context.Context, kode string) (*entity.RedeemCodeInfo, error) {
+	code, err := uc.inventory.ReleaseRedeemCode(ctx, kode)
+	if err != nil {
+		return code, err
+	}
+	uc.CheckStock(ctx)
+	return code, nil
+}
+
+// CheckStock reads the available code count and alerts the group if it fell
+// below the threshold. Errors are logged only (stock checks never block orders).
+func (uc *UseCase) CheckStock(ctx context.Context) {
+	if uc.threshold <= 0 {
+		return
+	}
+
+	result, err := uc.inventory.GetRedeemCodeAvailability(ctx, true)
+	if err != nil {
+		uc.logger.Printf("⚠️ Failed to check redeem code stock: %v", err)
+		return
+	}
+
+	if err := uc.alertIfLow(ctx, result.AvailableCodes); err != nil {
+		uc.logger.Printf("⚠️ Failed to send low-stock alert: %v", err)
+	}
+}
+
+// alertIfLow sends the low-stock alert once per shortage.
+func (uc *UseCase) alertIfLow(ctx context.Context, available int) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if available >= uc.threshold {
+		uc.alerted = false
+		return nil
+	}
+	if uc.alerted {
+		return nil
+	}
+	if uc.messaging == nil {
+		return fmt.Errorf("messaging not set")
+	}
+
+	if _, err := uc.messaging.SendTextToGroup(ctx, template.BuildRedeemLowStockAlert(available, uc.threshold)); err != nil && !errors.Is(err, domain.ErrMessageQueued) {
+		return err
+	}
+	uc.alerted = true
+	uc.logger.Printf("📉 Low redeem code stock: %d available (threshold %d)", available, uc.threshold)
+	return nil
+}
@@ -0,0 +1,283 @@
+// Package renewal implements subscription expiry reminders with renewal QRIS.
+package renewal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"github.com/exernia/botjanweb/pkg/helper/validator"
+	"github.com/exernia/botjanweb/pkg/logger"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// Notice store records of sent reminders (value = end date).
+const (
+	noticeKind       = "renewal"
+	noticeDateLayout = "2006-01-02"
+)
+
+// UseCase sends renewal reminders to customers whose subscription ends within
+// ReminderDays days, each with a ready-to-pay QRIS, plus a daily group digest.
+// Sent reminders are recorded, so each end date is reminded once even if the job
+// missed a day. Paying the renewal QRIS extends the existing member row (see
+// ConfirmationService).
+type UseCase struct {
+	subscriptions usecase.SubscriptionPort
+	prices        usecase.PriceLookupPort // Optional: nil = reminders without QRIS
+	qris          usecase.QrisUseCase
+	payments      usecase.PaymentUseCase
+	notices       usecase.NoticeStorePort
+	messaging     usecase.MessagingPort
+	logger        *log.Logger
+
+	reminderDays int // Days before end date to remind customers
+	runHour      int // Hour of day (WIB) to run the job
+
+	mu       sync.Mutex
+	stopChan chan struct{}
+	running  bool
+}
+
+// New creates a new renewal reminder use case.
+// Messaging is set later via SetMessaging (WhatsApp client is created in Run).
+func New(
+	subscriptions usecase.SubscriptionPort,
+	prices usecase.PriceLookupPort,
+	qris usecase.QrisUseCase,
+	payments usecase.PaymentUseCase,
+	notices usecase.NoticeStorePort,
+	reminderDays int,
+	runHour int,
+) *UseCase {
+	return &UseCase{
+		subscriptions: subscriptions,
+		prices:        prices,
+		qris:          qris,
+		payments:      payments,
+		notices:       notices,
+		logger:        logger.Renewal,
+		reminderDays:  reminderDays,
+		runHour:       runHour,
+	}
+}
+
+// SetMessaging sets the messaging service (must be called before Start).
+func (uc *UseCase) SetMessaging(m usecase.MessagingPort) {
+	uc.messaging = m
+}
+
+// Start runs the reminder job daily at runHour WIB.
+func (uc *UseCase) Start() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.running {
+		return
+	}
+	uc.running = true
+	uc.stopChan = make(chan struct{})
+	stop := uc.stopChan
+
+	go func() {
+		wib := time.FixedZone("WIB", 7*60*60)
+		uc.logger.Printf("📅 Renewal reminder scheduler started (%02d:00 WIB, H-%d)", uc.runHour, uc.reminderDays)
+
+		for {
+			now := time.Now().In(wib)
+			next := time.Date(now.Year(), now.Month(), now.Day(), uc.runHour, 0, 0, 0, wib)
+			if !next.After(now) {
+				next = next.AddDate(0, 0, 1)
+			}
+			uc.logger.Printf("⏰ Next renewal check: %s", next.Format("2006-01-02 15:04:05"))
+
+			select {
+			case <-time.After(next.Sub(now)):
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+				if err := uc.RunOnce(ctx, time.Now()); err != nil {
+					uc.logger.Printf("❌ Renewal check failed: %v", err)
+				}
+				cancel()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler.
+func (uc *UseCase) Stop() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.running {
+		uc.running = false
+		close(uc.stopChan)
+		uc.logger.Println("🛑 Renewal reminder scheduler stopped")
+	}
+}
+
+// RunOnce reminds every subscription ending within reminderDays that wasn't
+// reminded yet and posts a digest of all of them to the group.
+func (uc *UseCase) RunOnce(ctx context.Context, now time.Time) error {
+	if uc.messaging == nil {
+		return fmt.Errorf("messaging not set")
+	}
+
+	subs, err := uc.subscriptions.ListSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	sent, err := uc.notices.ListNotices(ctx, noticeKind)
+	if err != nil {
+		return fmt.Errorf("failed to load sent reminders: %w", err)
+	}
+	uc.forgetEnded(ctx, sent, now)
+
+	var expiring []entity.RenewalNotice
+	for _, sub := range subs {
+		daysLeft := sub.DaysLeft(now)
+		if daysLeft < 0 || daysLeft > uc.reminderDays {
+			continue
+		}
+
+		notice := entity.RenewalNotice{Subscription: sub, DaysLeft: daysLeft, Status: entity.RenewalEarlier}
+		if _, ok := sent[sub.ReminderKey()]; !ok {
+			notice.Status = uc.remind(ctx, sub, daysLeft)
+			uc.recordSent(ctx, sub, notice.Status)
+		}
+		expiring = append(expiring, notice)
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].Subscription.TanggalBerakhir.Before(expiring[j].Subscription.TanggalBerakhir)
+	})
+
+	uc.logger.Printf("📅 Renewal check: %d member(s) ending within %d day(s)", len(expiring), uc.reminderDays)
+	if len(expiring) == 0 {
+		return nil
+	}
+
+	if _, err := uc.messaging.SendTextToGroup(ctx, template.BuildRenewalDigest(expiring, uc.reminderDays)); err != nil && !errors.Is(err, domain.ErrMessageQueued) {
+		return fmt.Errorf("failed to send renewal digest: %w", err)
+	}
+	return nil
+}
+
+// remind sends the reminder (and renewal QRIS if the price is known) to the customer.
+// Returns the status shown in the group digest.
+func (uc *UseCase) remind(ctx context.Context, sub entity.Subscription, daysLeft int) string {
+	if !validator.ValidatePhone(sub.Akun) {
+		return entity.RenewalNoContact
+	}
+	chatID := formatter.NormalizePhone(sub.Akun) + "@s.whatsapp.net"
+
+	item := uc.renewalPrice(ctx, sub)
+	if item == nil {
+		// A queued reminder is still delivered, so it counts as sent
+		if err := uc.messaging.SendTextTo(ctx, chatID, template.BuildRenewalReminder(sub, daysLeft, 0)); err != nil && !errors.Is(err, domain.ErrMessageQueued) {
+			uc.logger.Printf("❌ Failed to send reminder to %s: %v", sub.Akun, err)
+			return entity.RenewalFailed
+		}
+		return entity.RenewalNoPrice
+	}
+
+	// Payments are matched by amount only, so the renewal gets a unique code too
+	amount, err := uc.payments.ReserveAmount(item.Harga, item.UniqueCodeLimit(constants.PriceTolerancePercent, constants.UniqueCodeMax))
+	if err != nil {
+		uc.logger.Printf("❌ No unique renewal amount for %s: %v", sub.Email, err)
+		return entity.RenewalFailed
+	}
+
+	cmd := &entity.QrisCommand{
+		Produk:    string(sub.Produk),
+		Nama:      sub.Nama,
+		Email:     sub.Email,
+		Paket:     sub.Paket,
+		Amount:    amount,
+		Deskripsi: fmt.Sprintf("Perpanjang %s - %s", sub.Produk, sub.Nama),
+	}
+	result, err := uc.qris.GenerateQRIS(ctx, cmd, nil)
+	if err != nil {
+		uc.logger.Printf("❌ Failed to generate renewal QRIS for %s: %v", sub.Email, err)
+		return entity.RenewalFailed
+	}
+
+	qrisMsgID, err := uc.messaging.SendImageTo(ctx, chatID, result.ImageData, "")
+	if errors.Is(err, domain.ErrMessageQueued) {
+		uc.logger.Printf("⏳ Renewal QRIS %s to %s queued, not sent yet", qrisMsgID, sub.Akun)
+	} else if err != nil {
+		uc.logger.Printf("❌ Failed to send renewal QRIS to %s: %v", sub.Akun, err)
+		return entity.RenewalFailed
+	}
+	if err := uc.messaging.SendTextReply(ctx, chatID, template.BuildRenewalReminder(sub, daysLeft, result.Amount), qrisMsgID, uc.messaging.GetOwnID()); err != nil {
+		uc.logger.Printf("⚠️ Failed to send renewal caption: %v (QRIS tetap terkirim)", err)
+	}
+
+	uc.payments.RegisterPending(&entity.PendingPayment{
+		MessageID:         qrisMsgID,
+		OriginalMessageID: qrisMsgID,
+		ChatID:            chatID,
+		SenderJID:         uc.messaging.GetOwnID(),
+		SenderPhone:       formatter.NormalizePhone(sub.Akun),
+		Amount:            result.Amount,
+		CreatedAt:         time.Now(),
+		IsSelfQris:        true,
+		Produk:            string(sub.Produk),
+		Nama:              sub.Nama,
+		Email:             sub.Email,
+		Family:            sub.Owner,
+		Paket:             sub.Paket,
+		Deskripsi:         result.Deskripsi,
+		Akun:              sub.Akun,
+		RenewalRow:        sub.Row,
+		ExpiresAt:         sub.TanggalBerakhir,
+	})
+
+	uc.logger.Printf("📨 Renewal QRIS sent to %s (%s, %s)", sub.Akun, sub.Email, formatter.FormatRupiah(result.Amount))
+	return entity.RenewalSent
+}
+
+// renewalPrice returns the catalog entry for the member's product/paket, or nil if unknown.
+func (uc *UseCase) renewalPrice(ctx context.Context, sub entity.Subscription) *entity.CatalogItem {
+	if uc.prices == nil {
+		return nil
+	}
+	item, err := uc.prices.Find(ctx, sub.Produk, sub.Paket)
+	if err != nil {
+		return nil
+	}
+	return item
+}
+
+// recordSent remembers a delivered reminder (failed or impossible ones are
+// tried again on the next run).
+func (uc *UseCase) recordSent(ctx context.Context, sub entity.Subscription, status string) {
+	if status != entity.RenewalSent && status != entity.RenewalNoPrice {
+		return
+	}
+	if err := uc.notices.SetNotice(ctx, noticeKind, sub.ReminderKey(), sub.TanggalBerakhir.Format(noticeDateLayout)); err != nil {
+		uc.logger.Printf("⚠️ Failed to record reminder for %s: %v", sub.Email, err)
+	}
+}
+
+// forgetEnded drops records of end dates that have passed.
+func (uc *UseCase) forgetEnded(ctx context.Context, sent map[string]string, now time.Time) {
+	for key, value := range sent {
+		end, err := time.Parse(noticeDateLayout, value)
+		if err == nil && (entity.Subscription{TanggalBerakhir: end}).DaysLeft(now) >= 0 {
+			continue
+		}
+		if err := uc.notices.DeleteNotice(ctx, noticeKind, key); err != nil {
+			uc.logger.Printf("⚠️ Failed to forget reminder %s: %v", key, err)
+		}
+	}
+}
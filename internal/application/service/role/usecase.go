@@ -0,0 +1,129 @@
+// Package role implements role-based permissions for bot commands (#role).
+package role
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/logger"
+)
+
+// UseCase resolves the role of a sender and lets owners grant/revoke roles.
+// Owners from OWNER_PHONES are fixed; everyone else is looked up in the store
+// and falls back to the default role.
+type UseCase struct {
+	store       usecase.RoleStorePort
+	owners      []string    // Normalized phones from OWNER_PHONES
+	defaultRole entity.Role // Role of allowed senders without an assignment
+	logger      *log.Logger
+}
+
+// New creates a new role use case. owners must be normalized phones.
+func New(store usecase.RoleStorePort, owners []string, defaultRole entity.Role) *UseCase {
+	return &UseCase{
+		store:       store,
+		owners:      owners,
+		defaultRole: defaultRole,
+		logger:      logger.Role,
+	}
+}
+
+// DefaultRole returns the role of allowed senders without an assignment.
+func (uc *UseCase) DefaultRole() entity.Role {
+	return uc.defaultRole
+}
+
+// Assigned returns the explicit role of a normalized phone (OWNER_PHONES or #role).
+// ok is false when the phone has none; lookup errors count as none.
+func (uc *UseCase) Assigned(ctx context.Context, phone string) (entity.Role, bool) {
+	if slices.Contains(uc.owners, phone) {
+		return entity.RoleOwner, true
+	}
+	a, err := uc.store.GetRole(ctx, phone)
+	if err != nil {
+		uc.logger.Printf("⚠️ Failed to look up role of %s: %v", phone, err)
+		return "", false
+	}
+	if a == nil {
+		return "", false
+	}
+	return a.Role, true
+}
+
+// RoleOf returns the role of a normalized phone (assigned or default).
+func (uc *UseCase) RoleOf(ctx context.Context, phone string) entity.Role {
+	if role, ok := uc.Assigned(ctx, phone); ok {
+		return role
+	}
+	return uc.defaultRole
+}
+
+// Grant gives a role to a phone. Owners from OWNER_PHONES cannot be changed.
+func (uc *UseCase) Grant(ctx context.Context, by, phone string, role entity.Role) error {
+	if slices.Contains(uc.owners, phone) {
+		return domain.ErrRoleConfigOwner
+	}
+	err := uc.store.SetRole(ctx, entity.RoleAssignment{
+		Phone:     phone,
+		Role:      role,
+		GrantedBy: by,
+		GrantedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	uc.logger.Printf("🔑 %s granted %s to %s", by, role, phone)
+	return nil
+}
+
+// Revoke removes a phone's role; the phone falls back to the default role.
+// Returns the removed role.
+func (uc *UseCase) Revoke(ctx context.Context, by, phone string) (entity.Role, error) {
+	if slices.Contains(uc.owners, phone) {
+		return "", domain.ErrRoleConfigOwner
+	}
+	a, err := uc.store.GetRole(ctx, phone)
+	if err != nil {
+		return "", err
+	}
+	if a == nil {
+		return "", domain.ErrRoleNotAssigned
+	}
+	if err := uc.store.DeleteRole(ctx, phone); err != nil {
+		return "", err
+	}
+	uc.logger.Printf("🔑 %s revoked %s from %s", by, a.Role, phone)
+	return a.Role, nil
+}
+
+// List returns all assignments including OWNER_PHONES, most privileged first.
+func (uc *UseCase) List(ctx context.Context) ([]entity.RoleAssignment, error) {
+	stored, err := uc.store.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]entity.RoleAssignment, 0, len(uc.owners)+len(stored))
+	for _, phone := range uc.owners {
+		list = append(list, entity.RoleAssignment{Phone: phone, Role: entity.RoleOwner, GrantedBy: entity.RoleGrantedByConfig})
+	}
+	for _, a := range stored {
+		if !slices.Contains(uc.owners, a.Phone) {
+			list = append(list, a)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Role != list[j].Role {
+			return list[i].Role.AtLeast(list[j].Role)
+		}
+		return list[i].Phone < list[j].Phone
+	})
+	return list, nil
+}
@@ -0,0 +1,32 @@
+// Package slot implements slot owner validation (family/workspace/head) for all products.
+package slot
+
+import (
+	"context"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+var _ usecase.SlotCapacityPort = (*Capacity)(nil)
+
+// Capacity resolves the slot limit per owner of every product: the catalog
+// value when set, else ProductInfo.SlotCapacity. #qris validation, #cekslot,
+// #member, #pindah and #export all read it, so they agree on capacities.
+type Capacity struct {
+	limits usecase.SlotLimitPort // Optional: slot limit override from catalog
+}
+
+// NewCapacity creates a capacity resolver. limits may be nil (product defaults only).
+func NewCapacity(limits usecase.SlotLimitPort) *Capacity {
+	return &Capacity{limits: limits}
+}
+
+// SlotCapacity returns how many members one family/workspace/head of the product holds.
+func (c *Capacity) SlotCapacity(ctx context.Context, product entity.Product) int {
+	fallback := product.Info().SlotCapacity
+	if c.limits == nil {
+		return fallback
+	}
+	return c.limits.SlotLimit(ctx, product, fallback)
+}
@@ -0,0 +1,76 @@
+// Package slot implements slot owner validation (family/workspace/head) for all products.
+package slot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+var _ entity.SlotValidator = (*UseCase)(nil)
+
+// UseCase implements slot owner validation for one product.
+// Rules (label, account sheet, special owners) come from the product registry,
+// the capacity from the shared capacity resolver.
+type UseCase struct {
+	product  entity.Product
+	owners   usecase.SlotOwnerPort
+	capacity usecase.SlotCapacityPort
+}
+
+// New creates a new slot validation use case for a product.
+func New(product entity.Product, owners usecase.SlotOwnerPort, capacity usecase.SlotCapacityPort) *UseCase {
+	return &UseCase{
+		product:  product,
+		owners:   owners,
+		capacity: capacity,
+	}
+}
+
+// ValidateSlot validates a slot owner before QRIS generation.
+// Input: family name (Gemini), owner email (ChatGPT) or head email (YouTube).
+// Returns SlotValidation with slot details.
+func (uc *UseCase) ValidateSlot(ctx context.Context, owner string) (*entity.SlotValidation, error) {
+	info := uc.product.Info()
+	maxSlots := uc.capacity.SlotCapacity(ctx, uc.product)
+	result := &entity.SlotValidation{
+		Product:  uc.product,
+		Owner:    owner,
+		MaxSlots: maxSlots,
+	}
+	label := strings.ToLower(info.SlotLabel)
+
+	// Special owners skip account sheet validation (e.g. internal families)
+	if info.IsSpecialOwner(owner) {
+		result.IsSpecial = true
+	} else {
+		exists, err := uc.owners.SlotOwnerExists(ctx, uc.product, owner)
+		if err != nil {
+			return nil, fmt.Errorf("gagal validasi %s: %w", label, err)
+		}
+		if !exists {
+			result.ErrorMessage = fmt.Sprintf("%s '%s' tidak ditemukan di %s", info.SlotLabel, owner, info.AccountSheet)
+			return result, domain.ErrSlotOwnerNotFound
+		}
+	}
+
+	// Count used slots in product sheet
+	count, err := uc.owners.CountSlots(ctx, uc.product, owner)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengecek slot %s: %w", label, err)
+	}
+	result.UsedSlots = count
+
+	// Check if full
+	if count >= maxSlots {
+		result.ErrorMessage = fmt.Sprintf("%s '%s' sudah penuh (%d/%d slot terpakai)", info.SlotLabel, owner, count, maxSlots)
+		return result, domain.ErrSlotFull
+	}
+
+	result.IsValid = true
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Package voucher implements voucher/discount code validation for #qris orders.
+package voucher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+)
+
+// UseCase implements voucher validation.
+// Usage is only counted on confirmed payment (see ConfirmationService), so
+// generating a QRIS with a voucher never consumes its quota.
+type UseCase struct {
+	source usecase.VoucherPort
+	now    func() time.Time
+}
+
+// New creates a new voucher use case.
+func New(source usecase.VoucherPort) *UseCase {
+	return &UseCase{
+		source: source,
+		now:    time.Now,
+	}
+}
+
+// Apply validates cmd.Voucher and subtracts the discount from cmd.Amount.
+// cmd.Amount must already hold the list price (after catalog resolution).
+// On success cmd.Amount holds the discounted amount and cmd.Discount the discount.
+func (uc *UseCase) Apply(ctx context.Context, cmd *entity.QrisCommand) (*entity.VoucherCheck, error) {
+	kode := strings.ToUpper(strings.TrimSpace(cmd.Voucher))
+	result := &entity.VoucherCheck{Original: cmd.Amount, Final: cmd.Amount}
+
+	vouchers, err := uc.source.GetVouchers(ctx)
+	if err != nil {
+		result.ErrorMessage = "Data voucher tidak bisa dimuat, coba lagi nanti"
+		return result, err
+	}
+
+	var v *entity.Voucher
+	for i := range vouchers {
+		if vouchers[i].Matches(kode) {
+			v = &vouchers[i]
+			break
+		}
+	}
+	if v == nil {
+		result.ErrorMessage = fmt.Sprintf("Voucher '%s' tidak ditemukan", kode)
+		return result, domain.ErrVoucherNotFound
+	}
+	result.Voucher = v
+
+	if !v.IsActiveAt(uc.now()) {
+		result.ErrorMessage = fmt.Sprintf("Voucher %s tidak berlaku saat ini%s", v.Kode, describeWindow(v))
+		return result, domain.ErrVoucherInactive
+	}
+
+	if !v.AppliesTo(entity.Product(cmd.Produk)) {
+		result.ErrorMessage = fmt.Sprintf("Voucher %s tidak berlaku untuk produk %s", v.Kode, cmd.Produk)
+		return result, domain.ErrVoucherNotApplicable
+	}
+
+	if !v.QuotaLeft() {
+		result.ErrorMessage = fmt.Sprintf("Kuota voucher %s sudah habis (%d/%d)", v.Kode, v.Terpakai, v.Kuota)
+		return result, domain.ErrVoucherQuotaExhausted
+	}
+
+	if v.MaxPerCustomer > 0 {
+		if cmd.Email == "" {
+			result.ErrorMessage = fmt.Sprintf("Voucher %s wajib disertai Email customer", v.Kode)
+			return result, domain.ErrVoucherCustomerLimit
+		}
+		used, err := uc.source.CountVoucherUsage(ctx, v.Kode, cmd.Email)
+		if err != nil {
+			result.ErrorMessage = "Riwayat pemakaian voucher tidak bisa dimuat, coba lagi nanti"
+			return result, err
+		}
+		if used >= v.MaxPerCustomer {
+			result.ErrorMessage = fmt.Sprintf("Voucher %s sudah dipakai %s sebanyak %d kali (maks %d)",
+				v.Kode, cmd.Email, used, v.MaxPerCustomer)
+			return result, domain.ErrVoucherCustomerLimit
+		}
+	}
+
+	discount := v.Discount(cmd.Amount)
+	if discount >= cmd.Amount {
+		result.ErrorMessage = fmt.Sprintf("Potongan voucher %s (%s) melebihi nominal %s",
+			v.Kode, formatter.FormatRupiah(discount), formatter.FormatRupiah(cmd.Amount))
+		return result, domain.ErrVoucherNotApplicable
+	}
+
+	cmd.Voucher = v.Kode
+	cmd.Discount = discount
+	cmd.Amount -= discount
+
+	result.Discount = discount
+	result.Final = cmd.Amount
+	result.IsValid = true
+	return result, nil
+}
+
+// describeWindow returns " (DD-MM-YYYY s/d DD-MM-YYYY)" for messages, or "" if unbounded.
+func describeWindow(v *entity.Voucher) string {
+	const layout = "02-01-2006"
+	switch {
+	case !v.Mulai.IsZero() && !v.Sampai.IsZero():
+		return fmt.Sprintf(" (%s s/d %s)", v.Mulai.Format(layout), v.Sampai.Format(layout))
+	case !v.Mulai.IsZero():
+		return fmt.Sprintf(" (mulai %s)", v.Mulai.Format(layout))
+	case !v.Sampai.IsZero():
+		return fmt.Sprintf(" (berakhir %s)", v.Sampai.Format(layout))
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+// Package adapters contains infrastructure adapters that implement domain ports.
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	appservice "github.com/exernia/botjanweb/internal/application/service"
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	infra "github.com/exernia/botjanweb/internal/infrastructure/messaging/whatsapp"
+)
+
+// GroupRouter is a MessagingPort whose group messages go to the groups
+// subscribed to one notification kind instead of the default group.
+// Used by the scheduled jobs (renewal digest, account monitor, stock alerts).
+type GroupRouter struct {
+	*infra.Client
+	groups *entity.GroupDirectory
+	kind   entity.NotifyKind
+}
+
+// NewGroupRouter creates a messaging port that routes group messages by kind.
+func NewGroupRouter(waClient *infra.Client, groups *entity.GroupDirectory, kind entity.NotifyKind) appservice.MessagingPort {
+	return &GroupRouter{Client: waClient, groups: groups, kind: kind}
+}
+
+// SendTextToGroup sends the text to every subscribed group.
+// Returns the message ID in the first group that got or queued it; fails only
+// if no group did, and returns domain.ErrMessageQueued if none was sent yet.
+func (r *GroupRouter) SendTextToGroup(ctx context.Context, text string) (string, error) {
+	var (
+		firstID string
+		sent    bool
+		errs    []error
+	)
+	for _, jid := range r.groups.Targets(r.kind) {
+		id, err := r.Client.SendTextToGroupJID(ctx, jid, text)
+		if err != nil && !errors.Is(err, domain.ErrMessageQueued) {
+			errs = append(errs, err)
+			continue
+		}
+		sent = sent || err == nil
+		if firstID == "" {
+			firstID = id
+		}
+	}
+	if firstID == "" {
+		return "", errors.Join(errs...)
+	}
+	if !sent {
+		return firstID, domain.ErrMessageQueued
+	}
+	return firstID, nil
+}
+
+// SendTextReplyToGroup replies in the first subscribed group.
+func (r *GroupRouter) SendTextReplyToGroup(ctx context.Context, text, quotedMsgID string) error {
+	return r.Client.SendTextReply(ctx, r.groups.Targets(r.kind)[0], text, quotedMsgID, r.Client.GetOwnID())
+}
+
+// SendImageToGroup sends the image to the first subscribed group.
+func (r *GroupRouter) SendImageToGroup(ctx context.Context, imageData []byte, caption string) (string, error) {
+	return r.Client.SendImageTo(ctx, r.groups.Targets(r.kind)[0], imageData, caption)
+}
@@ -5,24 +5,26 @@ package adapters
 
 import (
 	"context"
+	"strings"
 
 	"github.com/exernia/botjanweb/internal/application/service/payment"
 	"github.com/exernia/botjanweb/internal/domain/entity"
 	infra "github.com/exernia/botjanweb/internal/infrastructure/messaging/whatsapp"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
 )
 
 // WhatsAppNotificationAdapter implements the NotificationPort interface using WhatsApp.
 // This adapter translates use-case notification requests into WhatsApp-specific operations.
 type WhatsAppNotificationAdapter struct {
 	waClient *infra.Client
-	groupJID string // Group JID for sending group notifications
+	groups   *entity.GroupDirectory // Routes notices without a group to the payment group
 }
 
 // NewWhatsAppNotificationAdapter creates a new WhatsApp notification adapter.
-func NewWhatsAppNotificationAdapter(waClient *infra.Client, groupJID string) payment.NotificationPort {
+func NewWhatsAppNotificationAdapter(waClient *infra.Client, groups *entity.GroupDirectory) payment.NotificationPort {
 	return &WhatsAppNotificationAdapter{
 		waClient: waClient,
-		groupJID: groupJID,
+		groups:   groups,
 	}
 }
 
@@ -49,22 +51,44 @@ func (a *WhatsAppNotificationAdapter) RevokeQRISImage(ctx context.Context, chatI
 		return nil // Silently skip if WhatsApp client not available
 	}
 
-	return a.waClient.RevokeMessage(ctx, chatID, messageID)
+	return a.waClient.RevokeMessage(ctx, chatID, "", messageID)
 }
 
-// SendGroupNotification sends notification to the configured group via WhatsApp.
-func (a *WhatsAppNotificationAdapter) SendGroupNotification(ctx context.Context, message string, replyToMessageID string) error {
+// SendGroupNotification sends notification to a group via WhatsApp.
+// An empty groupJID (renewal QRIS, payments from before multi-group) uses the
+// first group subscribed to payment notices.
+func (a *WhatsAppNotificationAdapter) SendGroupNotification(ctx context.Context, groupJID, message, replyToMessageID string) error {
 	// Guard against nil client
 	if a == nil || a.waClient == nil {
 		return nil // Silently skip if WhatsApp client not available
 	}
 
+	if groupJID == "" {
+		groupJID = a.groups.Targets(entity.NotifyPayment)[0]
+	}
+
 	// If we have a message to reply to, use reply
 	if replyToMessageID != "" {
-		return a.waClient.SendTextReplyToGroup(ctx, message, replyToMessageID)
+		return a.waClient.SendTextReply(ctx, groupJID, message, replyToMessageID, a.waClient.GetOwnID())
 	}
 
 	// Otherwise, send as new message
-	_, err := a.waClient.SendTextToGroup(ctx, message)
+	_, err := a.waClient.SendTextToGroupJID(ctx, groupJID, message)
 	return err
 }
+
+// SendDirectMessage sends a private message via WhatsApp.
+// Recipient may be a full chat JID or a phone number (converted to a user JID).
+func (a *WhatsAppNotificationAdapter) SendDirectMessage(ctx context.Context, recipient, message string) error {
+	// Guard against nil client
+	if a == nil || a.waClient == nil {
+		return nil // Silently skip if WhatsApp client not available
+	}
+
+	chatID := recipient
+	if !strings.Contains(recipient, "@") {
+		chatID = formatter.NormalizePhone(recipient) + "@s.whatsapp.net"
+	}
+
+	return a.waClient.SendTextTo(ctx, chatID, message)
+}
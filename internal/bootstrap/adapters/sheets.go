@@ -3,6 +3,7 @@ package adapters
 
 import (
 	"context"
+	"time"
 
 	"github.com/exernia/botjanweb/internal/application/service/payment"
 	"github.com/exernia/botjanweb/internal/domain/entity"
@@ -28,3 +29,18 @@ func NewSheetsAdapter(repo *sheets.Repository) payment.SheetsPort {
 func (a *SheetsAdapter) LogOrder(ctx context.Context, order *entity.Order) error {
 	return a.repo.LogOrder(ctx, order)
 }
+
+// ClaimRedeemCode claims the next available redeem code from Google Sheets.
+func (a *SheetsAdapter) ClaimRedeemCode(ctx context.Context, customerEmail string) (*entity.RedeemCodeInfo, error) {
+	return a.repo.ClaimRedeemCode(ctx, customerEmail)
+}
+
+// RecordVoucherUsage records a confirmed voucher use in Google Sheets.
+func (a *SheetsAdapter) RecordVoucherUsage(ctx context.Context, usage *entity.VoucherUsage) error {
+	return a.repo.RecordVoucherUsage(ctx, usage)
+}
+
+// ExtendSubscription updates a member's end date in Google Sheets.
+func (a *SheetsAdapter) ExtendSubscription(ctx context.Context, produk string, row int, email string, end time.Time) error {
+	return a.repo.ExtendSubscription(ctx, produk, row, email, end)
+}
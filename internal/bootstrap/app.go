@@ -4,16 +4,29 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	appservice "github.com/exernia/botjanweb/internal/application/service"
 	accountuc "github.com/exernia/botjanweb/internal/application/service/account"
-	familyuc "github.com/exernia/botjanweb/internal/application/service/family"
+	cataloguc "github.com/exernia/botjanweb/internal/application/service/catalog"
+	connectionuc "github.com/exernia/botjanweb/internal/application/service/connection"
+	duplicateuc "github.com/exernia/botjanweb/internal/application/service/duplicate"
+	exportuc "github.com/exernia/botjanweb/internal/application/service/export"
+	importeruc "github.com/exernia/botjanweb/internal/application/service/importer"
+	migrationuc "github.com/exernia/botjanweb/internal/application/service/migration"
+	monitoruc "github.com/exernia/botjanweb/internal/application/service/monitor"
+	orderuc "github.com/exernia/botjanweb/internal/application/service/order"
 	paymentuc "github.com/exernia/botjanweb/internal/application/service/payment"
 	qrisuc "github.com/exernia/botjanweb/internal/application/service/qris"
-	workspaceuc "github.com/exernia/botjanweb/internal/application/service/workspace"
+	redeemuc "github.com/exernia/botjanweb/internal/application/service/redeem"
+	renewaluc "github.com/exernia/botjanweb/internal/application/service/renewal"
+	roleuc "github.com/exernia/botjanweb/internal/application/service/role"
+	slotuc "github.com/exernia/botjanweb/internal/application/service/slot"
+	voucheruc "github.com/exernia/botjanweb/internal/application/service/voucher"
 	"github.com/exernia/botjanweb/internal/bootstrap/adapters"
 	"github.com/exernia/botjanweb/internal/config"
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	infraalert "github.com/exernia/botjanweb/internal/infrastructure/external/alert"
 	infraqris "github.com/exernia/botjanweb/internal/infrastructure/external/qris"
 	infrawebhook "github.com/exernia/botjanweb/internal/infrastructure/messaging/webhook"
 	infrawa "github.com/exernia/botjanweb/internal/infrastructure/messaging/whatsapp"
@@ -34,15 +47,33 @@ type App struct {
 	WebhookServer *infrawebhook.Server
 
 	// Repository
-	PendingStore appservice.PendingStorePort
-	SheetsRepo   *reposheets.Repository
+	PendingStore  appservice.PendingStorePort
+	SheetsRepo    *reposheets.Repository
+	CatalogSource appservice.CatalogPort
+	Groups        *entity.GroupDirectory
+	RoleStore     appservice.RoleStorePort
+	NoticeStore   appservice.NoticeStorePort
 
 	// Use Cases
+	SlotCapacity   *slotuc.Capacity
+	SlotValidators map[entity.Product]entity.SlotValidator
+
 	QrisUC      *qrisuc.UseCase
 	PaymentUC   *paymentuc.UseCase
-	FamilyUC    *familyuc.UseCase
-	WorkspaceUC *workspaceuc.UseCase
 	AccountUC   *accountuc.UseCase
+	CatalogUC   *cataloguc.UseCase
+	VoucherUC   *voucheruc.UseCase
+	RenewalUC   *renewaluc.UseCase
+	MonitorUC   *monitoruc.UseCase
+	MigrationUC *migrationuc.UseCase
+	DuplicateUC *duplicateuc.UseCase
+	ImporterUC  *importeruc.UseCase
+	ExportUC    *exportuc.UseCase
+	RedeemUC    *redeemuc.UseCase
+	RoleUC      *roleuc.UseCase
+	OrderUC     *orderuc.UseCase
+
+	ConnectionUC *connectionuc.UseCase
 
 	// Domain Services
 	ConfirmationService *paymentuc.ConfirmationService
@@ -66,6 +97,11 @@ func New(ctx context.Context, assetsPath string) (*App, error) {
 		Logger: logger.App,
 	}
 
+	// Account sheet names are configurable; slot validation reads them from the registry
+	entity.SetAccountSheet(entity.ProductGemini, cfg.SheetAkunGoogle)
+	entity.SetAccountSheet(entity.ProductChatGPT, cfg.SheetAkunChatGPT)
+	entity.SetAccountSheet(entity.ProductYouTube, cfg.SheetAkunYouTube)
+
 	// Initialize infrastructure layer
 	if err := app.initInfrastructure(assetsPath); err != nil {
 		return nil, fmt.Errorf("failed to init infrastructure: %w", err)
@@ -101,36 +137,122 @@ func (app *App) initUseCases() {
 	sheetsPort := adapters.NewSheetsAdapter(app.SheetsRepo)
 	app.ConfirmationService = paymentuc.NewConfirmationService(nil, sheetsPort)
 
-	// Family validation use case
-	if app.SheetsRepo != nil {
-		app.FamilyUC = familyuc.New(app.SheetsRepo)
+	// Catalog (price list) use case: catalog file takes priority over Harga sheet
+	var slotLimits appservice.SlotLimitPort
+	if app.CatalogSource != nil {
+		app.CatalogUC = cataloguc.New(app.CatalogSource)
+		slotLimits = app.CatalogUC
 	}
 
-	// Workspace validation use case
+	// Slot capacity per product (catalog override) and slot validation use cases
+	app.SlotCapacity = slotuc.NewCapacity(slotLimits)
 	if app.SheetsRepo != nil {
-		app.WorkspaceUC = workspaceuc.New(app.SheetsRepo)
+		app.SlotValidators = app.slotValidators()
 	}
 
-	// Account management use case
+	// Account management, voucher, member migration, duplicate check, import, export
+	// and redeem code use cases
 	if app.SheetsRepo != nil {
-		app.AccountUC = accountuc.New(app.SheetsRepo)
+		app.AccountUC = accountuc.New(app.SheetsRepo, app.Config.AccountExpiryWarnDays)
+		app.VoucherUC = voucheruc.New(app.SheetsRepo)
+		app.MigrationUC = migrationuc.New(app.SheetsRepo, app.SlotCapacity)
+		app.DuplicateUC = duplicateuc.New(app.SheetsRepo, app.PaymentUC)
+		app.ImporterUC = importeruc.New(app.SheetsRepo, app.SheetsRepo)
+		app.ExportUC = exportuc.New(app.SheetsRepo, app.SlotCapacity)
+		app.RedeemUC = redeemuc.New(app.SheetsRepo, app.Config.RedeemLowStock)
 	}
+
+	// Renewal reminders (end dates live in the product sheets)
+	if app.SheetsRepo != nil && app.Config.RenewalReminderDays > 0 {
+		var prices appservice.PriceLookupPort
+		if app.CatalogUC != nil {
+			prices = app.CatalogUC
+		}
+		app.RenewalUC = renewaluc.New(
+			app.SheetsRepo,
+			prices,
+			app.QrisUC,
+			app.PaymentUC,
+			app.NoticeStore,
+			app.Config.RenewalReminderDays,
+			app.Config.RenewalReminderHour,
+		)
+	}
+
+	// Role-based command permissions (DEFAULT_ROLE already validated by config)
+	defaultRole, _ := entity.ParseRole(app.Config.DefaultRole)
+	if defaultRole != entity.RoleViewer {
+		app.Logger.Printf("⚠️ DEFAULT_ROLE=%s: every allowed sender without a #role gets %s permissions", defaultRole, defaultRole)
+	}
+	app.RoleUC = roleuc.New(app.RoleStore, app.Config.OwnerPhones, defaultRole)
+
+	// Step-by-step order wizard (#order)
+	app.OrderUC = orderuc.New()
+
+	// Account health monitor (Akun Google / Akun ChatGPT)
+	if app.SheetsRepo != nil && app.Config.AccountMonitorHours > 0 {
+		app.MonitorUC = monitoruc.New(
+			app.SheetsRepo,
+			app.NoticeStore,
+			time.Duration(app.Config.AccountMonitorHours)*time.Hour,
+			app.Config.AccountExpiryWarnDays,
+		)
+	}
+
+	// Fallback alerts for WhatsApp logout / failing reconnects (webhook and/or email)
+	alerts := infraalert.NewMulti(
+		infraalert.NewWebhook(app.Config.AlertWebhookURL),
+		infraalert.NewEmail(app.Config.AlertSMTPAddr, app.Config.AlertEmailFrom, app.Config.AlertEmailTo),
+	)
+	if alerts.Enabled() {
+		app.ConnectionUC = connectionuc.New(alerts, app.pairingURL())
+	} else {
+		app.Logger.Println("⚠️ No ALERT_WEBHOOK_URL / ALERT_SMTP_ADDR set, WhatsApp logout will only be logged")
+	}
+}
+
+// slotValidators builds a slot validator for every registered product with slots.
+// Sheets and columns come from the registry, so a new product needs no wiring here.
+func (app *App) slotValidators() map[entity.Product]entity.SlotValidator {
+	validators := make(map[entity.Product]entity.SlotValidator)
+	for _, product := range entity.AllProducts() {
+		if product.Info().HasSlots() {
+			validators[product] = slotuc.New(product, app.SheetsRepo, app.SlotCapacity)
+		}
+	}
+	return validators
 }
 
 // initControllers sets up controller components.
 func (app *App) initControllers() {
-	// Bot message handler with all allowed senders
+	// Inventory port stays a nil interface when Sheets is disabled,
+	// so handlers can skip sheet-backed checks with a plain nil check
+	var inventoryPort appservice.InventoryPort
+	if app.SheetsRepo != nil {
+		inventoryPort = app.SheetsRepo
+	}
+
+	// Bot message handler with per-group senders, kanal and commands
 	app.BotHandler = botctrl.NewHandler(
 		app.QrisUC,
 		app.PaymentUC,
-		app.FamilyUC,
-		app.WorkspaceUC,
 		app.AccountUC,
-		app.SheetsRepo, // Inventory port
-		app.Config.AllowedSenders,
+		app.CatalogUC,
+		app.VoucherUC,
+		app.MigrationUC,
+		app.DuplicateUC,
+		app.ImporterUC,
+		app.ExportUC,
+		app.RedeemUC,
+		app.RoleUC,
+		app.OrderUC,
+		inventoryPort,
+		app.SlotValidators,
+		app.SlotCapacity,
+		app.Groups,
 		app.Config.SheetAkunGoogle,
 		app.Config.SheetAkunChatGPT,
-		app.Config.DefaultKanal,
+		app.Config.EncryptionKey != "",
 	)
 
 	// Webhook controller with payment confirmation service
@@ -166,9 +288,12 @@ func (app *App) initQRPairingController() {
 // Called from Run() after WAClient is available.
 func (app *App) initPaymentConfirmationService() {
 	if app.WAClient != nil && app.ConfirmationService != nil {
-		notificationPort := adapters.NewWhatsAppNotificationAdapter(app.WAClient, app.Config.GroupJID)
+		notificationPort := adapters.NewWhatsAppNotificationAdapter(app.WAClient, app.Groups)
 		sheetsPort := adapters.NewSheetsAdapter(app.SheetsRepo)
 		app.ConfirmationService = paymentuc.NewConfirmationService(notificationPort, sheetsPort)
+		if app.RedeemUC != nil {
+			app.ConfirmationService.SetRedeemStock(app.RedeemUC)
+		}
 	}
 }
 
@@ -5,10 +5,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/exernia/botjanweb/internal/domain/entity"
 	infraqris "github.com/exernia/botjanweb/internal/infrastructure/external/qris"
+	repofile "github.com/exernia/botjanweb/internal/infrastructure/persistence/file"
 	repomemory "github.com/exernia/botjanweb/internal/infrastructure/persistence/memory"
 	repopostgres "github.com/exernia/botjanweb/internal/infrastructure/persistence/postgres"
 	reposheets "github.com/exernia/botjanweb/internal/infrastructure/persistence/sheets"
+	"github.com/exernia/botjanweb/pkg/helper/secret"
 )
 
 // initInfrastructure initializes infrastructure layer components.
@@ -40,6 +43,29 @@ func (app *App) initRepositories(ctx context.Context) error {
 		app.PendingStore.StartCleanup()
 	}
 
+	// Role store: same choice as the pending store (roles are lost on restart in memory)
+	if app.Config.DatabaseURL != "" {
+		roleStore, err := repopostgres.NewRoleStore(ctx, app.Config.DatabaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to init PostgreSQL role store: %w", err)
+		}
+		app.RoleStore = roleStore
+	} else {
+		app.Logger.Println("⚠️ DATABASE_URL not set, #role changes are kept in memory only")
+		app.RoleStore = repomemory.NewRoleStore()
+	}
+
+	// Notice store for sent reminders/alerts: same choice as the pending store
+	if app.Config.DatabaseURL != "" {
+		noticeStore, err := repopostgres.NewNoticeStore(ctx, app.Config.DatabaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to init PostgreSQL notice store: %w", err)
+		}
+		app.NoticeStore = noticeStore
+	} else {
+		app.NoticeStore = repomemory.NewNoticeStore()
+	}
+
 	// Google Sheets repository (optional, only if enabled)
 	if app.Config.SheetsEnabled {
 		repo, err := reposheets.NewRepository(
@@ -49,12 +75,61 @@ func (app *App) initRepositories(ctx context.Context) error {
 			app.Config.SheetOrders,      // ordersSheet
 			app.Config.SheetAkunGoogle,  // akunGoogleSheet
 			app.Config.SheetAkunChatGPT, // akunChatGPTSheet
+			app.Config.SheetAkunYouTube, // akunYouTubeSheet
 		)
 		if err != nil {
 			return fmt.Errorf("failed to init sheets repository: %w", err)
 		}
 		app.SheetsRepo = repo
 		app.Logger.Printf("✅ Google Sheets repository initialized")
+
+		// Account password encryption (key already validated by config)
+		if app.Config.EncryptionKey != "" {
+			c, err := secret.NewCipher(app.Config.EncryptionKey)
+			if err != nil {
+				return fmt.Errorf("invalid ENCRYPTION_KEY: %w", err)
+			}
+			repo.SetCipher(c)
+			app.Logger.Printf("🔐 Account passwords are encrypted before writing to Sheets")
+		} else {
+			app.Logger.Println("⚠️ ENCRYPTION_KEY not set, account passwords are stored as plain text")
+		}
+	}
+
+	// Product catalog source: CATALOG_FILE (if set) or Harga sheet (if Sheets enabled)
+	if app.Config.CatalogFile != "" {
+		app.CatalogSource = repofile.NewCatalogStore(app.Config.CatalogFile)
+		app.Logger.Printf("✅ Catalog loaded from file: %s", app.Config.CatalogFile)
+	} else if app.SheetsRepo != nil {
+		app.CatalogSource = app.SheetsRepo
+		app.Logger.Printf("✅ Catalog loaded from Harga sheet")
+	} else {
+		app.Logger.Println("ℹ️ No catalog source, nominal must be filled manually")
+	}
+
+	// Groups: GROUPS_FILE (if set) or the single GROUP_JID with global settings
+	global := entity.GroupConfig{
+		Name:           "default",
+		AllowedSenders: app.Config.AllowedSenders,
+		Kanal:          app.Config.DefaultKanal,
+	}
+	if app.Config.GroupsFile != "" {
+		groups, err := repofile.LoadGroups(app.Config.GroupsFile, global)
+		if err != nil {
+			return fmt.Errorf("failed to load groups: %w", err)
+		}
+		app.Groups = &entity.GroupDirectory{Groups: groups, Global: global, Strict: true}
+		if app.Config.GroupJID != "" {
+			app.Logger.Printf("ℹ️ GROUP_JID ignored, default group is the first in %s", app.Config.GroupsFile)
+		}
+		for _, g := range groups {
+			app.Logger.Printf("✅ Group %q (%s): commands=%v notify=%v", g.Name, g.JID, g.Commands, g.Notify)
+		}
+	} else {
+		legacy := global
+		legacy.JID = app.Config.GroupJID
+		legacy.Notify = entity.NotifyKinds
+		app.Groups = &entity.GroupDirectory{Groups: []entity.GroupConfig{legacy}, Global: global}
 	}
 
 	app.Logger.Printf("✅ Repositories initialized")
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/exernia/botjanweb/internal/bootstrap/adapters"
 	"github.com/exernia/botjanweb/internal/domain/entity"
 	infrawebhook "github.com/exernia/botjanweb/internal/infrastructure/messaging/webhook"
 	infrawa "github.com/exernia/botjanweb/internal/infrastructure/messaging/whatsapp"
@@ -16,7 +17,7 @@ func (app *App) Run(ctx context.Context) error {
 	waClient, err := infrawa.NewClient(
 		ctx,
 		app.Config.WhatsAppDBPath,
-		app.Config.GroupJID,
+		app.Groups.Default().JID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create WhatsApp client: %w", err)
@@ -25,10 +26,34 @@ func (app *App) Run(ctx context.Context) error {
 
 	// Set messaging service to bot handler
 	app.BotHandler.SetMessaging(app.WAClient)
+	if app.MigrationUC != nil {
+		app.MigrationUC.SetMessaging(app.WAClient)
+	}
+	if app.RedeemUC != nil {
+		// Low-stock alerts go to the groups subscribed to "stock"
+		app.RedeemUC.SetMessaging(adapters.NewGroupRouter(app.WAClient, app.Groups, entity.NotifyStock))
+	}
 
 	// Set message handler
 	app.WAClient.SetMessageHandler(app.createMessageHandler())
 
+	// Alert via webhook/email on logout or failing reconnects (must be set before connecting)
+	if app.ConnectionUC != nil {
+		app.WAClient.SetStateListener(app.ConnectionUC.OnStateChange)
+	}
+
+	// Start renewal reminders (needs WhatsApp client for sending)
+	if app.RenewalUC != nil {
+		app.RenewalUC.SetMessaging(adapters.NewGroupRouter(app.WAClient, app.Groups, entity.NotifyRenewal))
+		app.RenewalUC.Start()
+	}
+
+	// Start account health monitor (needs WhatsApp client for alerts)
+	if app.MonitorUC != nil {
+		app.MonitorUC.SetMessaging(adapters.NewGroupRouter(app.WAClient, app.Groups, entity.NotifyMonitor))
+		app.MonitorUC.Start()
+	}
+
 	// Re-initialize payment confirmation service with WhatsApp adapter (must be after WAClient is created)
 	app.initPaymentConfirmationService()
 
@@ -39,6 +64,9 @@ func (app *App) Run(ctx context.Context) error {
 
 	// Start webhook server if enabled
 	if app.Config.WebhookEnabled {
+		// /ready reports 503 while WhatsApp is not connected
+		app.WebhookController.SetConnectionChecker(app.WAClient)
+
 		// Build HTTP router with all routes
 		router := app.buildHTTPRouter()
 
@@ -55,21 +83,13 @@ func (app *App) Run(ctx context.Context) error {
 	// Start QR pairing process (will be activated on-demand via web interface)
 	// No need to check IsLoggedIn here - let the HTTP handler decide dynamically
 	if app.QRPairingController != nil {
-		// Get hostname for Heroku or use localhost for dev
-		hostname := "localhost"
-		if app.Config.HerokuAppName != "" {
-			hostname = app.Config.HerokuAppName + ".herokuapp.com"
-		} else {
-			hostname = fmt.Sprintf("localhost:%d", app.Config.WebhookPort)
-		}
-
 		if !app.WAClient.IsLoggedIn() {
 			app.Logger.Println("📱 Device not paired yet")
-			app.Logger.Printf("🌐 QR Pairing available at: https://%s/pairing?token=%s",
-				hostname, app.Config.WebhookSecret)
+			app.Logger.Printf("🌐 QR Pairing available at: %s?token=%s",
+				app.pairingURL(), app.Config.WebhookSecret)
 		} else {
-			app.Logger.Printf("✅ Device already paired. Pairing page: https://%s/pairing?token=%s",
-				hostname, app.Config.WebhookSecret)
+			app.Logger.Printf("✅ Device already paired. Pairing page: %s?token=%s",
+				app.pairingURL(), app.Config.WebhookSecret)
 		}
 	}
 
@@ -78,6 +98,19 @@ func (app *App) Run(ctx context.Context) error {
 	return app.WAClient.Run(ctx)
 }
 
+// pairingURL returns the pairing page URL without token, or "" if the web server is disabled.
+// Uses the Heroku hostname when available, localhost for dev.
+func (app *App) pairingURL() string {
+	if !app.Config.WebhookEnabled {
+		return ""
+	}
+	hostname := fmt.Sprintf("localhost:%d", app.Config.WebhookPort)
+	if app.Config.HerokuAppName != "" {
+		hostname = app.Config.HerokuAppName + ".herokuapp.com"
+	}
+	return "https://" + hostname + "/pairing"
+}
+
 // Shutdown gracefully stops all services.
 func (app *App) Shutdown(ctx context.Context) error {
 	app.Logger.Println("🛑 Initiating graceful shutdown...")
@@ -105,6 +138,20 @@ func (app *App) Shutdown(ctx context.Context) error {
 		app.Logger.Println("   ✅ WhatsApp disconnected")
 	}
 
+	// Stop renewal reminders
+	if app.RenewalUC != nil {
+		app.Logger.Println("   → Stopping renewal reminders...")
+		app.RenewalUC.Stop()
+		app.Logger.Println("   ✅ Renewal reminders stopped")
+	}
+
+	// Stop account monitor
+	if app.MonitorUC != nil {
+		app.Logger.Println("   → Stopping account monitor...")
+		app.MonitorUC.Stop()
+		app.Logger.Println("   ✅ Account monitor stopped")
+	}
+
 	// Stop cleanup goroutine
 	if app.PendingStore != nil {
 		app.Logger.Println("   → Stopping pending payment cleanup...")
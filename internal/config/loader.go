@@ -2,6 +2,7 @@
 package config
 
 import (
+	"github.com/exernia/botjanweb/internal/domain/entity"
 	"github.com/exernia/botjanweb/pkg/constants"
 	"github.com/exernia/botjanweb/pkg/helper/parser"
 	"github.com/joho/godotenv"
@@ -17,6 +18,7 @@ func Load() (*Config, error) {
 	cfg := &Config{
 		WhatsAppDBPath:        getEnv("WHATSAPP_DB_PATH", "./whatsmeow.db"),
 		GroupJID:              getEnv("GROUP_JID", ""),
+		GroupsFile:            getEnv("GROUPS_FILE", ""),
 		QRISStaticPayload:     getEnv("QRIS_STATIC_PAYLOAD", ""),
 		MerchantName:          getEnv("MERCHANT_NAME", "JAJAN WEB"),
 		SheetsEnabled:         getEnvBool("SHEETS_ENABLED", false),
@@ -26,7 +28,20 @@ func Load() (*Config, error) {
 		SheetOrders:           getEnv("SHEET_ORDERS", "Pemesanan"),
 		SheetAkunGoogle:       getEnv("SHEET_AKUN_GOOGLE", "Akun Google"),
 		SheetAkunChatGPT:      getEnv("SHEET_AKUN_CHATGPT", "Akun ChatGPT"),
+		SheetAkunYouTube:      getEnv("SHEET_AKUN_YOUTUBE", "Akun YouTube"),
 		DefaultKanal:          getEnv("DEFAULT_KANAL", constants.DefaultKanal),
+		CatalogFile:           getEnv("CATALOG_FILE", ""),
+		RenewalReminderDays:   getEnvInt("RENEWAL_REMINDER_DAYS", constants.RenewalReminderDays),
+		RenewalReminderHour:   getEnvInt("RENEWAL_REMINDER_HOUR", constants.RenewalReminderHour),
+		AccountMonitorHours:   getEnvInt("ACCOUNT_MONITOR_HOURS", constants.AccountMonitorHours),
+		AccountExpiryWarnDays: getEnvInt("ACCOUNT_EXPIRY_WARN_DAYS", constants.AccountExpiryWarnDays),
+		RedeemLowStock:        getEnvInt("REDEEM_LOW_STOCK", constants.RedeemLowStockThreshold),
+		EncryptionKey:         getEnv("ENCRYPTION_KEY", ""),
+		DefaultRole:           getEnv("DEFAULT_ROLE", string(entity.RoleViewer)),
+		AlertWebhookURL:       getEnv("ALERT_WEBHOOK_URL", ""),
+		AlertSMTPAddr:         getEnv("ALERT_SMTP_ADDR", ""),
+		AlertEmailFrom:        getEnv("ALERT_EMAIL_FROM", ""),
+		AlertEmailTo:          getEnv("ALERT_EMAIL_TO", ""),
 		WebhookEnabled:        getEnvBool("WEBHOOK_ENABLED", false),
 		WebhookPort:           getWebhookPort(),
 		WebhookSecret:         getEnv("WEBHOOK_SECRET", ""),
@@ -36,6 +51,7 @@ func Load() (*Config, error) {
 
 	// Parse allowed senders (comma-separated) using common utility
 	cfg.AllowedSenders = parser.ParsePhoneList(getEnv("ALLOWED_SENDERS", ""))
+	cfg.OwnerPhones = parser.ParsePhoneList(getEnv("OWNER_PHONES", ""))
 
 	// Validate required fields
 	if err := cfg.validate(); err != nil {
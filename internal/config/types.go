@@ -10,7 +10,12 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/secret"
 )
 
 // Config holds all application configuration values.
@@ -25,6 +30,9 @@ type Config struct {
 	// Target WhatsApp group JID (e.g., "123456789-1234567890@g.us")
 	GroupJID string
 
+	// Path to groups JSON file (optional, multi-group; replaces GroupJID)
+	GroupsFile string
+
 	// Static QRIS payload string (EMV format) to convert to dynamic
 	QRISStaticPayload string
 
@@ -41,10 +49,38 @@ type Config struct {
 	SheetOrders      string // Name of the orders sheet (customer orders)
 	SheetAkunGoogle  string // Name of the Google accounts sheet (account management)
 	SheetAkunChatGPT string // Name of the ChatGPT accounts sheet (account management)
+	SheetAkunYouTube string // Name of the YouTube head accounts sheet (YouTube validation)
 
 	// Default values for orders
 	DefaultKanal string // Default sales channel (e.g., "Threads")
 
+	// Product catalog (price list)
+	CatalogFile string // Path to catalog JSON file (optional, falls back to Harga sheet)
+
+	// Subscription renewal reminders (requires Google Sheets)
+	RenewalReminderDays int // Days before Tanggal Berakhir to remind customers (0 = disabled)
+	RenewalReminderHour int // Hour of day (WIB, 0-23) the reminder job runs
+
+	// Account health monitor (requires Google Sheets)
+	AccountMonitorHours   int // Scan Akun Google / Akun ChatGPT every N hours (0 = disabled)
+	AccountExpiryWarnDays int // Alert when an account ends within N days
+
+	// Redeem code stock (requires Google Sheets)
+	RedeemLowStock int // Alert the group when available Kode Perplexity fall below N (0 = disabled)
+
+	// Account password encryption (base64 32-byte key, optional)
+	EncryptionKey string // Encrypts Sandi in Akun Google / Akun ChatGPT (empty = plain text)
+
+	// Role-based command permissions
+	OwnerPhones []string // Fixed owners (can use #role), normalized
+	DefaultRole string   // Role of allowed senders without a #role assignment
+
+	// Fallback alerts when WhatsApp is logged out / cannot reconnect (optional)
+	AlertWebhookURL string // POST JSON alerts to this URL
+	AlertSMTPAddr   string // SMTP relay host:port (no auth, e.g. local Postfix/MailHog)
+	AlertEmailFrom  string // Sender address for email alerts
+	AlertEmailTo    string // Recipients for email alerts (comma-separated)
+
 	// Webhook configuration for payment notifications
 	WebhookEnabled bool   // Toggle to enable/disable webhook server
 	WebhookPort    int    // Port number for webhook server
@@ -59,11 +95,11 @@ type Config struct {
 
 // validate checks that all required configuration values are present and valid.
 func (c *Config) validate() error {
-	// Required: GroupJID
-	if c.GroupJID == "" {
-		return fmt.Errorf("GROUP_JID is required")
+	// Required: GroupJID, unless groups come from GROUPS_FILE
+	if c.GroupJID == "" && c.GroupsFile == "" {
+		return fmt.Errorf("GROUP_JID is required (or GROUPS_FILE for multiple groups)")
 	}
-	if !strings.HasSuffix(c.GroupJID, "@g.us") {
+	if c.GroupJID != "" && !strings.HasSuffix(c.GroupJID, "@g.us") {
 		return fmt.Errorf("GROUP_JID must be a group JID (must end with @g.us), got: %s", c.GroupJID)
 	}
 
@@ -108,6 +144,56 @@ func (c *Config) validate() error {
 		}
 	}
 
+	// Renewal reminder schedule
+	if c.RenewalReminderDays < 0 {
+		return fmt.Errorf("RENEWAL_REMINDER_DAYS must be 0 or more, got: %d", c.RenewalReminderDays)
+	}
+	if c.RenewalReminderHour < 0 || c.RenewalReminderHour > 23 {
+		return fmt.Errorf("RENEWAL_REMINDER_HOUR must be between 0-23, got: %d", c.RenewalReminderHour)
+	}
+
+	// Account monitor schedule
+	if c.AccountMonitorHours < 0 {
+		return fmt.Errorf("ACCOUNT_MONITOR_HOURS must be 0 or more, got: %d", c.AccountMonitorHours)
+	}
+
+	// Redeem code low-stock alert
+	if c.RedeemLowStock < 0 {
+		return fmt.Errorf("REDEEM_LOW_STOCK must be 0 or more, got: %d", c.RedeemLowStock)
+	}
+
+	// Encryption key must be a valid AES-256 key
+	if c.EncryptionKey != "" {
+		if _, err := secret.NewCipher(c.EncryptionKey); err != nil {
+			return fmt.Errorf("ENCRYPTION_KEY invalid (generate with: openssl rand -base64 32): %w", err)
+		}
+	}
+
+	// Roles
+	for i, phone := range c.OwnerPhones {
+		if len(phone) < 10 || len(phone) > 15 {
+			return fmt.Errorf("OWNER_PHONES[%d] invalid phone number: %s (must be 10-15 digits)", i, phone)
+		}
+	}
+	if _, err := entity.ParseRole(c.DefaultRole); err != nil {
+		return fmt.Errorf("DEFAULT_ROLE must be owner, admin, reseller or viewer, got: %s", c.DefaultRole)
+	}
+
+	// Fallback alert channels
+	if c.AlertWebhookURL != "" {
+		if u, err := url.Parse(c.AlertWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("ALERT_WEBHOOK_URL must be an http(s) URL, got: %s", c.AlertWebhookURL)
+		}
+	}
+	if c.AlertSMTPAddr != "" {
+		if _, _, err := net.SplitHostPort(c.AlertSMTPAddr); err != nil {
+			return fmt.Errorf("ALERT_SMTP_ADDR must be host:port, got: %s", c.AlertSMTPAddr)
+		}
+		if c.AlertEmailFrom == "" || c.AlertEmailTo == "" {
+			return fmt.Errorf("ALERT_EMAIL_FROM and ALERT_EMAIL_TO are required when ALERT_SMTP_ADDR is set")
+		}
+	}
+
 	// Webhook config validation if enabled
 	if c.WebhookEnabled {
 		if c.WebhookPort <= 0 || c.WebhookPort > 65535 {
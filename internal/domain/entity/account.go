@@ -40,15 +40,107 @@ type AddAkunCommand struct {
 
 // ListAkunCommand represents a parsed #listakun command.
 type ListAkunCommand struct {
-	Tipe        AccountType // Google or ChatGPT (optional filter)
-	AccountType string      // Raw parameter from command
-	IsHelpMode  bool        // True if #listakun sent without parameters
+	Tipe         AccountType   // Google or ChatGPT (optional filter)
+	AccountType  string        // Raw parameter from command
+	Filter       AccountFilter // Account state filter (optional)
+	Group        string        // Family / Workspace name contains (optional, case-insensitive)
+	Search       string        // Email contains (optional, case-insensitive)
+	SortByExpiry bool          // Sort by Tanggal Berakhir, soonest first
+	Page         int           // 1-based page number
+	IsHelpMode   bool          // True if #listakun sent with unknown parameters
+}
+
+// AccountFilter filters #listakun by account state.
+type AccountFilter string
+
+// Account state filters.
+const (
+	AccountFilterAvailable AccountFilter = "tersedia" // Usable accounts
+	AccountFilterLocked    AccountFilter = "kekunci"  // Locked accounts
+	AccountFilterBanned    AccountFilter = "banned"   // Banned / suspended accounts
+	AccountFilterExpiring  AccountFilter = "berakhir" // Ending within the warning window (or ended)
+)
+
+// Match reports whether an account list item passes the filter.
+func (f AccountFilter) Match(item *AccountListItem) bool {
+	switch f {
+	case AccountFilterAvailable:
+		return item.Available
+	case AccountFilterLocked:
+		return item.Health == HealthLocked
+	case AccountFilterBanned:
+		return item.Health == HealthBanned
+	case AccountFilterExpiring:
+		return item.Health == HealthExpiring
+	}
+	return true
+}
+
+// AccountListItem is one row of the #listakun output (Google or ChatGPT).
+type AccountListItem struct {
+	Tipe      AccountType
+	Email     string
+	Group     string        // Family (Google) or Workspace (ChatGPT)
+	Note      string        // Keterangan (Google) or Status (ChatGPT)
+	Available bool          // Same rule as IsAvailable
+	Health    AccountHealth // Same rule as the account monitor
+	EndText   string        // Tanggal Berakhir as written in the sheet (Google only)
+	End       time.Time     // Parsed Tanggal Berakhir (zero if empty or unparseable)
+}
+
+// AccountListPage is one page of filtered #listakun results.
+type AccountListPage struct {
+	Items   []AccountListItem // Items of this page
+	Matched int               // Items matching the filters (all pages)
+	Page    int               // 1-based page number
+	Pages   int               // Total pages (at least 1)
+
+	// Sheet totals (before filters)
+	TotalGoogle      int
+	TotalChatGPT     int
+	AvailableGoogle  int
+	AvailableChatGPT int
+}
+
+// SandiCommand represents a parsed #sandi command (reveal an account password).
+type SandiCommand struct {
+	Email      string // Account email (Akun Google or Akun ChatGPT)
+	IsHelpMode bool   // True if #sandi sent without an email
+}
+
+// AccountCredential is a decrypted account login, only built for #sandi.
+type AccountCredential struct {
+	Tipe  AccountType
+	Email string
+	Sandi string // Decrypted password
+	Group string // Family (Google) or Workspace (ChatGPT)
+}
+
+// NewAkunGoogle builds a new Google account from an #addakun command.
+// The account is valid for 1 year from activation.
+func NewAkunGoogle(cmd *AddAkunCommand, now time.Time) *AkunGoogle {
+	return &AkunGoogle{
+		Email:           cmd.Email,
+		Sandi:           cmd.Sandi,
+		TanggalAktivasi: now,
+		TanggalBerakhir: now.AddDate(1, 0, 0).Format("2006-01-02"), // Format as YYYY-MM-DD
+	}
+}
+
+// NewAkunChatGPT builds a new ChatGPT account from an #addakun command.
+func NewAkunChatGPT(cmd *AddAkunCommand, now time.Time) *AkunChatGPT {
+	return &AkunChatGPT{
+		Email:           cmd.Email,
+		Sandi:           cmd.Sandi,
+		Workspace:       cmd.Workspace,
+		TanggalAktivasi: now,
+	}
 }
 
 // AkunGoogle represents a Google account entity for the Akun Google sheet.
 type AkunGoogle struct {
 	Email           string    // A: Email
-	Sandi           string    // B: Sandi
+	Sandi           string    // B: Sandi (encrypted "enc:v1:..." when ENCRYPTION_KEY is set)
 	TanggalAktivasi time.Time // C: Tanggal Aktivasi
 	TanggalBerakhir string    // D: Tanggal Berakhir
 	StatusDibuat    string    // E: Creation status (Family name)
@@ -56,15 +148,28 @@ type AkunGoogle struct {
 	Keterangan      string    // G: Notes/remarks
 }
 
+// Keterangan/Status keywords that make an account unavailable.
+var (
+	lockedKeywords = []string{"kekunci", "terkunci", "locked"}
+	bannedKeywords = []string{"banned", "suspend", "disabled"}
+)
+
+// containsAny reports whether s (lowercased) contains any of the keywords.
+func containsAny(s string, keywords []string) bool {
+	s = strings.ToLower(s)
+	for _, kw := range keywords {
+		if strings.Contains(s, kw) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsAvailable checks if Google account is still available.
 func (a *AkunGoogle) IsAvailable() bool {
 	// Check if notes field contains unavailable keywords
-	ket := strings.ToLower(a.Keterangan)
-	unavailableKeywords := []string{"kekunci", "terkunci", "locked", "banned", "suspend", "disabled"}
-	for _, kw := range unavailableKeywords {
-		if strings.Contains(ket, kw) {
-			return false
-		}
+	if containsAny(a.Keterangan, lockedKeywords) || containsAny(a.Keterangan, bannedKeywords) {
+		return false
 	}
 
 	// Check if Tanggal Berakhir has passed (simple check)
@@ -79,10 +184,46 @@ func (a *AkunGoogle) IsAvailable() bool {
 	return true
 }
 
+// EndDate returns the parsed Tanggal Berakhir (false if empty or unparseable).
+func (a *AkunGoogle) EndDate() (time.Time, bool) {
+	if a.TanggalBerakhir == "" {
+		return time.Time{}, false
+	}
+	end, err := parseFlexibleDate(a.TanggalBerakhir)
+	return end, err == nil
+}
+
+// Health classifies the account for the account monitor.
+// Expiring covers accounts ending within warnDays (or already ended).
+func (a *AkunGoogle) Health(now time.Time, warnDays int) (AccountHealth, string) {
+	switch {
+	case containsAny(a.Keterangan, bannedKeywords):
+		return HealthBanned, a.Keterangan
+	case containsAny(a.Keterangan, lockedKeywords):
+		return HealthLocked, a.Keterangan
+	}
+
+	if a.TanggalBerakhir == "" {
+		return HealthOK, ""
+	}
+	expiry, err := parseFlexibleDate(a.TanggalBerakhir)
+	if err != nil {
+		return HealthOK, ""
+	}
+	daysLeft := int(expiry.Sub(now).Hours() / 24)
+	switch {
+	case now.After(expiry):
+		return HealthExpiring, "sudah berakhir " + a.TanggalBerakhir
+	case daysLeft <= warnDays:
+		return HealthExpiring, "berakhir " + a.TanggalBerakhir
+	}
+	return HealthOK, ""
+}
+
 // AkunChatGPT represents a ChatGPT account entity for the Akun ChatGPT sheet.
 type AkunChatGPT struct {
 	Email           string    // A: Email
-	Sandi           string    // B: Password
+	Sandi           string    // B: Password (encrypted "enc:v1:..." when ENCRYPTION_KEY is set)
 	Workspace       string    // C: Workspace
 	Status          string    // D: Status (Safe / Banned)
 	TanggalAktivasi time.Time // E: Tanggal Aktivasi
@@ -99,6 +240,68 @@ func (a *AkunChatGPT) IsAvailable() bool {
 	return true
 }
 
+// IsBanned reports whether the workspace is banned (not merely locked or full).
+func (a *AkunChatGPT) IsBanned() bool {
+	health, _ := a.Health()
+	return health == HealthBanned
+}
+
+// Health classifies the account for the account monitor.
+// ChatGPT accounts have no end date, only ban/lock status.
+func (a *AkunChatGPT) Health() (AccountHealth, string) {
+	switch {
+	case strings.Contains(strings.ToLower(a.Status), "ban") || a.TanggalKenaBan != "":
+		detail := a.Status
+		if a.TanggalKenaBan != "" {
+			detail = strings.TrimSpace(detail + " " + a.TanggalKenaBan)
+		}
+		return HealthBanned, detail
+	case containsAny(a.Status, lockedKeywords):
+		return HealthLocked, a.Status
+	}
+	return HealthOK, ""
+}
+
+// AccountHealth is the monitor status of an account (higher = more urgent).
+type AccountHealth int
+
+// Account health levels, in alert priority order.
+const (
+	HealthOK AccountHealth = iota
+	HealthExpiring
+	HealthLocked
+	HealthBanned
+)
+
+// Label returns the display label for the health level.
+func (h AccountHealth) Label() string {
+	switch h {
+	case HealthBanned:
+		return "BANNED"
+	case HealthLocked:
+		return "TERKUNCI"
+	case HealthExpiring:
+		return "AKAN BERAKHIR"
+	}
+	return "OK"
+}
+
+// AccountIssue is one unhealthy account reported by the account monitor.
+type AccountIssue struct {
+	Type    AccountType   // Google or ChatGPT
+	Email   string        // Account email (slot owner in product sheets)
+	Name    string        // Family name (Google) or Workspace (ChatGPT)
+	Health  AccountHealth // Banned / Locked / Expiring
+	Detail  string        // Keterangan/Status/end date
+	Members int           // Paying members on this account
+	IsNew   bool          // First seen (or worsened) since the previous scan
+}
+
+// Key identifies the account across scans.
+func (i AccountIssue) Key() string {
+	return string(i.Type) + "|" + strings.ToLower(i.Email)
+}
+
 // parseFlexibleDate parses date in various formats.
 func parseFlexibleDate(s string) (time.Time, error) {
 	formats := []string{
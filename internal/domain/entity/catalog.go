@@ -0,0 +1,48 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import "strings"
+
+// CatalogItem represents one price list entry (product + paket).
+type CatalogItem struct {
+	Produk   Product // Product key (Gemini, ChatGPT, ...)
+	Paket    string  // Package/duration (e.g. "30 Hari"), empty = default for product
+	Harga    int     // List price
+	MinHarga int     // Lowest accepted nominal (0 = derived from Harga)
+	MaxHarga int     // Highest accepted nominal (0 = derived from Harga)
+	MaxSlots int     // Slot limit per family/workspace/head (0 = product default)
+}
+
+// AmountRange returns the accepted nominal range for this item.
+// When Min/Max are not set, the range is Harga ± tolerancePercent.
+func (c CatalogItem) AmountRange(tolerancePercent int) (int, int) {
+	minHarga, maxHarga := c.MinHarga, c.MaxHarga
+	if minHarga <= 0 {
+		minHarga = c.Harga - c.Harga*tolerancePercent/100
+	}
+	if maxHarga <= 0 {
+		maxHarga = c.Harga + c.Harga*tolerancePercent/100
+	}
+	return minHarga, maxHarga
+}
+
+// UniqueCodeLimit returns the largest unique code that can be added to Harga
+// without leaving the accepted range (at most maxCode, never negative).
+func (c CatalogItem) UniqueCodeLimit(tolerancePercent, maxCode int) int {
+	_, maxHarga := c.AmountRange(tolerancePercent)
+	return max(0, min(maxCode, maxHarga-c.Harga))
+}
+
+// MatchesPaket reports whether this item applies to the given paket (case-insensitive).
+func (c CatalogItem) MatchesPaket(paket string) bool {
+	return strings.EqualFold(strings.TrimSpace(c.Paket), strings.TrimSpace(paket))
+}
+
+// PriceCheck hasil validasi nominal terhadap katalog harga.
+type PriceCheck struct {
+	IsValid      bool         // Apakah nominal diterima
+	Item         *CatalogItem // Entry katalog yang cocok (nil jika produk tidak terdaftar)
+	Amount       int          // Nominal final (hasil auto-fill atau input user)
+	AutoFilled   bool         // True jika nominal diisi otomatis dari katalog
+	ErrorMessage string       // Error message if validation fails
+}
@@ -1,6 +1,11 @@
 // Package entity defines core business entities used across all layers.
 package entity
 
+import (
+	"fmt"
+	"time"
+)
+
 // Command prefixes for bot commands.
 const (
 	CmdQris      = "#qris"
@@ -8,6 +13,17 @@ const (
 	CmdCekSlot   = "#cekslot"
 	CmdCekKode   = "#cekkode"
 	CmdInputKode = "#inputkode"
+	CmdHarga     = "#harga"
+	CmdPindah    = "#pindah"
+	CmdMember    = "#member"
+	CmdImport    = "#import"
+	CmdExport    = "#export"
+	CmdKode      = "#kode"
+	CmdSandi     = "#sandi"
+	CmdListAkun  = "#listakun"
+	CmdRole      = "#role"
+	CmdHelp      = "#help"
+	CmdOrder     = "#order"
 )
 
 // QrisCommand represents a parsed #qris command.
@@ -25,9 +41,29 @@ type QrisCommand struct {
 	Workspace string // Workspace name
 	Paket     string // Package type
 
+	// YouTube-specific
+	Head string // Head account email (YouTube Family owner)
+
 	// Optional fields
-	Kanal string // Sales channel (default: Threads)
-	Akun  string // Account identifier
+	Kanal   string // Sales channel (default: Threads)
+	Akun    string // Account identifier
+	Voucher string // Voucher code (optional)
+
+	// Set by the price check, before the voucher and unique code change Amount
+	Price int // Catalog price, or the typed nominal when accepted as is
+
+	// Set after voucher validation
+	Discount int // Voucher discount already subtracted from Amount
+
+	// Set by the duplicate check when the order renews an existing member row
+	RenewalRow int       // Product sheet row being renewed (0 = new order)
+	ExpiresAt  time.Time // Current end date of the renewed membership
+
+	// Form lines whose label matched no field (ignored, reported to the sender)
+	Unknown []string
+
+	// Set when an edited #qris form replaces its unpaid QRIS
+	Replaces string // QRIS message ID being replaced (not counted as a duplicate)
 
 	// Self-QRIS specific
 	TargetPhone string // Target phone number for self-QRIS (e.g., untuk:6281234567890)
@@ -36,7 +72,71 @@ type QrisCommand struct {
 	// Mode flags
 	IsFormMode  bool   // True if parsed from form format
 	IsHelpMode  bool   // True if command sent without parameters
-	ProductType string // Raw parameter ("google", "chatgpt")
+	ProductType string // Raw parameter ("google", "chatgpt", "youtube")
+}
+
+// Field returns the value of a form field by canonical key (FieldNama, ...).
+func (c *QrisCommand) Field(key string) string {
+	switch key {
+	case FieldNama:
+		return c.Nama
+	case FieldEmail:
+		return c.Email
+	case FieldFamily:
+		return c.Family
+	case FieldWorkspace:
+		return c.Workspace
+	case FieldHead:
+		return c.Head
+	case FieldPaket:
+		return c.Paket
+	case FieldKanal:
+		return c.Kanal
+	case FieldAkun:
+		return c.Akun
+	case FieldVoucher:
+		return c.Voucher
+	case FieldNominal:
+		if c.Amount > 0 {
+			return fmt.Sprintf("%d", c.Amount)
+		}
+	}
+	return ""
+}
+
+// SetField sets a text form field by canonical key.
+// Empty Kanal/Akun keep their current (default) value. Nominal is parsed by the caller.
+func (c *QrisCommand) SetField(key, value string) {
+	switch key {
+	case FieldNama:
+		c.Nama = value
+	case FieldEmail:
+		c.Email = value
+	case FieldFamily:
+		c.Family = value
+	case FieldWorkspace:
+		c.Workspace = value
+	case FieldHead:
+		c.Head = value
+	case FieldPaket:
+		c.Paket = value
+	case FieldKanal:
+		if value != "" {
+			c.Kanal = value
+		}
+	case FieldAkun:
+		if value != "" {
+			c.Akun = value
+		}
+	case FieldVoucher:
+		c.Voucher = value
+	}
+}
+
+// SlotOwner returns the slot owner for the ordered product
+// (Family for Gemini, Workspace for ChatGPT, Head for YouTube, "" otherwise).
+func (c *QrisCommand) SlotOwner() string {
+	return c.Field(Product(c.Produk).Info().SlotField)
 }
 
 // CekSlotCommand represents a parsed #cekslot command.
@@ -46,9 +146,88 @@ type CekSlotCommand struct {
 	IsHelpMode    bool   // True if command sent without parameters
 }
 
+// MemberCommand represents a parsed #member command.
+type MemberCommand struct {
+	Owner      string // Family / workspace owner email (column D)
+	IsHelpMode bool   // True if command sent without parameters
+}
+
 // InputKodeCommand represents a parsed #inputkode command.
 type InputKodeCommand struct {
 	Email      string // Email for the redeem code
 	KodeRedeem string // The redeem code
 	IsHelpMode bool   // True if command sent without parameters
 }
+
+// KodeAction is a #kode lifecycle action.
+type KodeAction string
+
+// #kode actions.
+const (
+	KodePakai   KodeAction = "pakai"   // Mark used by a customer
+	KodeTanggal KodeAction = "tanggal" // Set activation / end date
+	KodeInvalid KodeAction = "invalid" // Flag as not working
+	KodeLepas   KodeAction = "lepas"   // Release back to stock
+)
+
+// KodeCommand represents a parsed #kode command.
+type KodeCommand struct {
+	Action     KodeAction
+	Kode       string    // Redeem code (column C)
+	Customer   string    // pakai: customer email
+	Activated  time.Time // tanggal: activation date
+	Ends       time.Time // tanggal: end date (zero = unchanged)
+	Reason     string    // invalid: optional reason
+	IsHelpMode bool      // True if command sent without (valid) parameters
+}
+
+// RoleAction is a #role action.
+type RoleAction string
+
+// #role actions.
+const (
+	RoleList   RoleAction = "list"  // Show all assignments
+	RoleGrant  RoleAction = "beri"  // Grant a role to a phone
+	RoleRevoke RoleAction = "hapus" // Remove a phone's role (back to DEFAULT_ROLE)
+)
+
+// RoleCommand represents a parsed #role command.
+type RoleCommand struct {
+	Action     RoleAction
+	Phone      string // Normalized target phone (grant/revoke)
+	Role       Role   // grant: role to give
+	IsHelpMode bool   // True if command sent without (valid) parameters
+}
+
+// OrderCommand represents a parsed #order command (step-by-step order wizard).
+type OrderCommand struct {
+	Produk string // Product to order; empty = the wizard asks for it
+	Cancel bool   // "#order batal": end the running wizard
+}
+
+// PindahCommand represents a parsed #pindah command.
+type PindahCommand struct {
+	Workspace  string // Banned workspace owner email or workspace name
+	Confirm    bool   // "#pindah ok" - apply the pending plan
+	Cancel     bool   // "#pindah batal" - drop the pending plan
+	IsHelpMode bool   // True if command sent without parameters
+}
+
+// ImportCommand represents a parsed #import command (sent as a document caption).
+type ImportCommand struct {
+	Kind       ImportKind // What the attached file contains
+	Confirm    bool       // "#import ok" - append the previewed rows
+	Cancel     bool       // "#import batal" - drop the preview
+	IsHelpMode bool       // True if command sent without a (known) kind
+}
+
+// ExportCommand represents a parsed #export command.
+type ExportCommand struct {
+	Kind          ExportKind  // Data set to export
+	Format        string      // ExportFormatCSV (default) or ExportFormatXLSX
+	Product       Product     // orders/slots: only this product ("" = all)
+	AccountType   AccountType // akun: only this type ("" = all)
+	AvailableOnly bool        // slots/kode: only rows with free slots / unused codes
+	Range         DateRange   // orders: Tanggal Pesanan range (zero = all)
+	IsHelpMode    bool        // True if command sent without a (known) kind
+}
@@ -0,0 +1,171 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import "strings"
+
+// CommandSpec describes a bot command. The registry drives dispatch,
+// permissions, per-group command lists and the generated #help.
+type CommandSpec struct {
+	Name        string   // Command prefix with "#" (e.g. "#qris")
+	Aliases     []string // Alternative prefixes, dispatched as Name
+	Description string   // One line shown in #help
+	Usage       []string // Usage examples shown in #help <command>
+	Role        Role     // Least privileged role allowed to use the command
+}
+
+// Commands is the command registry, in the order shown by #help.
+var Commands = []CommandSpec{
+	{
+		Name:        CmdHelp,
+		Aliases:     []string{"#bantuan", "#menu"},
+		Description: "Daftar perintah atau panduan satu perintah",
+		Usage:       []string{"#help", "#help qris"},
+		Role:        RoleViewer,
+	},
+	{
+		Name:        CmdHarga,
+		Aliases:     []string{"#pricelist"},
+		Description: "Daftar harga produk dari katalog",
+		Usage:       []string{"#harga", "#harga gemini"},
+		Role:        RoleViewer,
+	},
+	{
+		Name:        CmdCekSlot,
+		Aliases:     []string{"#slot"},
+		Description: "Cek slot Family/Workspace/Head yang masih kosong",
+		Usage:       []string{"#cekslot gemini", "#cekslot chatgpt semua"},
+		Role:        RoleViewer,
+	},
+	{
+		Name:        CmdQris,
+		Description: "Buat QRIS pembayaran dari form order",
+		Usage:       []string{"#qris", "#qris gemini", "#qris chatgpt"},
+		Role:        RoleReseller,
+	},
+	{
+		Name:        CmdOrder,
+		Aliases:     []string{"#pesan"},
+		Description: "Order langkah demi langkah: bot menanyakan setiap field lalu membuat QRIS",
+		Usage:       []string{"#order", "#order gemini", "#order batal"},
+		Role:        RoleReseller,
+	},
+	{
+		Name:        CmdMember,
+		Description: "Daftar member sebuah Family/Workspace/Head",
+		Usage:       []string{"#member family01@gmail.com"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdAddAkun,
+		Description: "Tambah akun Google / ChatGPT ke sheet akun",
+		Usage:       []string{"#addakun google", "#addakun chatgpt"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdListAkun,
+		Aliases:     []string{"#akun"},
+		Description: "Daftar akun dengan filter, pencarian dan halaman",
+		Usage:       []string{"#listakun google tersedia", "#listakun google berakhir urut", "#listakun cari john hal 2"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdSandi,
+		Description: "Kirim sandi akun lewat chat pribadi",
+		Usage:       []string{"#sandi john@example.com"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdCekKode,
+		Aliases:     []string{"#stokkode"},
+		Description: "Cek stok Kode Perplexity",
+		Usage:       []string{"#cekkode", "#cekkode semua"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdInputKode,
+		Description: "Tambah Kode Perplexity baru",
+		Usage:       []string{"#inputkode user@gmail.com ABC123XYZ"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdKode,
+		Description: "Kelola Kode Perplexity (pakai, tanggal, invalid, lepas)",
+		Usage:       []string{"#kode pakai PPLX-ABC123 john@gmail.com", "#kode tanggal PPLX-ABC123 2026-10-01 2027-10-01", "#kode lepas PPLX-ABC123"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdPindah,
+		Description: "Pindahkan member dari workspace yang dibanned",
+		Usage:       []string{"#pindah", "#pindah owner@gmail.com", "#pindah ok", "#pindah batal"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdImport,
+		Description: "Import massal akun / kode dari file CSV atau XLSX (sebagai caption dokumen)",
+		Usage:       []string{"#import akun google", "#import kode", "#import ok"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdExport,
+		Description: "Export order, slot, kode atau akun sebagai dokumen",
+		Usage:       []string{"#export orders gemini", "#export slots chatgpt tersedia", "#export kode"},
+		Role:        RoleAdmin,
+	},
+	{
+		Name:        CmdRole,
+		Description: "Atur role pengguna (owner, admin, reseller, viewer)",
+		Usage:       []string{"#role", "#role beri 081234567890 reseller", "#role hapus 081234567890"},
+		Role:        RoleOwner,
+	},
+}
+
+// FindCommand returns the command with the given name or alias
+// (case-insensitive, "#" optional).
+func FindCommand(name string) (CommandSpec, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "#") {
+		name = "#" + name
+	}
+	for _, spec := range Commands {
+		if spec.Name == name {
+			return spec, true
+		}
+		for _, alias := range spec.Aliases {
+			if alias == name {
+				return spec, true
+			}
+		}
+	}
+	return CommandSpec{}, false
+}
+
+// MatchCommand finds the command a message starts with (longest matching
+// name or alias). The returned text has an alias prefix replaced by the
+// command name, so parsers only need to know the name.
+func MatchCommand(text string) (CommandSpec, string, bool) {
+	lower := strings.ToLower(text)
+	var (
+		match  CommandSpec
+		prefix string
+	)
+	for _, spec := range Commands {
+		for _, p := range append([]string{spec.Name}, spec.Aliases...) {
+			if len(p) > len(prefix) && strings.HasPrefix(lower, p) {
+				match, prefix = spec, p
+			}
+		}
+	}
+	if prefix == "" {
+		return CommandSpec{}, text, false
+	}
+	return match, match.Name + text[len(prefix):], true
+}
+
+// CommandNames returns the names of all registered commands.
+func CommandNames() []string {
+	names := make([]string, 0, len(Commands))
+	for _, spec := range Commands {
+		names = append(names, spec.Name)
+	}
+	return names
+}
@@ -0,0 +1,30 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import "time"
+
+// ConnectionState is the WhatsApp connection state tracked by the connection supervisor.
+type ConnectionState string
+
+// WhatsApp connection states.
+const (
+	ConnNotPaired    ConnectionState = "not_paired"   // No device session yet (waiting for pairing)
+	ConnConnecting   ConnectionState = "connecting"   // First connect in progress
+	ConnConnected    ConnectionState = "connected"    // Authenticated and able to send
+	ConnReconnecting ConnectionState = "reconnecting" // Connection lost, retrying with backoff
+	ConnLoggedOut    ConnectionState = "logged_out"   // Session revoked, needs re-pairing
+)
+
+// ConnectionStatus is a snapshot of the WhatsApp connection.
+type ConnectionStatus struct {
+	State     ConnectionState
+	Since     time.Time // When the current state was entered
+	Attempts  int       // Reconnect attempts in the current outage
+	LastError string    // Last connect error or logout reason
+	Queued    int       // Outbound messages waiting for the connection
+}
+
+// IsReady reports whether messages can be sent right now.
+func (s ConnectionStatus) IsReady() bool {
+	return s.State == ConnConnected
+}
@@ -0,0 +1,15 @@
+package entity
+
+// DuplicateCheck is the result of checking an order email against the
+// product sheet and unpaid QRIS (pending payments).
+type DuplicateCheck struct {
+	Pending  *PendingPayment  // Unpaid QRIS for the same email + product (blocks)
+	Conflict *WorkspaceMember // Active membership under another owner (blocks)
+	Renewal  *WorkspaceMember // Membership under the same owner: order renews this row
+	Expired  []WorkspaceMember
+}
+
+// IsBlocked reports whether the order must not get a QRIS.
+func (c *DuplicateCheck) IsBlocked() bool {
+	return c.Pending != nil || c.Conflict != nil
+}
@@ -0,0 +1,54 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import "time"
+
+// ExportKind is the data set produced by #export.
+type ExportKind string
+
+// Export kinds.
+const (
+	ExportOrders ExportKind = "orders"
+	ExportSlots  ExportKind = "slots"
+	ExportKode   ExportKind = "kode"
+	ExportAkun   ExportKind = "akun"
+)
+
+// Export file formats.
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// DateRange is a period of days in WIB. From is inclusive, To is exclusive;
+// a zero bound means unbounded.
+type DateRange struct {
+	From  time.Time
+	To    time.Time
+	Label string // As typed by the admin, e.g. "2026-10"
+}
+
+// IsZero reports whether the range has no bounds (all dates).
+func (r DateRange) IsZero() bool {
+	return r.From.IsZero() && r.To.IsZero()
+}
+
+// Contains reports whether t falls inside the range.
+// A zero t (unknown date) is only inside an unbounded range.
+func (r DateRange) Contains(t time.Time) bool {
+	if r.IsZero() {
+		return true
+	}
+	if t.IsZero() {
+		return false
+	}
+	return (r.From.IsZero() || !t.Before(r.From)) && (r.To.IsZero() || t.Before(r.To))
+}
+
+// ExportFile is a generated document ready to be sent.
+type ExportFile struct {
+	FileName string
+	MimeType string
+	Data     []byte
+	Rows     int // Data rows (without header)
+}
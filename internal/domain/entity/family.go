@@ -1,35 +1,30 @@
 // Package entity defines core business entities used across all layers.
 package entity
 
+import "fmt"
+
 // SpecialFamilies lists special family accounts that don't require validation against Google Accounts.
 // These are internal family accounts that are pre-configured.
 var SpecialFamilies = map[string]bool{
 	"Rumah Premium": true,
 }
 
-// IsSpecialFamily mengecek apakah family termasuk family khusus.
-func IsSpecialFamily(family string) bool {
-	return SpecialFamilies[family]
-}
-
-// FamilyValidation hasil validasi family (Gemini).
-type FamilyValidation struct {
-	IsValid      bool   // Apakah family valid
-	IsSpecial    bool   // Apakah family khusus (skip validasi Akun Google)
-	Email        string // Email family (jika bukan special)
-	UsedSlots    int    // Jumlah slot terpakai
-	MaxSlots     int    // Maksimal slot (5 for Gemini)
-	ErrorMessage string // Error message if validation fails
+// SlotValidation hasil validasi pemilik slot (family/workspace/head).
+type SlotValidation struct {
+	IsValid      bool    // Apakah pemilik slot valid dan masih ada slot
+	IsSpecial    bool    // Apakah pemilik khusus (skip validasi sheet akun)
+	Product      Product // Produk yang divalidasi
+	Owner        string  // Family/email owner/email head (input dari user)
+	UsedSlots    int     // Jumlah slot terpakai
+	MaxSlots     int     // Maksimal slot (dari registry atau katalog)
+	ErrorMessage string  // Error message if validation fails
 }
 
-// WorkspaceValidation hasil validasi workspace (ChatGPT).
-type WorkspaceValidation struct {
-	IsValid      bool   // Apakah workspace valid
-	OwnerEmail   string // Email pemilik workspace (input dari user)
-	UsedSlots    int    // Jumlah slot terpakai
-	MaxSlots     int    // Maksimal slot (4 for ChatGPT)
-	ErrorMessage string // Error message if validation fails
+// Status returns a formatted string showing slot usage.
+func (v *SlotValidation) Status() string {
+	remaining := v.MaxSlots - v.UsedSlots
+	if remaining <= 0 {
+		return fmt.Sprintf("❌ %s: Penuh (%d/%d)", v.Owner, v.UsedSlots, v.MaxSlots)
+	}
+	return fmt.Sprintf("✅ %s: %d/%d terpakai, %d tersedia", v.Owner, v.UsedSlots, v.MaxSlots, remaining)
 }
-
-// constants.MaxFamilySlots adalah batas maksimal anggota per family (Gemini).
-// constants.MaxWorkspaceSlots adalah batas maksimal anggota per workspace (ChatGPT).
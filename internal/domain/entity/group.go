@@ -0,0 +1,105 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import (
+	"slices"
+	"strings"
+)
+
+// NotifyKind is a kind of bot-initiated group notification.
+type NotifyKind string
+
+// Notification kinds that can be routed to specific groups.
+const (
+	NotifyPayment NotifyKind = "payment" // Self-QRIS / renewal QRIS notices and their payment results
+	NotifyRenewal NotifyKind = "renewal" // Daily renewal reminder digest
+	NotifyMonitor NotifyKind = "monitor" // Account health alerts
+	NotifyStock   NotifyKind = "stock"   // Redeem code low-stock alerts
+)
+
+// NotifyKinds lists all notification kinds (the legacy single group receives all of them).
+var NotifyKinds = []NotifyKind{NotifyPayment, NotifyRenewal, NotifyMonitor, NotifyStock}
+
+// GroupConfig holds the settings of one WhatsApp group the bot serves.
+type GroupConfig struct {
+	Name           string       // Display name (logs only)
+	JID            string       // Group JID (e.g., "123456789-1234567890@g.us")
+	AllowedSenders []string     // Normalized phones allowed to use commands ("*" = everyone)
+	Kanal          string       // Default sales channel for #qris in this group
+	Commands       []string     // Enabled commands with "#" (empty = all)
+	Notify         []NotifyKind // Notifications routed to this group
+}
+
+// AllowsSender reports whether a normalized phone may use commands in this group.
+func (g GroupConfig) AllowsSender(phone string) bool {
+	if len(g.AllowedSenders) == 0 || (len(g.AllowedSenders) == 1 && g.AllowedSenders[0] == "*") {
+		return true
+	}
+	return slices.Contains(g.AllowedSenders, phone)
+}
+
+// AllowsCommand reports whether cmd (e.g. "#qris") is enabled in this group.
+func (g GroupConfig) AllowsCommand(cmd string) bool {
+	if len(g.Commands) == 0 {
+		return true
+	}
+	return slices.Contains(g.Commands, strings.ToLower(cmd))
+}
+
+// Receives reports whether this group is subscribed to a notification kind.
+func (g GroupConfig) Receives(kind NotifyKind) bool {
+	return slices.Contains(g.Notify, kind)
+}
+
+// GroupDirectory is the set of groups the bot serves. The first group is the
+// default group (used by GetGroupJID and for notifications nobody subscribed to).
+type GroupDirectory struct {
+	Groups []GroupConfig
+	Global GroupConfig // Settings for private chats (global ALLOWED_SENDERS / DEFAULT_KANAL)
+	Strict bool        // Ignore unlisted groups (false = legacy: any group uses Global)
+}
+
+// Default returns the default group.
+func (d *GroupDirectory) Default() GroupConfig {
+	if len(d.Groups) == 0 {
+		return d.Global
+	}
+	return d.Groups[0]
+}
+
+// Find returns the configured group with the given JID.
+func (d *GroupDirectory) Find(jid string) (GroupConfig, bool) {
+	for _, g := range d.Groups {
+		if g.JID == jid {
+			return g, true
+		}
+	}
+	return GroupConfig{}, false
+}
+
+// Lookup returns the settings that apply to a chat. False means the bot
+// should ignore the chat (an unlisted group in strict mode).
+func (d *GroupDirectory) Lookup(chatID string) (GroupConfig, bool) {
+	if g, ok := d.Find(chatID); ok {
+		return g, true
+	}
+	if d.Strict && strings.HasSuffix(chatID, "@g.us") {
+		return GroupConfig{}, false
+	}
+	return d.Global, true
+}
+
+// Targets returns the JIDs of the groups subscribed to kind,
+// or the default group when none is.
+func (d *GroupDirectory) Targets(kind NotifyKind) []string {
+	var jids []string
+	for _, g := range d.Groups {
+		if g.Receives(kind) {
+			jids = append(jids, g.JID)
+		}
+	}
+	if len(jids) == 0 {
+		jids = append(jids, d.Default().JID)
+	}
+	return jids
+}
@@ -0,0 +1,62 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import "time"
+
+// ImportKind is what an #import file contains.
+type ImportKind string
+
+// Import kinds.
+const (
+	ImportAkunGoogle  ImportKind = "akun google"
+	ImportAkunChatGPT ImportKind = "akun chatgpt"
+	ImportKode        ImportKind = "kode"
+)
+
+// Label returns the sheet the rows are appended to.
+func (k ImportKind) Label() string {
+	switch k {
+	case ImportAkunGoogle:
+		return ProductGemini.Info().AccountSheet
+	case ImportAkunChatGPT:
+		return ProductChatGPT.Info().AccountSheet
+	case ImportKode:
+		return "Kode Perplexity"
+	}
+	return string(k)
+}
+
+// HasPasswords reports whether files of this kind contain account passwords.
+func (k ImportKind) HasPasswords() bool {
+	return k == ImportAkunGoogle || k == ImportAkunChatGPT
+}
+
+// ImportRow is one data row of an #import file.
+// Exactly one of Account / Code is set, depending on the kind.
+type ImportRow struct {
+	Number  int               // Row number in the file
+	Account *AddAkunCommand   // Akun Google / Akun ChatGPT row
+	Code    *InputKodeCommand // Kode Perplexity row
+	Error   string            // Why the row is skipped ("" = valid)
+}
+
+// Email returns the email of the row.
+func (r *ImportRow) Email() string {
+	if r.Code != nil {
+		return r.Code.Email
+	}
+	if r.Account != nil {
+		return r.Account.Email
+	}
+	return ""
+}
+
+// ImportPreview is the dry-run result of an #import file, kept until
+// the admin confirms or cancels it.
+type ImportPreview struct {
+	Kind      ImportKind
+	FileName  string
+	Valid     []ImportRow // Rows that will be appended
+	Invalid   []ImportRow // Rows that are skipped, with Error set
+	CreatedAt time.Time
+}
@@ -1,6 +1,8 @@
 // Package entity defines core business entities used across all layers.
 package entity
 
+import "strings"
+
 // SlotInfo represents availability information for a family/workspace.
 type SlotInfo struct {
 	Name          string // Family name or Workspace name
@@ -25,6 +27,18 @@ type RedeemCodeInfo struct {
 	KodeRedeem      string // Redeem code
 	TanggalAktivasi string // Activation date (empty = available)
 	TanggalBerakhir string // Expiry date
+	Customer        string // Customer email the code was given to
+	Keterangan      string // Notes; "Invalid..." marks a code that doesn't work
+}
+
+// IsInvalid reports whether the code was flagged invalid (#kode invalid).
+func (c *RedeemCodeInfo) IsInvalid() bool {
+	return strings.HasPrefix(strings.ToLower(c.Keterangan), "invalid")
+}
+
+// IsAvailable reports whether the code can still be given to a customer.
+func (c *RedeemCodeInfo) IsAvailable() bool {
+	return c.TanggalAktivasi == "" && !c.IsInvalid()
 }
 
 // RedeemCodeResult contains the result of checking redeem code availability.
@@ -32,5 +46,6 @@ type RedeemCodeResult struct {
 	Codes          []RedeemCodeInfo // List of codes (all or available only)
 	TotalCodes     int              // Total codes in sheet
 	AvailableCodes int              // Codes not yet activated
+	InvalidCodes   int              // Codes flagged invalid
 	AvailableOnly  bool             // Whether showing only available ones
 }
@@ -5,6 +5,7 @@ package entity
 import "time"
 
 // Message represents an incoming WhatsApp message.
+// An edited message keeps the ID of the original message and carries the new text.
 type Message struct {
 	ID             string
 	ChatID         string // Chat JID as string (e.g., "123456789@g.us")
@@ -12,7 +13,16 @@ type Message struct {
 	SenderPhone    string // Phone number without +
 	Text           string
 	Timestamp      time.Time
-	IsSelfMessage  bool   // True if message is from bot itself
-	IsPrivateChat  bool   // True if message is in private chat (not group)
-	RecipientPhone string // Phone number of recipient (for private chats)
+	IsSelfMessage  bool      // True if message is from bot itself
+	IsPrivateChat  bool      // True if message is in private chat (not group)
+	IsEdit         bool      // True if Text is the corrected content of an edited message
+	RecipientPhone string    // Phone number of recipient (for private chats)
+	Document       *Document // Attached document (only for commands sent as a document caption)
+}
+
+// Document is a file attached to a message.
+type Document struct {
+	FileName string
+	MimeType string
+	Data     []byte
 }
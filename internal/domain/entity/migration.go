@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// WorkspaceMember is a member row in a product sheet (B=Nama, C=Email, D=owner).
+type WorkspaceMember struct {
+	Produk          Product
+	Row             int       // 1-based sheet row
+	Nama            string    // B: Nama
+	Email           string    // C: Email
+	Owner           string    // D: Family/WorkSpace/Email Head (ChatGPT: workspace name on older rows)
+	Akun            string    // Customer WA number (Gemini column I, ChatGPT column J), may be empty
+	TanggalPesanan  string    // Order date as written in the sheet
+	TanggalBerakhir string    // End date as written in the sheet
+	EndsAt          time.Time // Parsed TanggalBerakhir (zero if empty or unparseable)
+}
+
+// IsActiveAt reports whether the membership is still running at now.
+// Rows without a parseable end date count as active.
+func (m *WorkspaceMember) IsActiveAt(now time.Time) bool {
+	return m.EndsAt.IsZero() || !now.After(m.EndsAt.Add(24*time.Hour-time.Nanosecond))
+}
+
+// MemberMove moves one member to a target workspace.
+type MemberMove struct {
+	Member WorkspaceMember
+	Target AkunChatGPT
+}
+
+// MigrationPlan is a proposed move of all members off a banned workspace.
+// It is kept per chat until confirmed with "#pindah ok" or dropped.
+type MigrationPlan struct {
+	Source     AkunChatGPT
+	Moves      []MemberMove
+	Unassigned []WorkspaceMember // Members left over when free slots run out
+	CreatedAt  time.Time
+}
+
+// MatchesOwner reports whether a column D value points at this workspace
+// (owner email, or workspace name on rows written before emails were used).
+func (a *AkunChatGPT) MatchesOwner(owner string) bool {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return false
+	}
+	return strings.EqualFold(owner, a.Email) || (a.Workspace != "" && strings.EqualFold(owner, a.Workspace))
+}
+
+// MigrationResult summarizes an applied migration plan.
+type MigrationResult struct {
+	Source     AkunChatGPT
+	Moved      []MemberMove
+	Failed     []MemberMove // Sheet update failed; member still on the old workspace
+	Notified   int          // Customers messaged about their new workspace
+	Unnotified []MemberMove // Moved, but not messaged (no WA number or send failed)
+}
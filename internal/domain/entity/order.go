@@ -0,0 +1,92 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Order wizard replies that are not field answers (case-insensitive).
+const (
+	OrderReplyCancel = "batal"   // End the wizard without creating a QRIS
+	OrderReplyBack   = "kembali" // Go back to the previous question
+	OrderReplySkip   = "-"       // Leave an optional field empty
+)
+
+// orderSkipWords are accepted in place of OrderReplySkip.
+var orderSkipWords = []string{"skip", "lewati"}
+
+// IsOrderSkip reports whether an answer leaves the field empty ("-", "skip" or
+// "lewati", case-insensitive).
+func IsOrderSkip(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == OrderReplySkip {
+		return true
+	}
+	for _, w := range orderSkipWords {
+		if answer == w {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderSession is one sender's #order wizard in one chat: the answers so far
+// and the question being asked. The questions are the product's form fields,
+// preceded by the product itself when #order was sent without one.
+type OrderSession struct {
+	ChatID       string
+	SenderPhone  string       // Normalized phone of the customer-facing sender
+	Cmd          *QrisCommand // Answers so far; Produk is empty while choosing the product
+	Step         int          // Index of the current field in Fields()
+	ProductAsked bool         // The product was chosen in the wizard ("kembali" can return to it)
+	DefaultKanal string       // Kanal used when the Kanal question is skipped
+	UpdatedAt    time.Time    // Last answer; the session expires after OrderWizardMinutes
+}
+
+// Fields returns the questions of the chosen product (nil while choosing the product).
+func (s *OrderSession) Fields() []FormField {
+	if s.Cmd.Produk == "" {
+		return nil
+	}
+	return Product(s.Cmd.Produk).Info().Fields
+}
+
+// Current returns the field being asked. ok is false while choosing the product.
+func (s *OrderSession) Current() (FormField, bool) {
+	fields := s.Fields()
+	if s.Step >= len(fields) {
+		return FormField{}, false
+	}
+	return fields[s.Step], true
+}
+
+// SetProduct chooses the product and starts at its first field.
+func (s *OrderSession) SetProduct(p Product) {
+	s.Cmd = &QrisCommand{IsFormMode: true, Produk: string(p), Kanal: s.DefaultKanal}
+	s.Step = 0
+}
+
+// Next moves to the following question.
+func (s *OrderSession) Next() {
+	s.Step++
+}
+
+// Done reports whether every field has been answered.
+func (s *OrderSession) Done() bool {
+	return s.Cmd.Produk != "" && s.Step >= len(s.Fields())
+}
+
+// Back moves to the previous question. Returns false at the first question.
+// Going back from the first field returns to the product choice if it was asked.
+func (s *OrderSession) Back() bool {
+	switch {
+	case s.Step > 0:
+		s.Step--
+	case s.Cmd.Produk != "" && s.ProductAsked:
+		s.Cmd = &QrisCommand{IsFormMode: true, Kanal: s.DefaultKanal}
+	default:
+		return false
+	}
+	return true
+}
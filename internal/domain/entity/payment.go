@@ -15,6 +15,7 @@ type PendingPayment struct {
 	CreatedAt         time.Time // When the QRIS was created
 	IsSelfQris        bool      // True if created via self-message to customer
 	GroupNotifMsgID   string    // ID of "QRIS TERKIRIM" notification in group (for reply threading)
+	GroupJID          string    // Group that receives payment notices (empty = default group)
 
 	// Order data (from #qris form)
 	Produk    string // Product name (determines target sheet)
@@ -25,6 +26,21 @@ type PendingPayment struct {
 	Deskripsi string // Description/notes
 	Kanal     string // Sales channel (default: WhatsApp)
 	Akun      string // Account identifier (default: sender phone)
+	Voucher   string // Voucher code applied (usage recorded on confirmation)
+	Discount  int    // Voucher discount already subtracted from Amount
+
+	// Renewal (set by the renewal reminder job)
+	RenewalRow int       // Product sheet row being renewed (0 = new order)
+	ExpiresAt  time.Time // Current end date of the renewed subscription
+}
+
+// QrisChatID returns the chat the QRIS image was sent to: the order group for
+// #qris forms (even when sent from a private chat), the customer chat for self-QRIS.
+func (p *PendingPayment) QrisChatID() string {
+	if !p.IsSelfQris && p.GroupJID != "" {
+		return p.GroupJID
+	}
+	return p.ChatID
 }
 
 // DANANotification represents a parsed DANA payment notification.
@@ -37,31 +53,39 @@ type DANANotification struct {
 // Order represents an order to be logged to spreadsheet.
 // Column mappings vary by product (see orders.go for details).
 type Order struct {
-	Produk         string    // Determines target sheet
-	Nama           string    // B: Nama (all products)
-	Email          string    // C: Email (all products)
-	Family         string    // D: Family/WorkSpace (Gemini/ChatGPT) or Email Head (YouTube)
-	KodeRedeem     string    // D: Kode Redeem (Perplexity only)
-	Paket          string    // E: Paket (ChatGPT only: "20 Hari" or "30 Hari")
-	TanggalPesanan time.Time // E/F: Tanggal Pesanan (varies by product)
-	Amount         int       // G/H: Amount/Nominal (varies by product)
-	Kanal          string    // H/I: Kanal (varies by product)
-	Akun           string    // I/J: Akun/Nomor/Username/Bukti (varies by product)
+	Produk          string    // Determines target sheet
+	Nama            string    // B: Nama (all products)
+	Email           string    // C: Email (all products)
+	Family          string    // D: Family/WorkSpace (Gemini/ChatGPT) or Email Head (YouTube)
+	KodeRedeem      string    // D: Kode Redeem (Perplexity only)
+	Paket           string    // E: Paket (ChatGPT only: "20 Hari" or "30 Hari")
+	TanggalPesanan  time.Time // E/F: Tanggal Pesanan (varies by product)
+	TanggalBerakhir time.Time // F/G: Tanggal Berakhir (zero = not tracked for product)
+	Amount          int       // G/H: Amount/Nominal (varies by product)
+	Kanal           string    // H/I: Kanal (varies by product)
+	Akun            string    // I/J: Akun/Nomor/Username/Bukti (varies by product)
+	Voucher         string    // Voucher code (also counted in the Pemakaian Voucher sheet)
+	Discount        int       // Voucher discount (Diskon, next to Voucher)
 }
 
 // NewOrderFromPending creates an Order entity from a confirmed PendingPayment.
 func NewOrderFromPending(pending *PendingPayment) *Order {
+	now := time.Now()
+	days := Product(pending.Produk).Info().SubscriptionDays(pending.Paket)
 	return &Order{
-		Produk:         pending.Produk,
-		Nama:           pending.Nama,
-		Email:          pending.Email,
-		Family:         pending.Family,
-		KodeRedeem:     "", // Will be filled manually by admin for redeem-based products
-		Paket:          pending.Paket,
-		TanggalPesanan: time.Now(),
-		Amount:         pending.Amount,
-		Kanal:          pending.Kanal,
-		Akun:           pending.Akun,
+		Produk:          pending.Produk,
+		Nama:            pending.Nama,
+		Email:           pending.Email,
+		Family:          pending.Family,
+		KodeRedeem:      "", // Filled by ConfirmationService after claiming a code (Perplexity)
+		Paket:           pending.Paket,
+		TanggalPesanan:  now,
+		TanggalBerakhir: SubscriptionEnd(now, days),
+		Amount:          pending.Amount,
+		Kanal:           pending.Kanal,
+		Akun:            pending.Akun,
+		Voucher:         pending.Voucher,
+		Discount:        pending.Discount,
 	}
 }
 
@@ -2,6 +2,7 @@
 package entity
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -18,45 +19,303 @@ const (
 	ProductPerplexity Product = "Perplexity"
 )
 
-// ProductInfo contains display information for a product.
+// Form field keys (canonical, lowercase) shared by all product order forms.
+const (
+	FieldNama      = "nama"
+	FieldEmail     = "email"
+	FieldFamily    = "family"
+	FieldWorkspace = "workspace"
+	FieldHead      = "head"
+	FieldPaket     = "paket"
+	FieldNominal   = "nominal"
+	FieldKanal     = "kanal"
+	FieldAkun      = "akun"
+	FieldVoucher   = "voucher"
+)
+
+// FormField describes one line of a product order form.
+type FormField struct {
+	Key      string   // Canonical key (FieldNama, FieldFamily, ...)
+	Label    string   // Label shown in the form template ("Nama", "Head", ...)
+	Aliases  []string // Extra accepted labels, e.g. "email head" (matched ignoring case and punctuation)
+	Required bool     // Order is rejected if empty
+	Help     string   // Description shown in the help message
+	Example  string   // Example value shown in the help message
+}
+
+// SlotValidator validates a slot owner (family/workspace/head) before QRIS generation.
+// Implementations are built at startup (they need repositories) and passed to the
+// bot handler per product; the registry itself stays read-only.
+type SlotValidator interface {
+	ValidateSlot(ctx context.Context, owner string) (*SlotValidation, error)
+}
+
+// ProductInfo declares everything the bot needs to know about a product.
+// Adding a product means registering a ProductInfo (see RegisterProduct).
 type ProductInfo struct {
 	Key       Product // Short key for input
 	FullName  string  // Full display name
 	SheetName string  // Target spreadsheet sheet name
+
+	// Order form (#qris <Param>)
+	Param    string      // Command parameter, e.g. "google"
+	Aliases  []string    // Extra accepted parameters, e.g. "gemini"
+	Fields   []FormField // Form fields in template order
+	HelpNote string      // Optional note appended to the help message
+
+	// Slot rules (empty SlotField = product has no slots)
+	SlotField     string          // Field key holding the slot owner
+	SlotLabel     string          // Display label for the slot owner ("Family", "Workspace", ...)
+	SlotCapacity  int             // Default members per slot owner (catalog may override, see SlotCapacityPort)
+	AccountSheet  string          // Sheet listing valid slot owners (Email in column A); default, see SetAccountSheet
+	SpecialOwners map[string]bool // Owners that skip AccountSheet validation
+	OwnerColumn   int             // Product sheet column (0-based) holding the slot owner
+	MemberColumn  int             // Product sheet column holding the member email (filled = slot used)
+	HeaderRows    int             // Title/header rows above the first member row
+
+	// Fulfilment
+	RedeemCode   bool // Code claimed from Kode Perplexity on payment
+	DurationDays int  // Default subscription length when Paket has no duration (0 = end date not tracked)
+}
+
+// HasSlots reports whether orders for this product occupy a slot.
+func (i ProductInfo) HasSlots() bool {
+	return i.SlotField != ""
+}
+
+// IsSpecialOwner reports whether owner skips AccountSheet validation.
+func (i ProductInfo) IsSpecialOwner(owner string) bool {
+	return i.SpecialOwners[owner]
+}
+
+// Field returns the form field with the given key.
+func (i ProductInfo) Field(key string) (FormField, bool) {
+	for _, f := range i.Fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return FormField{}, false
+}
+
+// Common form fields; product-specific help/example are set per product.
+var (
+	formNama = FormField{Key: FieldNama, Label: "Nama", Aliases: []string{"name", "nama lengkap", "nama customer", "nama pembeli"},
+		Required: true, Help: "Nama lengkap (wajib)", Example: "John Doe"}
+	formEmail = FormField{Key: FieldEmail, Label: "Email", Aliases: []string{"e-mail", "mail", "gmail", "surel", "alamat email"},
+		Required: true, Help: "Alamat email (wajib)", Example: "john@example.com"}
+	formNominal = FormField{Key: FieldNominal, Label: "Nominal", Aliases: []string{"harga", "jumlah", "total", "bayar", "amount"},
+		Help: "Jumlah pembayaran (kosongkan = harga katalog)"}
+	formKanal = FormField{Key: FieldKanal, Label: "Kanal", Aliases: []string{"channel", "sumber", "platform"},
+		Help: "Channel pembelian (default: Threads)", Example: "Threads"}
+	formAkun = FormField{Key: FieldAkun, Label: "Akun", Aliases: []string{"username", "no hp", "nomor hp", "no wa", "nomor wa", "whatsapp", "wa", "hp"},
+		Help: "Username/akun (opsional)", Example: "@johndoe"}
+	formVoucher = FormField{Key: FieldVoucher, Label: "Voucher", Aliases: []string{"kode voucher", "promo", "kode promo", "kupon"},
+		Help: "Kode voucher diskon (opsional)", Example: "HEMAT10"}
+)
+
+// withExample returns a copy of f with a product-specific help text and example.
+func (f FormField) withExample(help, example string) FormField {
+	if help != "" {
+		f.Help = help
+	}
+	f.Example = example
+	return f
 }
 
 // Products maps product keys to their full information.
-var Products = map[Product]ProductInfo{
-	ProductChatGPT: {
-		Key:       ProductChatGPT,
-		FullName:  "ChatGPT Pro",
-		SheetName: "ChatGPT",
-	},
-	ProductGemini: {
+var Products = map[Product]ProductInfo{}
+
+// productOrder keeps registration order for listings and help.
+var productOrder []Product
+
+func init() {
+	RegisterProduct(ProductInfo{
 		Key:       ProductGemini,
 		FullName:  "GDrive 2TB + Gemini AI Pro",
 		SheetName: "Gemini",
-	},
-	ProductYouTube: {
+		Param:     "google",
+		Aliases:   []string{"gemini"},
+		Fields: []FormField{
+			formNama,
+			formEmail.withExample("Alamat Gmail (wajib)", "john@example.com"),
+			{Key: FieldFamily, Label: "Family", Aliases: []string{"family plan", "nama family", "grup family"}, Required: true, Help: "Nama family plan (wajib)", Example: "Rumah Premium"},
+			formNominal.withExample("", "49901"),
+			formKanal,
+			formAkun,
+			formVoucher,
+		},
+		SlotField:     FieldFamily,
+		SlotLabel:     "Family",
+		SlotCapacity:  5,
+		AccountSheet:  "Akun Google",
+		SpecialOwners: SpecialFamilies,
+		OwnerColumn:   3, // D
+		MemberColumn:  2, // C
+		HeaderRows:    2,
+		DurationDays:  30,
+	})
+	RegisterProduct(ProductInfo{
+		Key:       ProductChatGPT,
+		FullName:  "ChatGPT Pro",
+		SheetName: "ChatGPT",
+		Param:     "chatgpt",
+		Aliases:   []string{"gpt"},
+		Fields: []FormField{
+			formNama,
+			formEmail,
+			{Key: FieldWorkspace, Label: "Workspace", Aliases: []string{"ws", "email workspace", "owner workspace"}, Required: true, Help: "Email owner workspace (wajib)", Example: "gptadmin03@jajanweb.id"},
+			{Key: FieldPaket, Label: "Paket", Aliases: []string{"durasi", "package", "plan"}, Required: true, Help: "Paket langganan (wajib)", Example: "30 Hari"},
+			formNominal.withExample("", "75000"),
+			formKanal,
+			formVoucher,
+		},
+		SlotField:    FieldWorkspace,
+		SlotLabel:    "Workspace",
+		SlotCapacity: 4,
+		AccountSheet: "Akun ChatGPT",
+		OwnerColumn:  3, // D
+		MemberColumn: 2, // C
+		HeaderRows:   2,
+		DurationDays: 30,
+	})
+	RegisterProduct(ProductInfo{
 		Key:       ProductYouTube,
 		FullName:  "YouTube Premium",
 		SheetName: "YouTube",
-	},
-	ProductPerplexity: {
+		Param:     "youtube",
+		Aliases:   []string{"yt"},
+		Fields: []FormField{
+			formNama,
+			formEmail.withExample("Alamat Gmail yang diundang (wajib)", "john@gmail.com"),
+			{Key: FieldHead, Label: "Head", Aliases: []string{"email head", "head family", "akun head"}, Required: true, Help: "Email head YouTube Family (wajib)", Example: "ythead01@jajanweb.id"},
+			formNominal.withExample("", "15000"),
+			formKanal,
+			formAkun,
+			formVoucher,
+		},
+		SlotField:    FieldHead,
+		SlotLabel:    "Email Head",
+		SlotCapacity: 5,
+		AccountSheet: "Akun YouTube",
+		OwnerColumn:  3, // D
+		MemberColumn: 2, // C
+		HeaderRows:   2,
+		DurationDays: 30,
+	})
+	RegisterProduct(ProductInfo{
 		Key:       ProductPerplexity,
 		FullName:  "Perplexity Pro",
 		SheetName: "Perplexity",
-	},
+		Param:     "perplexity",
+		Fields: []FormField{
+			formNama,
+			formEmail.withExample("Alamat email customer (wajib)", "john@example.com"),
+			formNominal.withExample("", "25000"),
+			formKanal,
+			formAkun.withExample("Nomor WA customer (opsional)", "081234567890"),
+			formVoucher,
+		},
+		HelpNote:   "Kode redeem otomatis diambil dari stok dan dikirim via chat pribadi setelah pembayaran dikonfirmasi. Jika *Akun* berisi nomor WA, kode dikirim ke nomor tersebut; jika tidak, ke pembuat QRIS.",
+		RedeemCode: true,
+	})
+}
+
+// RegisterProduct adds a product to the registry.
+// Must be called during initialization (init or bootstrap), not concurrently with lookups.
+func RegisterProduct(info ProductInfo) {
+	if info.Key == "" || info.Param == "" {
+		panic("entity: product registration requires Key and Param")
+	}
+	if _, exists := Products[info.Key]; exists {
+		panic(fmt.Sprintf("entity: product %s already registered", info.Key))
+	}
+	Products[info.Key] = info
+	productOrder = append(productOrder, info.Key)
 }
 
-// AllProducts returns all valid product keys in order.
+// SetAccountSheet points a registered slot product at its account sheet
+// (SHEET_AKUN_* settings). Empty names keep the registered default.
+// Like RegisterProduct, must be called during initialization.
+func SetAccountSheet(product Product, sheet string) {
+	info, ok := Products[product]
+	if !ok {
+		panic(fmt.Sprintf("entity: product %s is not registered", product))
+	}
+	if sheet == "" {
+		return
+	}
+	info.AccountSheet = sheet
+	Products[product] = info
+}
+
+// AllProducts returns all valid product keys in registration order.
 func AllProducts() []Product {
-	return []Product{
-		ProductChatGPT,
-		ProductGemini,
-		ProductYouTube,
-		ProductPerplexity,
+	return append([]Product(nil), productOrder...)
+}
+
+// ProductByParam finds a product by its #qris parameter or alias (case-insensitive).
+func ProductByParam(param string) (ProductInfo, bool) {
+	param = strings.ToLower(strings.TrimSpace(param))
+	if param == "" {
+		return ProductInfo{}, false
+	}
+	for _, p := range productOrder {
+		info := Products[p]
+		if info.Param == param {
+			return info, true
+		}
+		for _, alias := range info.Aliases {
+			if alias == param {
+				return info, true
+			}
+		}
 	}
+	return ProductInfo{}, false
+}
+
+// LookupFormField maps a form label (case-insensitive) to its canonical field key.
+// Searches all registered products so forms without a product parameter still parse.
+func LookupFormField(label string) (string, bool) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	for _, p := range productOrder {
+		for _, f := range Products[p].Fields {
+			if f.Key == label || strings.ToLower(f.Label) == label {
+				return f.Key, true
+			}
+			for _, alias := range f.Aliases {
+				if alias == label {
+					return f.Key, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// FormFieldNames returns every accepted form label (keys, labels and aliases,
+// lowercase) mapped to its canonical field key.
+func FormFieldNames() map[string]string {
+	names := make(map[string]string)
+	for _, p := range productOrder {
+		for _, f := range Products[p].Fields {
+			names[f.Key] = f.Key
+			names[strings.ToLower(f.Label)] = f.Key
+			for _, alias := range f.Aliases {
+				names[strings.ToLower(alias)] = f.Key
+			}
+		}
+	}
+	return names
+}
+
+// productKeys returns product keys joined for error messages.
+func productKeys() string {
+	keys := make([]string, 0, len(productOrder))
+	for _, p := range productOrder {
+		keys = append(keys, string(p))
+	}
+	return strings.Join(keys, ", ")
 }
 
 // ParseProduct parses a string to a valid Product.
@@ -65,7 +324,7 @@ func AllProducts() []Product {
 func ParseProduct(s string) (Product, error) {
 	s = strings.TrimSpace(s)
 	if s == "" {
-		return "", fmt.Errorf("produk tidak boleh kosong. Pilihan: ChatGPT, Gemini, YouTube, Perplexity")
+		return "", fmt.Errorf("produk tidak boleh kosong. Pilihan: %s", productKeys())
 	}
 
 	lower := strings.ToLower(s)
@@ -89,13 +348,7 @@ func ParseProduct(s string) (Product, error) {
 		}
 	}
 
-	// Build error message with valid options
-	validOptions := make([]string, 0, len(Products))
-	for _, p := range AllProducts() {
-		validOptions = append(validOptions, string(p))
-	}
-
-	return "", fmt.Errorf("produk '%s' tidak valid. Pilihan: %s", s, strings.Join(validOptions, ", "))
+	return "", fmt.Errorf("produk '%s' tidak valid. Pilihan: %s", s, productKeys())
 }
 
 // Info returns the ProductInfo for this product.
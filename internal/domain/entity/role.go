@@ -0,0 +1,76 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Role is a bot user's permission level.
+type Role string
+
+// Roles from most to least privileged.
+const (
+	RoleOwner    Role = "owner"    // Everything, including #role
+	RoleAdmin    Role = "admin"    // Account, redeem code and member management
+	RoleReseller Role = "reseller" // Create orders (#qris) and check prices/slots
+	RoleViewer   Role = "viewer"   // Read-only: prices and slots
+)
+
+// Roles lists all roles from most to least privileged.
+var Roles = []Role{RoleOwner, RoleAdmin, RoleReseller, RoleViewer}
+
+// ParseRole parses a role name (case-insensitive).
+func ParseRole(name string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(name)))
+	if role.level() == 0 {
+		return "", fmt.Errorf("role tidak dikenal: '%s' (pilih: owner, admin, reseller, viewer)", name)
+	}
+	return role, nil
+}
+
+// level returns the rank of the role (0 = unknown).
+func (r Role) level() int {
+	switch r {
+	case RoleOwner:
+		return 4
+	case RoleAdmin:
+		return 3
+	case RoleReseller:
+		return 2
+	case RoleViewer:
+		return 1
+	}
+	return 0
+}
+
+// AtLeast reports whether r is min or a more privileged role.
+func (r Role) AtLeast(min Role) bool {
+	return r.level() >= min.level()
+}
+
+// RequiredRole returns the least privileged role allowed to use cmd
+// (from the command registry; unknown commands are owner-only).
+func RequiredRole(cmd string) Role {
+	if spec, ok := FindCommand(cmd); ok {
+		return spec.Role
+	}
+	return RoleOwner
+}
+
+// Can reports whether the role may use cmd (e.g. "#qris").
+func (r Role) Can(cmd string) bool {
+	return r.AtLeast(RequiredRole(cmd))
+}
+
+// RoleGrantedByConfig is the GrantedBy of owners from OWNER_PHONES.
+const RoleGrantedByConfig = "config"
+
+// RoleAssignment maps a phone number to a role.
+type RoleAssignment struct {
+	Phone     string    // Normalized phone (e.g. "6281234567890")
+	Role      Role      // Granted role
+	GrantedBy string    // Phone of the owner who granted it (RoleGrantedByConfig for OWNER_PHONES)
+	GrantedAt time.Time // When the role was granted
+}
@@ -0,0 +1,116 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var paketDurationRegex = regexp.MustCompile(`(?i)(\d+)\s*(hari|minggu|bulan|tahun)`)
+
+// Subscription represents one member row in a product sheet with an end date.
+type Subscription struct {
+	Produk          Product   // Product (determines sheet)
+	Row             int       // Sheet row number (1-indexed)
+	Nama            string    // Customer name
+	Email           string    // Customer email
+	Owner           string    // Family / Workspace / Email Head
+	Paket           string    // Package duration (ChatGPT only)
+	Akun            string    // Akun/Nomor column (customer WA number if filled)
+	TanggalBerakhir time.Time // End date (WIB midnight)
+}
+
+// Renewal reminder statuses (shown in the group digest).
+const (
+	RenewalSent      = "QRIS terkirim"
+	RenewalNoPrice   = "pengingat terkirim, harga belum ada di katalog"
+	RenewalNoContact = "nomor WA tidak ada"
+	RenewalFailed    = "gagal kirim"
+	RenewalEarlier   = "sudah dikirim sebelumnya"
+)
+
+// RenewalNotice is one member in the renewal digest.
+type RenewalNotice struct {
+	Subscription Subscription
+	DaysLeft     int    // Days until Tanggal Berakhir (0 = today)
+	Status       string // Reminder status (empty = not reminded today)
+}
+
+// DaysLeft returns the number of calendar days (WIB) from now until the end date.
+// 0 = ends today, negative = already ended.
+func (s Subscription) DaysLeft(now time.Time) int {
+	wib := time.FixedZone("WIB", 7*60*60)
+	n := now.In(wib)
+	today := time.Date(n.Year(), n.Month(), n.Day(), 0, 0, 0, 0, wib)
+	e := s.TanggalBerakhir.In(wib)
+	end := time.Date(e.Year(), e.Month(), e.Day(), 0, 0, 0, 0, wib)
+	return int(end.Sub(today).Hours() / 24)
+}
+
+// ReminderKey identifies the reminder for this end date. A renewal moves the
+// end date, so the next period gets its own reminder.
+func (s Subscription) ReminderKey() string {
+	member := strings.ToLower(strings.TrimSpace(s.Email))
+	if member == "" {
+		member = fmt.Sprintf("row %d", s.Row)
+	}
+	return fmt.Sprintf("%s/%s/%s", s.Produk, member, s.TanggalBerakhir.Format("2006-01-02"))
+}
+
+// ParsePaketDays extracts a duration in days from a paket text
+// ("30 Hari", "2 Minggu", "1 Bulan", "1 Tahun"). Months count as 30 days.
+func ParsePaketDays(paket string) (int, bool) {
+	m := paketDurationRegex.FindStringSubmatch(paket)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	switch strings.ToLower(m[2]) {
+	case "minggu":
+		return n * 7, true
+	case "bulan":
+		return n * 30, true
+	case "tahun":
+		return n * 365, true
+	}
+	return n, true
+}
+
+// SubscriptionDays returns the subscription length for a paket, falling back to
+// the product default. 0 = product has no tracked end date.
+func (i ProductInfo) SubscriptionDays(paket string) int {
+	if i.DurationDays <= 0 {
+		return 0
+	}
+	if days, ok := ParsePaketDays(paket); ok {
+		return days
+	}
+	return i.DurationDays
+}
+
+// SubscriptionEnd returns the end date (WIB midnight) of a subscription of the
+// given length starting at start. Returns zero time if days <= 0.
+func SubscriptionEnd(start time.Time, days int) time.Time {
+	if days <= 0 {
+		return time.Time{}
+	}
+	wib := time.FixedZone("WIB", 7*60*60)
+	s := start.In(wib)
+	return time.Date(s.Year(), s.Month(), s.Day()+days, 0, 0, 0, 0, wib)
+}
+
+// RenewalEnd returns the new end date when a subscription ending at current is
+// renewed at now: the new period starts at the later of the two.
+func RenewalEnd(current, now time.Time, days int) time.Time {
+	start := now
+	if current.After(now) {
+		start = current
+	}
+	return SubscriptionEnd(start, days)
+}
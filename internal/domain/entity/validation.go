@@ -0,0 +1,66 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is one failed check of an input field.
+type FieldError struct {
+	Field   string // Field label as shown to the user ("Email", "Nominal", ...)
+	Message string // What is wrong with it
+}
+
+// ValidationResult collects every field error of one input (order form,
+// #addakun form, #inputkode) so they are reported together instead of one
+// at a time. An empty result is valid. It implements error so parsers can
+// return it; use Err to avoid returning a non-nil error for a valid result.
+type ValidationResult struct {
+	Errors []FieldError
+}
+
+// Add records an error for field.
+func (v *ValidationResult) Add(field, message string) {
+	v.Errors = append(v.Errors, FieldError{Field: field, Message: message})
+}
+
+// Addf records a formatted error for field.
+func (v *ValidationResult) Addf(field, format string, args ...any) {
+	v.Add(field, fmt.Sprintf(format, args...))
+}
+
+// Valid reports whether no error was recorded.
+func (v *ValidationResult) Valid() bool {
+	return v == nil || len(v.Errors) == 0
+}
+
+// Has reports whether field already has an error.
+func (v *ValidationResult) Has(field string) bool {
+	if v == nil {
+		return false
+	}
+	for _, e := range v.Errors {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns v as an error, or nil if it is valid.
+func (v *ValidationResult) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return v
+}
+
+// Error implements error: "Field: message; Field: message".
+func (v *ValidationResult) Error() string {
+	parts := make([]string, 0, len(v.Errors))
+	for _, e := range v.Errors {
+		parts = append(parts, e.Field+": "+e.Message)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,104 @@
+// Package entity defines core business entities used across all layers.
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// VoucherType represents how a voucher discount is calculated.
+type VoucherType string
+
+// Voucher types.
+const (
+	VoucherPercent VoucherType = "persen"   // Nilai is a percentage of the amount
+	VoucherFixed   VoucherType = "potongan" // Nilai is a fixed Rupiah discount
+)
+
+// Voucher represents a discount code from the Voucher sheet.
+type Voucher struct {
+	Kode           string      // Voucher code (case-insensitive)
+	Tipe           VoucherType // persen or potongan
+	Nilai          int         // Percentage (1-100) or Rupiah amount
+	Produk         []Product   // Products the voucher applies to (empty = all products)
+	Mulai          time.Time   // Valid from (zero = no start limit)
+	Sampai         time.Time   // Valid until, inclusive (zero = no end limit)
+	Kuota          int         // Max confirmed uses in total (0 = unlimited)
+	Terpakai       int         // Confirmed uses so far
+	MaxPerCustomer int         // Max confirmed uses per customer email (0 = unlimited)
+	Row            int         // Sheet row number (for usage updates)
+}
+
+// Matches reports whether code refers to this voucher (case-insensitive).
+func (v Voucher) Matches(code string) bool {
+	return strings.EqualFold(strings.TrimSpace(code), v.Kode)
+}
+
+// AppliesTo reports whether the voucher can be used for the product.
+func (v Voucher) AppliesTo(p Product) bool {
+	if len(v.Produk) == 0 {
+		return true
+	}
+	for _, scope := range v.Produk {
+		if scope == p {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActiveAt reports whether t falls inside the validity window.
+// Sampai is inclusive for the whole day.
+func (v Voucher) IsActiveAt(t time.Time) bool {
+	if !v.Mulai.IsZero() && t.Before(v.Mulai) {
+		return false
+	}
+	if !v.Sampai.IsZero() && !t.Before(v.Sampai.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// QuotaLeft reports whether the voucher still has uses left.
+func (v Voucher) QuotaLeft() bool {
+	return v.Kuota <= 0 || v.Terpakai < v.Kuota
+}
+
+// Discount returns the discount for the given amount, never more than the amount itself.
+func (v Voucher) Discount(amount int) int {
+	var discount int
+	switch v.Tipe {
+	case VoucherPercent:
+		discount = amount * v.Nilai / 100
+	case VoucherFixed:
+		discount = v.Nilai
+	}
+	if discount > amount {
+		discount = amount
+	}
+	if discount < 0 {
+		discount = 0
+	}
+	return discount
+}
+
+// VoucherCheck hasil validasi voucher pada form #qris.
+type VoucherCheck struct {
+	IsValid      bool     // Apakah voucher bisa dipakai
+	Voucher      *Voucher // Voucher yang cocok (nil jika tidak ditemukan)
+	Original     int      // Nominal sebelum diskon
+	Discount     int      // Potongan harga
+	Final        int      // Nominal setelah diskon (dipakai untuk QRIS)
+	ErrorMessage string   // Error message if validation fails
+}
+
+// VoucherUsage represents one confirmed voucher use (Pemakaian Voucher sheet).
+type VoucherUsage struct {
+	Kode     string    // Voucher code
+	Email    string    // Customer email
+	Produk   string    // Ordered product
+	Nama     string    // Customer name
+	Amount   int       // Paid amount (after discount)
+	Discount int       // Discount given
+	Tanggal  time.Time // Confirmation time
+}
@@ -9,19 +9,70 @@ var (
 	ErrInvalidNotification = errors.New("invalid notification format")
 )
 
-// Family validation errors (Gemini).
+// Slot validation errors (family/workspace/head, see ProductInfo.SlotLabel).
 var (
-	ErrFamilyNotFound = errors.New("family not found in Google Accounts")
-	ErrFamilyFull     = errors.New("family is full (5/5 slots used)")
+	ErrSlotOwnerNotFound = errors.New("slot owner not found in account sheet")
+	ErrSlotFull          = errors.New("slot owner has no slots left")
 )
 
-// Workspace validation errors (ChatGPT).
+// Messaging errors.
 var (
-	ErrWorkspaceNotFound = errors.New("workspace not found in ChatGPT Accounts")
-	ErrWorkspaceFull     = errors.New("workspace is full (4/4 slots used)")
+	// ErrMessageQueued is returned with the message ID when a message is still in
+	// the outbound queue: it is sent later, or dropped if it waits too long.
+	ErrMessageQueued = errors.New("message queued, not sent yet")
+)
+
+// Catalog/price errors.
+var (
+	ErrPriceNotListed   = errors.New("price not listed in catalog")
+	ErrAmountOutOfRange = errors.New("amount outside catalog price range")
+	ErrCatalogNotLoaded = errors.New("catalog not loaded")
+	ErrAmountInUse      = errors.New("no unique amount left, all codes wait for payment")
+)
+
+// Voucher errors.
+var (
+	ErrVoucherNotFound       = errors.New("voucher not found")
+	ErrVoucherInactive       = errors.New("voucher outside validity window")
+	ErrVoucherNotApplicable  = errors.New("voucher not valid for product")
+	ErrVoucherQuotaExhausted = errors.New("voucher usage quota exhausted")
+	ErrVoucherCustomerLimit  = errors.New("voucher per-customer limit reached")
 )
 
 // Account errors.
 var (
-	ErrInvalidAccType = errors.New("invalid account type (choose: Google or ChatGPT)")
+	ErrInvalidAccType  = errors.New("invalid account type (choose: Google or ChatGPT)")
+	ErrAccountNotFound = errors.New("account not found in Akun Google or Akun ChatGPT sheet")
+)
+
+// Member migration errors.
+var (
+	ErrWorkspaceNotFound  = errors.New("workspace not found in Akun ChatGPT sheet")
+	ErrWorkspaceNotBanned = errors.New("workspace is not banned")
+	ErrNoMigrationPlan    = errors.New("no pending migration plan")
+)
+
+// Bulk import errors.
+var (
+	ErrImportNoRows      = errors.New("import file has no data rows")
+	ErrImportTooManyRows = errors.New("import file has too many rows")
+	ErrNoImportPreview   = errors.New("no pending import preview")
+)
+
+// Redeem code errors.
+var (
+	ErrRedeemCodeNotFound = errors.New("redeem code not found in Kode Perplexity sheet")
+	ErrRedeemCodeUsed     = errors.New("redeem code already used")
+	ErrRedeemCodeInvalid  = errors.New("redeem code flagged invalid")
+)
+
+// Role errors.
+var (
+	ErrRoleNotAssigned = errors.New("phone has no assigned role")
+	ErrRoleConfigOwner = errors.New("owner from OWNER_PHONES cannot be changed with #role")
+)
+
+// Order wizard errors.
+var (
+	ErrOrderSessionExpired = errors.New("order wizard session expired")
 )
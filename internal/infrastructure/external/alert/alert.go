@@ -0,0 +1,57 @@
+// Package alert delivers operator alerts over channels that do not depend on
+// WhatsApp (outbound webhook, email), used when the WhatsApp session is down.
+package alert
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/exernia/botjanweb/pkg/logger"
+)
+
+// Channel delivers a single alert.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, subject, body string) error
+}
+
+// Multi sends an alert to every configured channel.
+type Multi struct {
+	channels []Channel
+	logger   *log.Logger
+}
+
+// NewMulti creates an alerter for the given channels (nil channels are skipped).
+func NewMulti(channels ...Channel) *Multi {
+	m := &Multi{logger: logger.Alert}
+	for _, ch := range channels {
+		if ch != nil {
+			m.channels = append(m.channels, ch)
+		}
+	}
+	return m
+}
+
+// Enabled reports whether at least one channel is configured.
+func (m *Multi) Enabled() bool {
+	return len(m.channels) > 0
+}
+
+// SendAlert sends the alert to all channels. It fails only if every channel failed,
+// so one broken channel does not hide a delivered alert.
+func (m *Multi) SendAlert(ctx context.Context, subject, body string) error {
+	var errs []error
+	for _, ch := range m.channels {
+		if err := ch.Send(ctx, subject, body); err != nil {
+			m.logger.Printf("⚠️ Failed to send alert via %s: %v", ch.Name(), err)
+			errs = append(errs, err)
+			continue
+		}
+		m.logger.Printf("📣 Alert sent via %s: %s", ch.Name(), subject)
+	}
+	if len(errs) == len(m.channels) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
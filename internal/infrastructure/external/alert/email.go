@@ -0,0 +1,103 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/exernia/botjanweb/pkg/constants"
+)
+
+// Email sends alerts through an SMTP relay (e.g. a local Postfix/MailHog stand-in).
+// The relay is expected to accept mail without authentication.
+type Email struct {
+	addr string // host:port
+	from string
+	to   []string
+}
+
+// NewEmail creates an email channel, or nil if addr is empty.
+// to is a comma-separated list of recipients.
+func NewEmail(addr, from, to string) *Email {
+	if addr == "" {
+		return nil
+	}
+	var recipients []string
+	for _, r := range strings.Split(to, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			recipients = append(recipients, r)
+		}
+	}
+	return &Email{addr: addr, from: from, to: recipients}
+}
+
+// Name returns the channel name for logs.
+func (e *Email) Name() string { return "email" }
+
+// Send sends a plain-text email. net/smtp has no context support, so the
+// call runs in the background and is abandoned when ctx is done.
+func (e *Email) Send(ctx context.Context, subject, body string) error {
+	msg := strings.Join([]string{
+		"From: " + e.from,
+		"To: " + strings.Join(e.to, ", "),
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	ctx, cancel := context.WithTimeout(ctx, constants.AlertTimeoutSeconds*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- e.send([]byte(msg))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("smtp %s: %w", e.addr, ctx.Err())
+	}
+}
+
+// send delivers the message with a dial timeout.
+func (e *Email) send(msg []byte) error {
+	conn, err := net.DialTimeout("tcp", e.addr, constants.AlertTimeoutSeconds*time.Second)
+	if err != nil {
+		return err
+	}
+	host, _, _ := net.SplitHostPort(e.addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if err := c.Mail(e.from); err != nil {
+		return err
+	}
+	for _, rcpt := range e.to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,62 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/exernia/botjanweb/pkg/constants"
+)
+
+// Webhook posts alerts as JSON to an HTTP endpoint (e.g. Slack/Discord-compatible relay).
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook creates a webhook channel, or nil if url is empty.
+func NewWebhook(url string) *Webhook {
+	if url == "" {
+		return nil
+	}
+	return &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: constants.AlertTimeoutSeconds * time.Second},
+	}
+}
+
+// Name returns the channel name for logs.
+func (w *Webhook) Name() string { return "webhook" }
+
+// Send posts {"subject", "text", "timestamp"}. "text" carries subject and body
+// so chat relays that only read one field still show the full alert.
+func (w *Webhook) Send(ctx context.Context, subject, body string) error {
+	payload, err := json.Marshal(map[string]string{
+		"subject":   subject,
+		"text":      subject + "\n\n" + body,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
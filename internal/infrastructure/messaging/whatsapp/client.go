@@ -3,9 +3,13 @@ package whatsapp
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/exernia/botjanweb/pkg/logger"
 
@@ -29,6 +33,17 @@ type Client struct {
 	groupJID types.JID
 	handler  MessageHandler
 	logger   *log.Logger
+
+	// Connection supervisor (see supervisor.go)
+	stateMu      sync.Mutex
+	status       entity.ConnectionStatus
+	onState      StateListener
+	reconnecting atomic.Bool // A reconnect loop is running
+	stopping     atomic.Bool // Disconnect was requested, never reconnect
+	handlerOnce  sync.Once   // Event handler is registered by pairing or Connect, never both
+
+	queue *outboundQueue // All outgoing messages (see queue.go)
+	dedup *messageDedup  // Handled incoming messages (see dedup.go)
 }
 
 // NewClient creates and initializes a new WhatsMeow client.
@@ -44,21 +59,24 @@ func NewClient(ctx context.Context, dbPath string, groupJIDStr string) (*Client,
 	dbLog := waLog.Stdout("Database", "ERROR", true)
 
 	// Determine database type and connection string
-	var container *sqlstore.Container
+	dialect, address := "sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=10000", dbPath)
 	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
 		// Use PostgreSQL (production/Heroku)
 		log.Println("📊 Using PostgreSQL database")
-		container, err = sqlstore.New(ctx, "postgres", databaseURL, dbLog)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create PostgreSQL container: %w", err)
-		}
+		dialect, address = "postgres", databaseURL
 	} else {
 		// Use SQLite (local development)
 		log.Printf("📊 Using SQLite database: %s", dbPath)
-		container, err = sqlstore.New(ctx, "sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=10000", dbPath), dbLog)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create SQLite container: %w", err)
-		}
+	}
+
+	// Open the database ourselves so the outbound queue can share it
+	db, err := sql.Open(dialect, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", dialect, err)
+	}
+	container := sqlstore.NewWithDB(db, dialect, dbLog)
+	if err := container.Upgrade(ctx); err != nil {
+		return nil, fmt.Errorf("failed to upgrade %s database: %w", dialect, err)
 	}
 
 	deviceStore, err := container.GetFirstDevice(ctx)
@@ -70,12 +88,42 @@ func NewClient(ctx context.Context, dbPath string, groupJIDStr string) (*Client,
 	// These are expected when messages arrive out of order and are handled internally by WhatsMeow
 	clientLog := waLog.Stdout("Client", "WARN", true)
 	wmClient := whatsmeow.NewClient(deviceStore, clientLog)
+	// Reconnects are handled by the connection supervisor (exponential backoff + state)
+	wmClient.EnableAutoReconnect = false
+
+	state := entity.ConnNotPaired
+	if wmClient.Store.ID != nil {
+		state = entity.ConnConnecting
+	}
 
-	return &Client{
+	// Outbound queue survives restarts; falls back to memory if the table cannot be created
+	var store queueStore
+	if s, err := newSQLQueueStore(ctx, db, dialect); err != nil {
+		log.Printf("⚠️ Outbound queue not persisted: %v", err)
+	} else {
+		store = s
+	}
+	queue := newOutboundQueue(store, log)
+	queue.restore(ctx)
+
+	// Handled message IDs survive restarts too, so redeliveries are not handled twice
+	var processed processedStore
+	if s, err := newSQLProcessedStore(ctx, db); err != nil {
+		log.Printf("⚠️ Processed messages not persisted: %v", err)
+	} else {
+		processed = s
+	}
+
+	c := &Client{
 		wm:       wmClient,
 		groupJID: groupJID,
 		logger:   log,
-	}, nil
+		status:   entity.ConnectionStatus{State: state, Since: time.Now()},
+		queue:    queue,
+		dedup:    newMessageDedup(processed, log),
+	}
+	go queue.run(c.canSend, c.sendJob)
+	return c, nil
 }
 
 // SetMessageHandler sets the callback for incoming messages.
@@ -3,11 +3,14 @@ package whatsapp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+
+	"go.mau.fi/whatsmeow"
 )
 
 // Connect starts the WhatsApp connection.
@@ -15,13 +18,16 @@ import (
 // For new devices, use the web pairing flow instead of this method.
 func (c *Client) Connect(ctx context.Context) error {
 	// Add event handler
-	c.wm.AddEventHandler(c.eventHandler)
+	c.addEventHandler()
 
 	// Check if already logged in
 	if c.wm.Store.ID != nil {
 		c.logger.Printf("Logged in as: %s", c.wm.Store.ID.String())
-		if err := c.wm.Connect(); err != nil {
-			return fmt.Errorf("failed to connect: %w", err)
+		// Already connected right after web pairing
+		if err := c.wm.Connect(); err != nil && !errors.Is(err, whatsmeow.ErrAlreadyConnected) {
+			// Network errors at startup are retried by the supervisor instead of exiting
+			c.logger.Printf("⚠️ Failed to connect: %v", err)
+			c.startReconnect(fmt.Sprintf("failed to connect: %v", err))
 		}
 		return nil
 	}
@@ -31,8 +37,10 @@ func (c *Client) Connect(ctx context.Context) error {
 	return nil // Don't error out, let web pairing handle it
 }
 
-// Disconnect disconnects from WhatsApp.
+// Disconnect disconnects from WhatsApp. The supervisor does not reconnect afterwards.
 func (c *Client) Disconnect() {
+	c.stopping.Store(true)
+	c.queue.stop()
 	c.wm.Disconnect()
 	c.logger.Println("Disconnected from WhatsApp")
 }
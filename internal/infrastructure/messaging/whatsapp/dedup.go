@@ -0,0 +1,98 @@
+// Package whatsapp wraps WhatsMeow for WhatsApp connectivity.
+package whatsapp
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/pkg/constants"
+)
+
+// processedStore persists handled message keys so redeliveries after a
+// restart are skipped too.
+type processedStore interface {
+	// Claim records key; returns false if it was already recorded.
+	Claim(ctx context.Context, key string, at time.Time) (bool, error)
+	// Prune removes keys recorded before the given time.
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// messageDedup remembers handled messages for ProcessedMessageHours.
+// WhatsMeow can deliver a message again after a reconnect; without this each
+// delivery runs the handler (e.g. two QRIS for one form).
+type messageDedup struct {
+	mu        sync.Mutex
+	seen      map[string]time.Time // Keys claimed by this process
+	store     processedStore       // nil = in memory only
+	lastPrune time.Time
+	logger    *log.Logger
+}
+
+// newMessageDedup creates an empty cache (store may be nil).
+func newMessageDedup(store processedStore, logger *log.Logger) *messageDedup {
+	return &messageDedup{
+		seen:      make(map[string]time.Time),
+		store:     store,
+		lastPrune: time.Now(),
+		logger:    logger,
+	}
+}
+
+// claim marks a message as handled. Returns false if it was handled before.
+// If the store fails the message is handled (the memory cache still applies).
+func (d *messageDedup) claim(key string) bool {
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.prune(now)
+
+	if _, ok := d.seen[key]; ok {
+		return false
+	}
+	d.seen[key] = now
+	if d.store == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	claimed, err := d.store.Claim(ctx, key, now)
+	if err != nil {
+		d.logger.Printf("⚠️ Failed to record processed message %s: %v", key, err)
+		return true
+	}
+	return claimed
+}
+
+// prune forgets old keys, at most once an hour. Caller holds mu.
+func (d *messageDedup) prune(now time.Time) {
+	if now.Sub(d.lastPrune) < time.Hour {
+		return
+	}
+	d.lastPrune = now
+
+	cutoff := now.Add(-constants.ProcessedMessageHours * time.Hour)
+	for key, at := range d.seen {
+		if at.Before(cutoff) {
+			delete(d.seen, key)
+		}
+	}
+	if d.store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.store.Prune(ctx, cutoff); err != nil {
+		d.logger.Printf("⚠️ Failed to prune processed messages: %v", err)
+	}
+}
+
+// messageKey identifies a message: IDs are chosen by the sender's device, so
+// the chat and sender are part of the key.
+func messageKey(chat, sender, id string) string {
+	return chat + "/" + sender + "/" + id
+}
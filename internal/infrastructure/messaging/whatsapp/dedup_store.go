@@ -0,0 +1,49 @@
+// Package whatsapp wraps WhatsMeow for WhatsApp connectivity.
+package whatsapp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// processedSchema creates the processed message table next to the WhatsMeow tables.
+const processedSchema = `CREATE TABLE IF NOT EXISTS botjanweb_processed_messages (
+	msg_key      TEXT PRIMARY KEY,
+	processed_at BIGINT NOT NULL
+)`
+
+// sqlProcessedStore persists handled message keys in the WhatsMeow database (SQLite or PostgreSQL).
+type sqlProcessedStore struct {
+	db *sql.DB
+}
+
+// newSQLProcessedStore creates the processed message table if needed.
+func newSQLProcessedStore(ctx context.Context, db *sql.DB) (*sqlProcessedStore, error) {
+	if _, err := db.ExecContext(ctx, processedSchema); err != nil {
+		return nil, fmt.Errorf("failed to create processed message table: %w", err)
+	}
+	return &sqlProcessedStore{db: db}, nil
+}
+
+// Claim records key; returns false if it was already recorded.
+func (s *sqlProcessedStore) Claim(ctx context.Context, key string, at time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO botjanweb_processed_messages (msg_key, processed_at) VALUES ($1, $2) ON CONFLICT (msg_key) DO NOTHING`,
+		key, at.UnixMilli())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// Prune removes keys recorded before the given time.
+func (s *sqlProcessedStore) Prune(ctx context.Context, before time.Time) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM botjanweb_processed_messages WHERE processed_at < $1`, before.UnixMilli())
+	return err
+}
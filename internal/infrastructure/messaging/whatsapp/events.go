@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
 	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
@@ -20,15 +22,26 @@ func (c *Client) eventHandler(evt interface{}) {
 
 	case *events.Connected:
 		c.logger.Printf("✅ WebSocket connected to WhatsApp servers")
+		c.onConnected()
 
 	case *events.PairSuccess:
 		c.logger.Printf("🎉 Pairing successful! Device: %s", v.ID.String())
 
 	case *events.LoggedOut:
 		c.logger.Printf("🚪 Logged out from WhatsApp (reason: %s)", v.Reason)
+		c.onLoggedOut(v.Reason.String())
+
+	case *events.StreamReplaced:
+		// Another client took over this session; reconnecting would fight it
+		c.logger.Println("🚪 Session replaced by another WhatsApp Web client")
+		c.onLoggedOut("stream replaced by another client")
 
 	case *events.Disconnected:
 		c.logger.Println("⚠️ Disconnected from WhatsApp")
+		c.onDisconnected()
+
+	case *events.KeepAliveTimeout:
+		c.onKeepAliveTimeout(v.LastSuccess)
 	}
 }
 
@@ -60,20 +73,53 @@ func (c *Client) handleIncomingMessage(evt *events.Message) {
 		return
 	}
 
+	// Edits carry the corrected content and the key of the original message
+	messageID := evt.Info.ID
+	isEdit := false
+	if pm := msg.GetProtocolMessage(); pm.GetType() == waE2E.ProtocolMessage_MESSAGE_EDIT {
+		messageID = pm.GetKey().GetID()
+		msg = pm.GetEditedMessage()
+		isEdit = true
+		if msg == nil {
+			return
+		}
+	}
+
 	text := ""
+	var doc *waE2E.DocumentMessage
 	switch {
 	case msg.GetConversation() != "":
 		text = msg.GetConversation()
 	case msg.ExtendedTextMessage != nil && msg.ExtendedTextMessage.Text != nil:
 		text = msg.ExtendedTextMessage.GetText()
+	case msg.DocumentMessage != nil:
+		doc = msg.DocumentMessage
+	case msg.GetDocumentWithCaptionMessage().GetMessage().GetDocumentMessage() != nil:
+		// Documents sent with a caption are wrapped in DocumentWithCaptionMessage
+		doc = msg.GetDocumentWithCaptionMessage().GetMessage().GetDocumentMessage()
 	default:
 		// Only handle text messages
 		return
 	}
 
+	// Documents are only handled as a command caption (e.g. "#import akun google")
+	if doc != nil {
+		text = doc.GetCaption()
+		if isEdit || !strings.HasPrefix(strings.TrimSpace(text), "#") {
+			return
+		}
+	}
+
+	// Redelivered messages (e.g. after a reconnect) were already handled.
+	// An edit has its own ID, so each edit is handled once as well.
+	if !c.dedup.claim(messageKey(evt.Info.Chat.String(), evt.Info.Sender.String(), evt.Info.ID)) {
+		c.logger.Printf("🔁 Skipping already handled message %s from %s", evt.Info.ID, evt.Info.Sender.User)
+		return
+	}
+
 	// Log incoming message for debugging
-	c.logger.Printf("📨 [DEBUG] Received: from=%s server=%s chat=%s text=%q",
-		evt.Info.Sender.User, evt.Info.Sender.Server, evt.Info.Chat.String(), truncateText(text, 50))
+	c.logger.Printf("📨 [DEBUG] Received: from=%s server=%s chat=%s edit=%t text=%q",
+		evt.Info.Sender.User, evt.Info.Sender.Server, evt.Info.Chat.String(), isEdit, truncateText(text, 50))
 
 	info := evt.Info
 
@@ -92,7 +138,7 @@ func (c *Client) handleIncomingMessage(evt *events.Message) {
 	}
 
 	entityMsg := &entity.Message{
-		ID:            info.ID,
+		ID:            messageID,
 		ChatID:        info.Chat.String(),
 		SenderID:      info.Sender.String(),
 		SenderPhone:   senderPhone,
@@ -100,6 +146,7 @@ func (c *Client) handleIncomingMessage(evt *events.Message) {
 		Timestamp:     info.Timestamp,
 		IsSelfMessage: info.IsFromMe,
 		IsPrivateChat: info.Chat.Server != "g.us",
+		IsEdit:        isEdit,
 	}
 
 	// For private chat, extract recipient phone
@@ -134,10 +181,36 @@ func (c *Client) handleIncomingMessage(evt *events.Message) {
 	// Use background context - let operations complete naturally
 	go func() {
 		ctx := context.Background()
+		if doc != nil {
+			entityMsg.Document = c.downloadDocument(ctx, doc)
+		}
 		c.handler(ctx, entityMsg)
 	}()
 }
 
+// downloadDocument downloads an attached document.
+// Returns nil if the file is too large or the download fails (the handler
+// then answers as if no file was attached).
+func (c *Client) downloadDocument(ctx context.Context, doc *waE2E.DocumentMessage) *entity.Document {
+	if doc.GetFileLength() > constants.ImportMaxFileBytes {
+		c.logger.Printf("⚠️ Skipping document %q: %d bytes (max %d)",
+			doc.GetFileName(), doc.GetFileLength(), constants.ImportMaxFileBytes)
+		return nil
+	}
+
+	data, err := c.wm.Download(ctx, doc)
+	if err != nil {
+		c.logger.Printf("❌ Failed to download document %q: %v", doc.GetFileName(), err)
+		return nil
+	}
+
+	return &entity.Document{
+		FileName: doc.GetFileName(),
+		MimeType: doc.GetMimetype(),
+		Data:     data,
+	}
+}
+
 // tryResolveLIDPhone attempts to resolve a phone number from a LID JID.
 // It first checks the local cache/store, then tries GetUserInfo as a fallback.
 // Returns "Private User" if resolution fails.
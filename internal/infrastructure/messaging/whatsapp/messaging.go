@@ -3,12 +3,68 @@ package whatsapp
 
 import (
 	"context"
+	"time"
 
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"google.golang.org/protobuf/proto"
 )
 
+// send queues a message (see queue.go) and returns its message ID.
+// While connected it waits for the outcome, including retries of transient
+// failures, for up to OutboundSendTimeoutSeconds. A message that is still queued
+// after that, or was queued while reconnecting, returns its ID together with
+// domain.ErrMessageQueued, so callers can tell it from a delivered one.
+func (c *Client) send(ctx context.Context, to types.JID, msg *waE2E.Message) (string, error) {
+	return c.sendQueued(ctx, to, msg, false)
+}
+
+// sendQueued is send with the choice to keep the message out of the persisted
+// queue (volatile), for messages that must not be stored at rest.
+func (c *Client) sendQueued(ctx context.Context, to types.JID, msg *waE2E.Message, volatile bool) (string, error) {
+	status := c.ConnectionStatus()
+	if status.State == entity.ConnNotPaired || status.State == entity.ConnLoggedOut {
+		return "", ErrNotConnected
+	}
+
+	id := c.wm.GenerateMessageID()
+	result, err := c.queue.enqueue(to, id, msg, volatile)
+	if err != nil {
+		return "", err
+	}
+	if !status.IsReady() {
+		c.logger.Printf("📥 WhatsApp %s, message to %s queued (%d waiting)", status.State, to.String(), status.Queued+1)
+		return id, domain.ErrMessageQueued
+	}
+
+	timer := time.NewTimer(constants.OutboundSendTimeoutSeconds * time.Second)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		if err != nil {
+			return "", err
+		}
+		return id, nil
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+	c.logger.Printf("⏳ Message %s to %s still queued", id, to.String())
+	return id, domain.ErrMessageQueued
+}
+
+// sendJob performs one send attempt for the queue worker. The pre-generated
+// ID lets WhatsApp deduplicate a retry of a message that did arrive.
+func (c *Client) sendJob(job *outboundJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.OutboundSendTimeoutSeconds*time.Second)
+	defer cancel()
+	_, err := c.wm.SendMessage(ctx, job.to, job.msg, whatsmeow.SendRequestExtra{ID: job.id})
+	return err
+}
+
 // SendText sends a text message to the default group.
 func (c *Client) SendText(ctx context.Context, text string) error {
 	return c.SendTextTo(ctx, c.groupJID.String(), text)
@@ -21,12 +77,27 @@ func (c *Client) SendTextTo(ctx context.Context, chatID string, text string) err
 		return err
 	}
 
-	_, err = c.wm.SendMessage(ctx, jid, &waE2E.Message{
+	_, err = c.send(ctx, jid, &waE2E.Message{
 		Conversation: proto.String(text),
 	})
 	return err
 }
 
+// SendSensitiveTextTo sends a text message that is never written to the persisted
+// outbound queue (e.g. a revealed password). If the bot restarts before it is
+// sent, the message is lost.
+func (c *Client) SendSensitiveTextTo(ctx context.Context, chatID string, text string) error {
+	jid, err := types.ParseJID(chatID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.sendQueued(ctx, jid, &waE2E.Message{
+		Conversation: proto.String(text),
+	}, true)
+	return err
+}
+
 // SendTextReply sends a reply to a specific message.
 func (c *Client) SendTextReply(ctx context.Context, chatID, text, quotedMsgID, quotedSenderID string) error {
 	jid, err := types.ParseJID(chatID)
@@ -34,7 +105,7 @@ func (c *Client) SendTextReply(ctx context.Context, chatID, text, quotedMsgID, q
 		return err
 	}
 
-	_, err = c.wm.SendMessage(ctx, jid, &waE2E.Message{
+	_, err = c.send(ctx, jid, &waE2E.Message{
 		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
 			Text: proto.String(text),
 			ContextInfo: &waE2E.ContextInfo{
@@ -74,30 +145,31 @@ func (c *Client) SendImageTo(ctx context.Context, chatID string, imageData []byt
 		FileLength:    proto.Uint64(uint64(len(imageData))),
 	}
 
-	resp, err := c.wm.SendMessage(ctx, jid, &waE2E.Message{
+	return c.send(ctx, jid, &waE2E.Message{
 		ImageMessage: imgMsg,
 	})
-	if err != nil {
-		return "", err
-	}
-
-	return resp.ID, nil
 }
 
 // SendTextToGroup sends a text message to the configured group, returns message ID.
 func (c *Client) SendTextToGroup(ctx context.Context, text string) (string, error) {
-	resp, err := c.wm.SendMessage(ctx, c.groupJID, &waE2E.Message{
-		Conversation: proto.String(text),
-	})
+	return c.SendTextToGroupJID(ctx, c.groupJID.String(), text)
+}
+
+// SendTextToGroupJID sends a text message to a specific group, returns message ID.
+func (c *Client) SendTextToGroupJID(ctx context.Context, groupJID, text string) (string, error) {
+	jid, err := types.ParseJID(groupJID)
 	if err != nil {
 		return "", err
 	}
-	return resp.ID, nil
+
+	return c.send(ctx, jid, &waE2E.Message{
+		Conversation: proto.String(text),
+	})
 }
 
 // SendTextReplyToGroup sends a reply to a specific message in the configured group.
 func (c *Client) SendTextReplyToGroup(ctx context.Context, text, quotedMsgID string) error {
-	_, err := c.wm.SendMessage(ctx, c.groupJID, &waE2E.Message{
+	_, err := c.send(ctx, c.groupJID, &waE2E.Message{
 		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
 			Text: proto.String(text),
 			ContextInfo: &waE2E.ContextInfo{
@@ -114,15 +186,54 @@ func (c *Client) SendImageToGroup(ctx context.Context, imageData []byte, caption
 	return c.SendImageTo(ctx, c.groupJID.String(), imageData, caption)
 }
 
-// RevokeMessage revokes (deletes for everyone) a message sent by the bot.
+// SendDocument sends a file as a document message to a specific chat.
+func (c *Client) SendDocument(ctx context.Context, chatID string, data []byte, fileName, mimeType, caption string) (string, error) {
+	jid, err := types.ParseJID(chatID)
+	if err != nil {
+		return "", err
+	}
+
+	uploaded, err := c.wm.Upload(ctx, data, whatsmeow.MediaDocument)
+	if err != nil {
+		return "", err
+	}
+
+	docMsg := &waE2E.DocumentMessage{
+		Caption:       proto.String(caption),
+		FileName:      proto.String(fileName),
+		Title:         proto.String(fileName),
+		Mimetype:      proto.String(mimeType),
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		MediaKey:      uploaded.MediaKey,
+		FileEncSHA256: uploaded.FileEncSHA256,
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uint64(len(data))),
+	}
+
+	return c.send(ctx, jid, &waE2E.Message{
+		DocumentMessage: docMsg,
+	})
+}
+
+// RevokeMessage revokes (deletes for everyone) a message.
+// senderID is empty for messages sent by the bot; revoking another member's
+// message in a group requires the bot to be a group admin.
 // Uses WhatsMeow's BuildRevoke to create a revoke protocol message.
-func (c *Client) RevokeMessage(ctx context.Context, chatID, messageID string) error {
+func (c *Client) RevokeMessage(ctx context.Context, chatID, senderID, messageID string) error {
 	jid, err := types.ParseJID(chatID)
 	if err != nil {
 		return err
 	}
 
-	revokeMsg := c.wm.BuildRevoke(jid, types.EmptyJID, messageID)
-	_, err = c.wm.SendMessage(ctx, jid, revokeMsg)
+	sender := types.EmptyJID
+	if senderID != "" {
+		if sender, err = types.ParseJID(senderID); err != nil {
+			return err
+		}
+	}
+
+	revokeMsg := c.wm.BuildRevoke(jid, sender, messageID)
+	_, err = c.send(ctx, jid, revokeMsg)
 	return err
 }
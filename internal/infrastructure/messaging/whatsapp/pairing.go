@@ -31,6 +31,18 @@ func (c *Client) GetQRChannelForPairing(ctx context.Context) (<-chan whatsmeow.Q
 	return qrChan, nil
 }
 
+// PairPhone requests a phone-number pairing code (formatted "ABCD-EFGH") for
+// phone in international format. The pairing websocket must already be
+// connected and have received its first QR code (see GetQRChannelForPairing).
+// The code is entered on the phone under Linked Devices → Link with phone number.
+func (c *Client) PairPhone(ctx context.Context, phone string) (string, error) {
+	if c.IsLoggedIn() {
+		return "", fmt.Errorf("device is already paired")
+	}
+	// The display name must be a common "Browser (OS)" pair or WhatsApp rejects it
+	return c.wm.PairPhone(ctx, phone, true, whatsmeow.PairClientChrome, "Chrome (Linux)")
+}
+
 // ConnectWithEventHandler connects the websocket with event handler already set.
 // This is used by the web pairing flow.
 func (c *Client) ConnectWithEventHandler() error {
@@ -39,7 +51,7 @@ func (c *Client) ConnectWithEventHandler() error {
 	}
 
 	// Add event handler before connecting
-	c.wm.AddEventHandler(c.eventHandler)
+	c.addEventHandler()
 
 	if err := c.wm.Connect(); err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
@@ -0,0 +1,396 @@
+// Package whatsapp wraps WhatsMeow for WhatsApp connectivity.
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/exernia/botjanweb/pkg/constants"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Outbound queue errors.
+var (
+	// ErrNotConnected is returned when a message cannot be queued (not paired or logged out).
+	ErrNotConnected = errors.New("whatsapp is not connected")
+	// ErrQueueFull is returned when the outbound queue has no room left.
+	ErrQueueFull = errors.New("outbound message queue is full")
+	// errExpired is reported for messages dropped after OutboundMaxAgeMinutes.
+	errExpired = errors.New("message expired in outbound queue")
+)
+
+// outboundJob is a message waiting in the outbound queue.
+type outboundJob struct {
+	seq      int64           // Queue order (persisted)
+	id       types.MessageID // Pre-generated so callers get the final message ID and retries are deduplicated
+	to       types.JID
+	msg      *waE2E.Message
+	queuedAt time.Time
+	attempts int        // Failed send attempts so far
+	volatile bool       // Not persisted (sensitive content such as passwords); lost on restart
+	next     time.Time  // Not sent before this time (retry backoff)
+	result   chan error // Final outcome (sent, given up, expired) for the caller (nil once reported / after restart)
+}
+
+// queueStore persists queued messages so they survive restarts.
+type queueStore interface {
+	Save(ctx context.Context, job *outboundJob) error
+	UpdateAttempts(ctx context.Context, id types.MessageID, attempts int) error
+	Delete(ctx context.Context, id types.MessageID) error
+	Load(ctx context.Context) ([]*outboundJob, error)
+	Clear(ctx context.Context) error
+}
+
+// outboundQueue sends messages one at a time: in order per chat, round-robin
+// across chats, within a global rate limit. Transient send errors are retried
+// with exponential backoff; the chat's later messages wait behind the retry.
+type outboundQueue struct {
+	mu    sync.Mutex
+	chats map[string][]*outboundJob // Per-chat FIFO, only the head is sent
+	order []string                  // Chats with queued messages, in round-robin order
+	rr    int                       // Next chat to look at in order
+	count int
+	seq   int64
+
+	store   queueStore // nil = in memory only
+	limiter *rateLimiter
+	logger  *log.Logger
+
+	wakeCh   chan struct{}
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// newOutboundQueue creates an empty queue (store may be nil).
+func newOutboundQueue(store queueStore, logger *log.Logger) *outboundQueue {
+	return &outboundQueue{
+		chats:   make(map[string][]*outboundJob),
+		store:   store,
+		limiter: newRateLimiter(constants.OutboundBurst, constants.OutboundIntervalMillis*time.Millisecond),
+		logger:  logger,
+		wakeCh:  make(chan struct{}, 1),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// restore loads persisted messages from a previous run.
+func (q *outboundQueue) restore(ctx context.Context) {
+	if q.store == nil {
+		return
+	}
+	jobs, err := q.store.Load(ctx)
+	if err != nil {
+		q.logger.Printf("⚠️ Failed to restore outbound queue: %v", err)
+		return
+	}
+
+	q.mu.Lock()
+	for _, job := range jobs {
+		q.push(job)
+		q.seq = max(q.seq, job.seq)
+	}
+	q.mu.Unlock()
+
+	if len(jobs) > 0 {
+		q.logger.Printf("📤 Restored %d queued message(s) from the previous run", len(jobs))
+	}
+}
+
+// enqueue adds a message to the end of its chat's queue. Volatile messages are
+// kept in memory only. The returned channel reports the final outcome, after any retries.
+func (q *outboundQueue) enqueue(to types.JID, id types.MessageID, msg *waE2E.Message, volatile bool) (<-chan error, error) {
+	q.mu.Lock()
+	if q.count >= constants.OutboundQueueMax {
+		q.mu.Unlock()
+		return nil, ErrQueueFull
+	}
+	q.seq++
+	result := make(chan error, 1)
+	job := &outboundJob{
+		seq:      q.seq,
+		id:       id,
+		to:       to,
+		msg:      msg,
+		queuedAt: time.Now(),
+		volatile: volatile,
+		result:   result,
+	}
+	// Saved before the worker can see it, so a fast send never leaves a stale row
+	if q.store != nil && !volatile {
+		if err := q.store.Save(context.Background(), job); err != nil {
+			q.logger.Printf("⚠️ Failed to persist queued message %s: %v", id, err)
+		}
+	}
+	q.push(job)
+	q.mu.Unlock()
+	q.wake()
+	return result, nil
+}
+
+// push appends a job (caller holds mu).
+func (q *outboundQueue) push(job *outboundJob) {
+	chat := job.to.String()
+	if _, ok := q.chats[chat]; !ok {
+		q.order = append(q.order, chat)
+	}
+	q.chats[chat] = append(q.chats[chat], job)
+	q.count++
+}
+
+// pop removes the head of a chat's queue (caller holds mu).
+func (q *outboundQueue) pop(job *outboundJob) {
+	chat := job.to.String()
+	jobs := q.chats[chat]
+	if len(jobs) == 0 || jobs[0] != job {
+		return
+	}
+	q.count--
+	if len(jobs) > 1 {
+		q.chats[chat] = jobs[1:]
+		return
+	}
+	delete(q.chats, chat)
+	for i, c := range q.order {
+		if c == chat {
+			q.order = append(q.order[:i], q.order[i+1:]...)
+			if q.rr > i {
+				q.rr--
+			}
+			break
+		}
+	}
+}
+
+// next returns the next message that may be sent now, dropping expired ones.
+// If none is due, it returns how long to wait (0 = until woken).
+func (q *outboundQueue) next(now time.Time) (*outboundJob, time.Duration) {
+	maxAge := constants.OutboundMaxAgeMinutes * time.Minute
+
+	q.mu.Lock()
+	var expired []*outboundJob
+	var job *outboundJob
+	var wait time.Duration
+	for i := 0; i < len(q.order) && job == nil; {
+		idx := (q.rr + i) % len(q.order)
+		head := q.chats[q.order[idx]][0]
+		if now.Sub(head.queuedAt) > maxAge {
+			q.pop(head)
+			expired = append(expired, head)
+			continue // Same position now holds the next chat (or the chat's next message)
+		}
+		if head.next.After(now) {
+			if d := head.next.Sub(now); wait == 0 || d < wait {
+				wait = d
+			}
+			i++
+			continue
+		}
+		job = head
+		q.rr = idx + 1
+	}
+	q.mu.Unlock()
+
+	for _, e := range expired {
+		q.logger.Printf("🗑️ Dropped message %s to %s: queued longer than %s", e.id, e.to.String(), maxAge)
+		q.report(e, errExpired)
+		q.forget(e.id)
+	}
+	return job, wait
+}
+
+// finish records the outcome of a send attempt.
+func (q *outboundQueue) finish(job *outboundJob, err error) {
+	if err == nil {
+		q.mu.Lock()
+		q.pop(job)
+		q.mu.Unlock()
+		q.report(job, nil)
+		q.forget(job.id)
+		return
+	}
+
+	job.attempts++
+	if isTransientSendError(err) && job.attempts < constants.OutboundMaxAttempts {
+		delay := min(constants.OutboundRetryBaseSeconds*time.Second<<(job.attempts-1), constants.OutboundRetryMaxSeconds*time.Second)
+		job.next = time.Now().Add(delay)
+		q.logger.Printf("⚠️ Send to %s failed (attempt %d/%d), retrying in %s: %v",
+			job.to.String(), job.attempts, constants.OutboundMaxAttempts, delay, err)
+
+		// The outcome is reported once the retry succeeds or attempts run out
+		if q.store != nil && !job.volatile {
+			if serr := q.store.UpdateAttempts(context.Background(), job.id, job.attempts); serr != nil {
+				q.logger.Printf("⚠️ Failed to persist retry of message %s: %v", job.id, serr)
+			}
+		}
+		return
+	}
+
+	q.logger.Printf("❌ Giving up on message %s to %s after %d attempt(s): %v", job.id, job.to.String(), job.attempts, err)
+	q.mu.Lock()
+	q.pop(job)
+	q.mu.Unlock()
+	q.report(job, err)
+	q.forget(job.id)
+}
+
+// report sends the final outcome to the caller (buffered, so a caller that
+// stopped waiting doesn't block the worker).
+func (q *outboundQueue) report(job *outboundJob, err error) {
+	q.mu.Lock()
+	ch := job.result
+	job.result = nil
+	q.mu.Unlock()
+	if ch != nil {
+		ch <- err
+	}
+}
+
+// forget removes a finished message from the store.
+func (q *outboundQueue) forget(id types.MessageID) {
+	if q.store == nil {
+		return
+	}
+	if err := q.store.Delete(context.Background(), id); err != nil {
+		q.logger.Printf("⚠️ Failed to remove message %s from the persisted queue: %v", id, err)
+	}
+}
+
+// clear drops all queued messages and returns how many were dropped.
+func (q *outboundQueue) clear() int {
+	q.mu.Lock()
+	var jobs []*outboundJob
+	for _, chatJobs := range q.chats {
+		jobs = append(jobs, chatJobs...)
+	}
+	q.chats = make(map[string][]*outboundJob)
+	q.order, q.rr, q.count = nil, 0, 0
+	q.mu.Unlock()
+
+	for _, job := range jobs {
+		q.report(job, ErrNotConnected)
+	}
+	if q.store != nil {
+		if err := q.store.Clear(context.Background()); err != nil {
+			q.logger.Printf("⚠️ Failed to clear the persisted queue: %v", err)
+		}
+	}
+	return len(jobs)
+}
+
+// len returns the number of queued messages.
+func (q *outboundQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count
+}
+
+// wake makes the worker look at the queue again (new message or reconnected).
+func (q *outboundQueue) wake() {
+	select {
+	case q.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// stop ends the worker. Queued messages stay persisted for the next run.
+func (q *outboundQueue) stop() {
+	q.stopOnce.Do(func() { close(q.stopCh) })
+}
+
+// run is the worker loop. ready reports whether WhatsApp can send right now;
+// while it cannot, messages stay queued.
+func (q *outboundQueue) run(ready func() bool, send func(job *outboundJob) error) {
+	for {
+		var job *outboundJob
+		wait := time.Second
+		if ready() {
+			job, wait = q.next(time.Now())
+		}
+
+		if job == nil {
+			if !q.sleep(wait) {
+				return
+			}
+			continue
+		}
+
+		if !q.limiter.wait(q.stopCh) {
+			return
+		}
+		q.finish(job, send(job))
+	}
+}
+
+// sleep waits for d (0 = until woken). Returns false when stopped.
+func (q *outboundQueue) sleep(d time.Duration) bool {
+	var timer <-chan time.Time
+	if d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		timer = t.C
+	}
+	select {
+	case <-q.stopCh:
+		return false
+	case <-q.wakeCh:
+	case <-timer:
+	}
+	return true
+}
+
+// isTransientSendError reports whether a failed send is worth retrying
+// (connection or timeout problems rather than an invalid message/recipient).
+func isTransientSendError(err error) bool {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, whatsmeow.ErrNotConnected),
+		errors.Is(err, whatsmeow.ErrIQTimedOut),
+		errors.Is(err, whatsmeow.ErrMessageTimedOut),
+		errors.Is(err, whatsmeow.ErrIQDisconnected),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr):
+		return true
+	}
+	return false
+}
+
+// rateLimiter is a token bucket: burst messages at once, then one per interval.
+// Only the queue worker uses it, so it needs no locking.
+type rateLimiter struct {
+	tokens   float64
+	burst    float64
+	interval time.Duration
+	last     time.Time
+}
+
+// newRateLimiter creates a full bucket.
+func newRateLimiter(burst int, interval time.Duration) *rateLimiter {
+	return &rateLimiter{tokens: float64(burst), burst: float64(burst), interval: interval, last: time.Now()}
+}
+
+// wait blocks until a token is available. Returns false when stopped.
+func (l *rateLimiter) wait(stop <-chan struct{}) bool {
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	l.last = now
+
+	if l.tokens < 1 {
+		t := time.NewTimer(time.Duration((1 - l.tokens) * float64(l.interval)))
+		defer t.Stop()
+		select {
+		case <-stop:
+			return false
+		case <-t.C:
+		}
+		l.tokens = 1
+		l.last = time.Now()
+	}
+	l.tokens--
+	return true
+}
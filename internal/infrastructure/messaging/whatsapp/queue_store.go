@@ -0,0 +1,116 @@
+// Package whatsapp wraps WhatsMeow for WhatsApp connectivity.
+package whatsapp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// queueSchema creates the outbound queue table next to the WhatsMeow tables.
+// %s is the binary column type of the dialect.
+const queueSchema = `CREATE TABLE IF NOT EXISTS botjanweb_outbound_queue (
+	id        TEXT PRIMARY KEY,
+	seq       BIGINT NOT NULL,
+	chat_jid  TEXT NOT NULL,
+	message   %s NOT NULL,
+	queued_at BIGINT NOT NULL,
+	attempts  INTEGER NOT NULL DEFAULT 0
+)`
+
+// sqlQueueStore persists the outbound queue in the WhatsMeow database (SQLite or PostgreSQL).
+type sqlQueueStore struct {
+	db *sql.DB
+}
+
+// newSQLQueueStore creates the queue table if needed.
+func newSQLQueueStore(ctx context.Context, db *sql.DB, dialect string) (*sqlQueueStore, error) {
+	blobType := "BLOB"
+	if dialect == "postgres" {
+		blobType = "BYTEA"
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(queueSchema, blobType)); err != nil {
+		return nil, fmt.Errorf("failed to create outbound queue table: %w", err)
+	}
+	return &sqlQueueStore{db: db}, nil
+}
+
+// Save stores a newly queued message.
+func (s *sqlQueueStore) Save(ctx context.Context, job *outboundJob) error {
+	data, err := proto.Marshal(job.msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO botjanweb_outbound_queue (id, seq, chat_jid, message, queued_at, attempts) VALUES ($1, $2, $3, $4, $5, $6)`,
+		job.id, job.seq, job.to.String(), data, job.queuedAt.UnixMilli(), job.attempts)
+	return err
+}
+
+// UpdateAttempts records a failed attempt.
+func (s *sqlQueueStore) UpdateAttempts(ctx context.Context, id types.MessageID, attempts int) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE botjanweb_outbound_queue SET attempts = $1 WHERE id = $2`, attempts, id)
+	return err
+}
+
+// Delete removes a sent or dropped message.
+func (s *sqlQueueStore) Delete(ctx context.Context, id types.MessageID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM botjanweb_outbound_queue WHERE id = $1`, id)
+	return err
+}
+
+// Clear removes all queued messages.
+func (s *sqlQueueStore) Clear(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM botjanweb_outbound_queue`)
+	return err
+}
+
+// Load returns all queued messages in queue order. Rows that cannot be
+// decoded are skipped and deleted.
+func (s *sqlQueueStore) Load(ctx context.Context) ([]*outboundJob, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, seq, chat_jid, message, queued_at, attempts FROM botjanweb_outbound_queue ORDER BY seq`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		jobs []*outboundJob
+		bad  []types.MessageID
+	)
+	for rows.Next() {
+		var (
+			job      outboundJob
+			chat     string
+			data     []byte
+			queuedAt int64
+		)
+		if err := rows.Scan(&job.id, &job.seq, &chat, &data, &queuedAt, &job.attempts); err != nil {
+			return nil, err
+		}
+
+		job.msg = &waE2E.Message{}
+		to, err := types.ParseJID(chat)
+		if err != nil || proto.Unmarshal(data, job.msg) != nil {
+			bad = append(bad, job.id)
+			continue
+		}
+		job.to = to
+		job.queuedAt = time.UnixMilli(queuedAt)
+		jobs = append(jobs, &job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range bad {
+		_ = s.Delete(ctx, id)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,145 @@
+// Package whatsapp wraps WhatsMeow for WhatsApp connectivity.
+package whatsapp
+
+import (
+	"errors"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"go.mau.fi/whatsmeow"
+)
+
+// StateListener is called after every connection state change.
+type StateListener func(status entity.ConnectionStatus)
+
+// SetStateListener sets the callback for connection state changes (e.g. fallback alerts).
+func (c *Client) SetStateListener(listener StateListener) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.onState = listener
+}
+
+// ConnectionStatus returns the current connection state (used by /ready).
+func (c *Client) ConnectionStatus() entity.ConnectionStatus {
+	c.stateMu.Lock()
+	status := c.status
+	c.stateMu.Unlock()
+
+	status.Queued = c.queue.len()
+	return status
+}
+
+// setState records a state change and notifies the listener outside the lock.
+// attempts and lastErr describe the current outage (zero/empty when connected).
+func (c *Client) setState(state entity.ConnectionState, attempts int, lastErr string) {
+	c.stateMu.Lock()
+	changed := c.status.State != state || c.status.Attempts != attempts
+	if c.status.State != state {
+		c.status.Since = time.Now()
+	}
+	c.status.State = state
+	c.status.Attempts = attempts
+	c.status.LastError = lastErr
+	listener := c.onState
+	c.stateMu.Unlock()
+
+	if changed && listener != nil {
+		listener(c.ConnectionStatus())
+	}
+}
+
+// addEventHandler registers the WhatsMeow event handler once.
+func (c *Client) addEventHandler() {
+	c.handlerOnce.Do(func() {
+		c.wm.AddEventHandler(c.eventHandler)
+	})
+}
+
+// onConnected marks the connection as usable and resumes the outbound queue.
+func (c *Client) onConnected() {
+	c.setState(entity.ConnConnected, 0, "")
+	c.queue.wake()
+}
+
+// canSend reports whether the outbound queue may send right now.
+func (c *Client) canSend() bool {
+	c.stateMu.Lock()
+	ready := c.status.IsReady()
+	c.stateMu.Unlock()
+	return ready && c.wm.IsConnected()
+}
+
+// onDisconnected starts the reconnect loop unless the disconnect was requested.
+func (c *Client) onDisconnected() {
+	if c.stopping.Load() || !c.IsLoggedIn() {
+		return
+	}
+	c.startReconnect("connection lost")
+}
+
+// onKeepAliveTimeout forces a reconnect when the socket looks dead for too long.
+// WhatsMeow only does this itself when its own auto-reconnect is enabled.
+func (c *Client) onKeepAliveTimeout(lastSuccess time.Time) {
+	if time.Since(lastSuccess) <= whatsmeow.KeepAliveMaxFailTime || c.stopping.Load() || !c.IsLoggedIn() {
+		return
+	}
+	c.logger.Printf("⚠️ No keepalive response since %s, forcing reconnect", lastSuccess.Format(time.RFC3339))
+	c.wm.Disconnect()
+	c.startReconnect("keepalive timeout")
+}
+
+// onLoggedOut marks the session as revoked. Reconnecting is pointless until the
+// device is paired again, so queued messages are dropped.
+func (c *Client) onLoggedOut(reason string) {
+	if dropped := c.queue.clear(); dropped > 0 {
+		c.logger.Printf("🗑️ Dropped %d queued message(s) after logout", dropped)
+	}
+	c.setState(entity.ConnLoggedOut, 0, reason)
+}
+
+// startReconnect runs the reconnect loop in the background (at most one at a time).
+func (c *Client) startReconnect(reason string) {
+	if !c.reconnecting.CompareAndSwap(false, true) {
+		return
+	}
+	c.setState(entity.ConnReconnecting, 0, reason)
+
+	go func() {
+		defer c.reconnecting.Store(false)
+		c.reconnectLoop()
+	}()
+}
+
+// reconnectLoop retries Connect with exponential backoff until connected,
+// logged out or shutting down. The Connected event finishes the outage.
+func (c *Client) reconnectLoop() {
+	delay := constants.ReconnectBaseSeconds * time.Second
+	maxDelay := constants.ReconnectMaxSeconds * time.Second
+
+	for attempt := 1; ; attempt++ {
+		c.logger.Printf("🔄 Reconnecting to WhatsApp in %s (attempt %d)", delay, attempt)
+		time.Sleep(delay)
+
+		if c.stopping.Load() || !c.IsLoggedIn() {
+			return
+		}
+
+		err := c.wm.Connect()
+		if err == nil {
+			c.logger.Printf("✅ Reconnected to WhatsApp after %d attempt(s)", attempt)
+			return
+		}
+		if errors.Is(err, whatsmeow.ErrAlreadyConnected) {
+			// Connected by someone else (e.g. web pairing); no Connected event will follow
+			if c.wm.IsLoggedIn() {
+				c.onConnected()
+			}
+			return
+		}
+
+		c.logger.Printf("⚠️ Reconnect attempt %d failed: %v", attempt, err)
+		c.setState(entity.ConnReconnecting, attempt, err.Error())
+		delay = min(delay*2, maxDelay)
+	}
+}
@@ -0,0 +1,67 @@
+// Package file implements file-based configuration stores.
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// catalogEntry is the JSON shape of one price list entry.
+type catalogEntry struct {
+	Produk string `json:"produk"`
+	Paket  string `json:"paket"`
+	Harga  int    `json:"harga"`
+	Min    int    `json:"min"`
+	Max    int    `json:"max"`
+	Slot   int    `json:"slot"`
+}
+
+// CatalogStore loads the product price list from a JSON file.
+// The file is re-read on every call so edits apply without restart
+// (the catalog use case caches the result).
+type CatalogStore struct {
+	path string
+}
+
+// NewCatalogStore creates a new file-based catalog store.
+func NewCatalogStore(path string) *CatalogStore {
+	return &CatalogStore{path: path}
+}
+
+// GetCatalog reads and validates all entries from the catalog file.
+func (s *CatalogStore) GetCatalog(ctx context.Context) ([]entity.CatalogItem, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog file: %w", err)
+	}
+
+	var entries []catalogEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog file %s: %w", s.path, err)
+	}
+
+	items := make([]entity.CatalogItem, 0, len(entries))
+	for i, e := range entries {
+		product, err := entity.ParseProduct(e.Produk)
+		if err != nil {
+			return nil, fmt.Errorf("catalog entry %d: %w", i+1, err)
+		}
+		if e.Harga <= 0 {
+			return nil, fmt.Errorf("catalog entry %d (%s): harga harus lebih dari 0", i+1, e.Produk)
+		}
+		items = append(items, entity.CatalogItem{
+			Produk:   product,
+			Paket:    e.Paket,
+			Harga:    e.Harga,
+			MinHarga: e.Min,
+			MaxHarga: e.Max,
+			MaxSlots: e.Slot,
+		})
+	}
+
+	return items, nil
+}
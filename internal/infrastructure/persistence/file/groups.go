@@ -0,0 +1,112 @@
+// Package file implements file-based configuration stores.
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+)
+
+// groupEntry is the JSON shape of one group in the groups file.
+type groupEntry struct {
+	Name           string   `json:"name"`
+	JID            string   `json:"jid"`
+	AllowedSenders []string `json:"allowed_senders"`
+	Kanal          string   `json:"kanal"`
+	Commands       []string `json:"commands"`
+	Notify         []string `json:"notify"`
+}
+
+// LoadGroups reads and validates the groups file. Empty allowed_senders and
+// kanal fall back to the global settings in defaults.
+func LoadGroups(path string, defaults entity.GroupConfig) ([]entity.GroupConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read groups file: %w", err)
+	}
+
+	var entries []groupEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse groups file %s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("groups file %s has no groups", path)
+	}
+
+	groups := make([]entity.GroupConfig, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for i, e := range entries {
+		g, err := e.toGroup(defaults)
+		if err != nil {
+			return nil, fmt.Errorf("group %d (%s): %w", i+1, g.Name, err)
+		}
+		if seen[g.JID] {
+			return nil, fmt.Errorf("group %d (%s): duplicate jid %s", i+1, g.Name, g.JID)
+		}
+		seen[g.JID] = true
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// toGroup validates and normalizes one entry.
+func (e groupEntry) toGroup(defaults entity.GroupConfig) (entity.GroupConfig, error) {
+	g := entity.GroupConfig{
+		Name:           strings.TrimSpace(e.Name),
+		JID:            strings.TrimSpace(e.JID),
+		AllowedSenders: defaults.AllowedSenders,
+		Kanal:          strings.TrimSpace(e.Kanal),
+	}
+	if g.Name == "" {
+		g.Name = g.JID
+	}
+	if !strings.HasSuffix(g.JID, "@g.us") {
+		return g, fmt.Errorf("jid must be a group JID (must end with @g.us), got: %s", g.JID)
+	}
+	if g.Kanal == "" {
+		g.Kanal = defaults.Kanal
+	}
+
+	if len(e.AllowedSenders) > 0 {
+		g.AllowedSenders = nil
+		for _, phone := range e.AllowedSenders {
+			phone = strings.TrimSpace(phone)
+			if phone == "*" {
+				g.AllowedSenders = []string{"*"}
+				break
+			}
+			normalized := formatter.NormalizePhone(phone)
+			if len(normalized) < 10 || len(normalized) > 15 {
+				return g, fmt.Errorf("invalid allowed_senders phone number: %s (must be 10-15 digits)", phone)
+			}
+			g.AllowedSenders = append(g.AllowedSenders, normalized)
+		}
+	}
+
+	// Empty or "*" enables every command; aliases are stored as the command name
+	for _, cmd := range e.Commands {
+		if strings.TrimSpace(cmd) == "*" {
+			g.Commands = nil
+			break
+		}
+		spec, ok := entity.FindCommand(cmd)
+		if !ok {
+			return g, fmt.Errorf("unknown command %q (available: %s)", cmd, strings.Join(entity.CommandNames(), ", "))
+		}
+		g.Commands = append(g.Commands, spec.Name)
+	}
+
+	for _, kind := range e.Notify {
+		k := entity.NotifyKind(strings.ToLower(strings.TrimSpace(kind)))
+		if !slices.Contains(entity.NotifyKinds, k) {
+			return g, fmt.Errorf("unknown notify kind %q (available: payment, renewal, monitor, stock)", kind)
+		}
+		g.Notify = append(g.Notify, k)
+	}
+	return g, nil
+}
@@ -0,0 +1,48 @@
+// Package memory implements in-memory pending payment store.
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// NoticeStore keeps sent reminders and alerts in memory (development mode, lost on restart).
+type NoticeStore struct {
+	mu      sync.RWMutex
+	notices map[string]map[string]string // kind -> key -> value
+}
+
+// NewNoticeStore creates a new in-memory notice store.
+func NewNoticeStore() *NoticeStore {
+	return &NoticeStore{notices: make(map[string]map[string]string)}
+}
+
+// ListNotices returns the recorded keys of a kind with their values.
+func (s *NoticeStore) ListNotices(_ context.Context, kind string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	notices := make(map[string]string, len(s.notices[kind]))
+	for key, value := range s.notices[kind] {
+		notices[key] = value
+	}
+	return notices, nil
+}
+
+// SetNotice records or replaces a key.
+func (s *NoticeStore) SetNotice(_ context.Context, kind, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.notices[kind] == nil {
+		s.notices[kind] = make(map[string]string)
+	}
+	s.notices[kind][key] = value
+	return nil
+}
+
+// DeleteNotice forgets a key.
+func (s *NoticeStore) DeleteNotice(_ context.Context, kind, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.notices[kind], key)
+	return nil
+}
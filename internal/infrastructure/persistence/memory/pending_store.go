@@ -3,6 +3,8 @@ package memory
 
 import (
 	"log"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -57,6 +59,69 @@ func (s *PendingStore) Match(amount int) *entity.PendingPayment {
 	return matched
 }
 
+// HasAmount reports whether an unpaid QRIS already waits for this amount.
+func (s *PendingStore) HasAmount(amount int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.pending[amount]) > 0
+}
+
+// FindByEmail returns pending payments for a customer email (case-insensitive), oldest first.
+func (s *PendingStore) FindByEmail(email string) []*entity.PendingPayment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var found []*entity.PendingPayment
+	for _, payments := range s.pending {
+		for _, p := range payments {
+			if p.Email != "" && strings.EqualFold(strings.TrimSpace(p.Email), strings.TrimSpace(email)) {
+				found = append(found, p)
+			}
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].CreatedAt.Before(found[j].CreatedAt)
+	})
+	return found
+}
+
+// FindByOriginal returns the pending payment created by a #qris message (not removed).
+func (s *PendingStore) FindByOriginal(chatID, originalMessageID string) *entity.PendingPayment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, payments := range s.pending {
+		for _, p := range payments {
+			if p.ChatID == chatID && p.OriginalMessageID == originalMessageID {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// Remove removes a pending payment by its QRIS message ID (nil if already matched).
+func (s *PendingStore) Remove(messageID string) *entity.PendingPayment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for amount, payments := range s.pending {
+		for i, p := range payments {
+			if p.MessageID != messageID {
+				continue
+			}
+			if len(payments) == 1 {
+				delete(s.pending, amount)
+			} else {
+				s.pending[amount] = append(payments[:i:i], payments[i+1:]...)
+			}
+			s.logger.Printf("Pending dihapus: Rp%d | MsgID: %s", p.Amount, p.MessageID)
+			return p
+		}
+	}
+	return nil
+}
+
 // Count returns total pending payments.
 func (s *PendingStore) Count() int {
 	s.mu.RLock()
@@ -0,0 +1,58 @@
+// Package memory implements in-memory pending payment store.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// RoleStore keeps role assignments in memory (development mode, lost on restart).
+type RoleStore struct {
+	mu    sync.RWMutex
+	roles map[string]entity.RoleAssignment
+}
+
+// NewRoleStore creates a new in-memory role store.
+func NewRoleStore() *RoleStore {
+	return &RoleStore{roles: make(map[string]entity.RoleAssignment)}
+}
+
+// GetRole returns the assignment of a phone, or nil if none.
+func (s *RoleStore) GetRole(_ context.Context, phone string) (*entity.RoleAssignment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	a, ok := s.roles[phone]
+	if !ok {
+		return nil, nil
+	}
+	return &a, nil
+}
+
+// SetRole creates or replaces an assignment.
+func (s *RoleStore) SetRole(_ context.Context, a entity.RoleAssignment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles[a.Phone] = a
+	return nil
+}
+
+// DeleteRole removes an assignment.
+func (s *RoleStore) DeleteRole(_ context.Context, phone string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.roles, phone)
+	return nil
+}
+
+// ListRoles returns all assignments.
+func (s *RoleStore) ListRoles(_ context.Context) ([]entity.RoleAssignment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]entity.RoleAssignment, 0, len(s.roles))
+	for _, a := range s.roles {
+		list = append(list, a)
+	}
+	return list, nil
+}
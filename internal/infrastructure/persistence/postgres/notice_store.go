@@ -0,0 +1,76 @@
+// Package postgres implements PostgreSQL pending payment store.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// NoticeStore persists sent reminders and alerts in PostgreSQL.
+type NoticeStore struct {
+	db *sql.DB
+}
+
+// NewNoticeStore creates a new PostgreSQL notice store.
+// Automatically creates the table if it doesn't exist.
+func NewNoticeStore(ctx context.Context, databaseURL string) (*NoticeStore, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	query := `
+		CREATE TABLE IF NOT EXISTS bot_notices (
+			kind TEXT NOT NULL,
+			key TEXT NOT NULL,
+			value TEXT NOT NULL DEFAULT '',
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (kind, key)
+		);
+	`
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return &NoticeStore{db: db}, nil
+}
+
+// ListNotices returns the recorded keys of a kind with their values.
+func (s *NoticeStore) ListNotices(ctx context.Context, kind string) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM bot_notices WHERE kind = $1`, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notices := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		notices[key] = value
+	}
+	return notices, rows.Err()
+}
+
+// SetNotice records or replaces a key.
+func (s *NoticeStore) SetNotice(ctx context.Context, kind, key, value string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO bot_notices (kind, key, value, updated_at) VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (kind, key) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	`, kind, key, value)
+	return err
+}
+
+// DeleteNotice forgets a key.
+func (s *NoticeStore) DeleteNotice(ctx context.Context, kind, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM bot_notices WHERE kind = $1 AND key = $2`, kind, key)
+	return err
+}
@@ -78,6 +78,18 @@ func (s *PendingStore) createTable(ctx context.Context) error {
 			created_at TIMESTAMP NOT NULL DEFAULT NOW()
 		);
 
+		-- Voucher columns (added after initial release)
+		ALTER TABLE pending_payments ADD COLUMN IF NOT EXISTS voucher TEXT NOT NULL DEFAULT '';
+		ALTER TABLE pending_payments ADD COLUMN IF NOT EXISTS discount INTEGER NOT NULL DEFAULT 0;
+
+		-- Renewal columns (renewal reminder QRIS)
+		ALTER TABLE pending_payments ADD COLUMN IF NOT EXISTS paket TEXT NOT NULL DEFAULT '';
+		ALTER TABLE pending_payments ADD COLUMN IF NOT EXISTS renewal_row INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE pending_payments ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+		-- Group that receives the payment notices (multi-group)
+		ALTER TABLE pending_payments ADD COLUMN IF NOT EXISTS group_jid TEXT NOT NULL DEFAULT '';
+
 		-- Index for faster matching by amount (FIFO order)
 		CREATE INDEX IF NOT EXISTS idx_pending_amount_created 
 		ON pending_payments(amount, created_at);
@@ -96,14 +108,16 @@ func (s *PendingStore) Add(p *entity.PendingPayment) {
 		INSERT INTO pending_payments (
 			amount, message_id, chat_id, sender_jid, sender_phone,
 			original_message_id, is_self_qris, group_notif_msg_id,
-			produk, nama, email, family, deskripsi, kanal, akun, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			produk, nama, email, family, deskripsi, kanal, akun, created_at,
+			voucher, discount, paket, renewal_row, expires_at, group_jid
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 	`
 
 	_, err := s.db.ExecContext(ctx, query,
 		p.Amount, p.MessageID, p.ChatID, p.SenderJID, p.SenderPhone,
 		p.OriginalMessageID, p.IsSelfQris, p.GroupNotifMsgID,
 		p.Produk, p.Nama, p.Email, p.Family, p.Deskripsi, p.Kanal, p.Akun, p.CreatedAt,
+		p.Voucher, p.Discount, p.Paket, p.RenewalRow, sql.NullTime{Time: p.ExpiresAt, Valid: !p.ExpiresAt.IsZero()}, p.GroupJID,
 	)
 
 	if err != nil {
@@ -131,7 +145,8 @@ func (s *PendingStore) Match(amount int) *entity.PendingPayment {
 	query := `
 		SELECT id, amount, message_id, chat_id, sender_jid, sender_phone,
 		       original_message_id, is_self_qris, group_notif_msg_id,
-		       produk, nama, email, family, deskripsi, kanal, akun, created_at
+		       produk, nama, email, family, deskripsi, kanal, akun, created_at,
+		       voucher, discount, paket, renewal_row, expires_at, group_jid
 		FROM pending_payments
 		WHERE amount = $1
 		ORDER BY created_at ASC
@@ -141,11 +156,13 @@ func (s *PendingStore) Match(amount int) *entity.PendingPayment {
 
 	var p entity.PendingPayment
 	var id int64
+	var expiresAt sql.NullTime
 
 	err = tx.QueryRowContext(ctx, query, amount).Scan(
 		&id, &p.Amount, &p.MessageID, &p.ChatID, &p.SenderJID, &p.SenderPhone,
 		&p.OriginalMessageID, &p.IsSelfQris, &p.GroupNotifMsgID,
 		&p.Produk, &p.Nama, &p.Email, &p.Family, &p.Deskripsi, &p.Kanal, &p.Akun, &p.CreatedAt,
+		&p.Voucher, &p.Discount, &p.Paket, &p.RenewalRow, &expiresAt, &p.GroupJID,
 	)
 
 	if err == sql.ErrNoRows {
@@ -156,6 +173,8 @@ func (s *PendingStore) Match(amount int) *entity.PendingPayment {
 		return nil
 	}
 
+	p.ExpiresAt = expiresAt.Time
+
 	// Delete the matched payment
 	_, err = tx.ExecContext(ctx, "DELETE FROM pending_payments WHERE id = $1", id)
 	if err != nil {
@@ -173,6 +192,140 @@ func (s *PendingStore) Match(amount int) *entity.PendingPayment {
 	return &p
 }
 
+// HasAmount reports whether an unpaid QRIS already waits for this amount.
+// Lookup failures report true so no colliding amount is issued.
+func (s *PendingStore) HasAmount(amount int) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM pending_payments WHERE amount = $1)",
+		amount,
+	).Scan(&exists)
+	if err != nil {
+		s.logger.Printf("❌ Failed to check pending amount: %v", err)
+		return true
+	}
+
+	return exists
+}
+
+// FindByEmail returns pending payments for a customer email (case-insensitive), oldest first.
+func (s *PendingStore) FindByEmail(email string) []*entity.PendingPayment {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT amount, message_id, chat_id, sender_jid, sender_phone,
+		       original_message_id, is_self_qris, group_notif_msg_id,
+		       produk, nama, email, family, deskripsi, kanal, akun, created_at,
+		       voucher, discount, paket, renewal_row, expires_at, group_jid
+		FROM pending_payments
+		WHERE LOWER(TRIM(email)) = LOWER(TRIM($1)) AND email <> ''
+		ORDER BY created_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, email)
+	if err != nil {
+		s.logger.Printf("❌ Failed to query pending payments by email: %v", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var found []*entity.PendingPayment
+	for rows.Next() {
+		var p entity.PendingPayment
+		var expiresAt sql.NullTime
+		if err := rows.Scan(
+			&p.Amount, &p.MessageID, &p.ChatID, &p.SenderJID, &p.SenderPhone,
+			&p.OriginalMessageID, &p.IsSelfQris, &p.GroupNotifMsgID,
+			&p.Produk, &p.Nama, &p.Email, &p.Family, &p.Deskripsi, &p.Kanal, &p.Akun, &p.CreatedAt,
+			&p.Voucher, &p.Discount, &p.Paket, &p.RenewalRow, &expiresAt, &p.GroupJID,
+		); err != nil {
+			s.logger.Printf("❌ Failed to scan pending payment: %v", err)
+			return found
+		}
+		p.ExpiresAt = expiresAt.Time
+		found = append(found, &p)
+	}
+
+	return found
+}
+
+// FindByOriginal returns the pending payment created by a #qris message (not removed).
+func (s *PendingStore) FindByOriginal(chatID, originalMessageID string) *entity.PendingPayment {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT amount, message_id, chat_id, sender_jid, sender_phone,
+		       original_message_id, is_self_qris, group_notif_msg_id,
+		       produk, nama, email, family, deskripsi, kanal, akun, created_at,
+		       voucher, discount, paket, renewal_row, expires_at, group_jid
+		FROM pending_payments
+		WHERE chat_id = $1 AND original_message_id = $2
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var p entity.PendingPayment
+	var expiresAt sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, query, chatID, originalMessageID).Scan(
+		&p.Amount, &p.MessageID, &p.ChatID, &p.SenderJID, &p.SenderPhone,
+		&p.OriginalMessageID, &p.IsSelfQris, &p.GroupNotifMsgID,
+		&p.Produk, &p.Nama, &p.Email, &p.Family, &p.Deskripsi, &p.Kanal, &p.Akun, &p.CreatedAt,
+		&p.Voucher, &p.Discount, &p.Paket, &p.RenewalRow, &expiresAt, &p.GroupJID,
+	)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		s.logger.Printf("❌ Failed to query pending payment by message: %v", err)
+		return nil
+	}
+
+	p.ExpiresAt = expiresAt.Time
+	return &p
+}
+
+// Remove removes a pending payment by its QRIS message ID (nil if already matched).
+func (s *PendingStore) Remove(messageID string) *entity.PendingPayment {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := `
+		DELETE FROM pending_payments
+		WHERE message_id = $1
+		RETURNING amount, message_id, chat_id, sender_jid, sender_phone,
+		          original_message_id, is_self_qris, group_notif_msg_id,
+		          produk, nama, email, family, deskripsi, kanal, akun, created_at,
+		          voucher, discount, paket, renewal_row, expires_at, group_jid
+	`
+
+	var p entity.PendingPayment
+	var expiresAt sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, query, messageID).Scan(
+		&p.Amount, &p.MessageID, &p.ChatID, &p.SenderJID, &p.SenderPhone,
+		&p.OriginalMessageID, &p.IsSelfQris, &p.GroupNotifMsgID,
+		&p.Produk, &p.Nama, &p.Email, &p.Family, &p.Deskripsi, &p.Kanal, &p.Akun, &p.CreatedAt,
+		&p.Voucher, &p.Discount, &p.Paket, &p.RenewalRow, &expiresAt, &p.GroupJID,
+	)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		s.logger.Printf("❌ Failed to remove pending payment: %v", err)
+		return nil
+	}
+
+	p.ExpiresAt = expiresAt.Time
+	s.logger.Printf("Pending dihapus: Rp%d | MsgID: %s", p.Amount, p.MessageID)
+	return &p
+}
+
 // Count returns total pending payments.
 func (s *PendingStore) Count() int {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
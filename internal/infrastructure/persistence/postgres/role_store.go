@@ -0,0 +1,92 @@
+// Package postgres implements PostgreSQL pending payment store.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// RoleStore persists bot role assignments in PostgreSQL.
+type RoleStore struct {
+	db *sql.DB
+}
+
+// NewRoleStore creates a new PostgreSQL role store.
+// Automatically creates the table if it doesn't exist.
+func NewRoleStore(ctx context.Context, databaseURL string) (*RoleStore, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	query := `
+		CREATE TABLE IF NOT EXISTS bot_roles (
+			phone TEXT PRIMARY KEY,
+			role TEXT NOT NULL,
+			granted_by TEXT NOT NULL DEFAULT '',
+			granted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+	`
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return &RoleStore{db: db}, nil
+}
+
+// GetRole returns the assignment of a phone, or nil if none.
+func (s *RoleStore) GetRole(ctx context.Context, phone string) (*entity.RoleAssignment, error) {
+	var a entity.RoleAssignment
+	err := s.db.QueryRowContext(ctx,
+		`SELECT phone, role, granted_by, granted_at FROM bot_roles WHERE phone = $1`, phone,
+	).Scan(&a.Phone, &a.Role, &a.GrantedBy, &a.GrantedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// SetRole creates or replaces an assignment.
+func (s *RoleStore) SetRole(ctx context.Context, a entity.RoleAssignment) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO bot_roles (phone, role, granted_by, granted_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (phone) DO UPDATE SET role = EXCLUDED.role, granted_by = EXCLUDED.granted_by, granted_at = EXCLUDED.granted_at
+	`, a.Phone, string(a.Role), a.GrantedBy, a.GrantedAt)
+	return err
+}
+
+// DeleteRole removes an assignment.
+func (s *RoleStore) DeleteRole(ctx context.Context, phone string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM bot_roles WHERE phone = $1`, phone)
+	return err
+}
+
+// ListRoles returns all assignments.
+func (s *RoleStore) ListRoles(ctx context.Context) ([]entity.RoleAssignment, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT phone, role, granted_by, granted_at FROM bot_roles ORDER BY phone`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []entity.RoleAssignment
+	for rows.Next() {
+		var a entity.RoleAssignment
+		if err := rows.Scan(&a.Phone, &a.Role, &a.GrantedBy, &a.GrantedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/exernia/botjanweb/internal/domain"
 	"github.com/exernia/botjanweb/internal/domain/entity"
 	"google.golang.org/api/sheets/v4"
 )
@@ -13,6 +14,15 @@ import (
 // AddAkunGoogle adds a new Google account to Akun Google sheet using InsertDimension.
 // Columns: A=Email, B=Sandi, C=Tanggal Aktivasi, D=Tanggal Berakhir, E=Status Dibuat, F=YT Premium
 func (r *Repository) AddAkunGoogle(ctx context.Context, akun *entity.AkunGoogle) error {
+	return r.AddAkunGoogleBatch(ctx, []entity.AkunGoogle{*akun})
+}
+
+// AddAkunGoogleBatch adds Google accounts to Akun Google sheet in a single BatchUpdate
+// (one InsertDimension for all rows + one UpdateCells). Passwords are encrypted first.
+func (r *Repository) AddAkunGoogleBatch(ctx context.Context, akuns []entity.AkunGoogle) error {
+	if len(akuns) == 0 {
+		return nil
+	}
 	sheetName := r.akunGoogleSheet
 
 	// Get sheet ID
@@ -28,19 +38,44 @@ func (r *Repository) AddAkunGoogle(ctx context.Context, akun *entity.AkunGoogle)
 	}
 
 	wib := time.FixedZone("WIB", 7*60*60)
-	tanggalAktivasi := akun.TanggalAktivasi.In(wib).Format("2006-01-02")
-	tanggalBerakhir := akun.TanggalBerakhir // Already formatted as YYYY-MM-DD from usecase
+	rows := make([]*sheets.RowData, 0, len(akuns))
+	for i := range akuns {
+		akun := &akuns[i]
+		tanggalAktivasi := akun.TanggalAktivasi.In(wib).Format("2006-01-02")
+		tanggalBerakhir := akun.TanggalBerakhir // Already formatted as YYYY-MM-DD from usecase
+		sandi, err := r.encryptSandi(akun.Sandi)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt password for %s: %w", akun.Email, err)
+		}
+
+		rows = append(rows, &sheets.RowData{
+			Values: []*sheets.CellData{
+				// A: Email (text biasa)
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: &akun.Email}},
+				// B: Sandi
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: &sandi}},
+				// C: Tanggal Aktivasi
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: &tanggalAktivasi}},
+				// D: Tanggal Berakhir (1 year from activation)
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: &tanggalBerakhir}},
+				// E: Status Dibuat (empty)
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: ptr("")}},
+				// F: YT Premium? (empty)
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: ptr("")}},
+			},
+		})
+	}
 
-	// Insert row and update cells
+	// Insert rows and update cells
 	requests := []*sheets.Request{
-		// Insert 1 row at lastRow position
+		// Insert len(akuns) rows at lastRow position
 		{
 			InsertDimension: &sheets.InsertDimensionRequest{
 				Range: &sheets.DimensionRange{
 					SheetId:    sheetID,
 					Dimension:  "ROWS",
 					StartIndex: lastRow,
-					EndIndex:   lastRow + 1,
+					EndIndex:   lastRow + int64(len(akuns)),
 				},
 				InheritFromBefore: true,
 			},
@@ -53,24 +88,7 @@ func (r *Repository) AddAkunGoogle(ctx context.Context, akun *entity.AkunGoogle)
 					RowIndex:    lastRow,
 					ColumnIndex: 0, // Column A
 				},
-				Rows: []*sheets.RowData{
-					{
-						Values: []*sheets.CellData{
-							// A: Email (text biasa)
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: &akun.Email}},
-							// B: Sandi
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: &akun.Sandi}},
-							// C: Tanggal Aktivasi
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: &tanggalAktivasi}},
-							// D: Tanggal Berakhir (1 year from activation)
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: &tanggalBerakhir}},
-							// E: Status Dibuat (empty)
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: ptr("")}},
-							// F: YT Premium? (empty)
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: ptr("")}},
-						},
-					},
-				},
+				Rows:   rows,
 				Fields: "userEnteredValue",
 			},
 		},
@@ -84,7 +102,11 @@ func (r *Repository) AddAkunGoogle(ctx context.Context, akun *entity.AkunGoogle)
 		return fmt.Errorf("failed to add Akun Google: %w", err)
 	}
 
-	r.logger.Printf("📊 Added Akun Google at row %d: %s", lastRow+1, akun.Email)
+	if len(akuns) == 1 {
+		r.logger.Printf("📊 Added Akun Google at row %d: %s", lastRow+1, akuns[0].Email)
+	} else {
+		r.logger.Printf("📊 Added %d Akun Google at rows %d-%d", len(akuns), lastRow+1, lastRow+int64(len(akuns)))
+	}
 	return nil
 }
 
@@ -92,22 +114,36 @@ func (r *Repository) AddAkunGoogle(ctx context.Context, akun *entity.AkunGoogle)
 // Note: Akun ChatGPT doesn't have a table, so we use Append.
 // Columns: A=Email, B=Sandi, C=WorkSpace, D=Status, E=Tanggal Aktivasi, F=Tanggal kena ban
 func (r *Repository) AddAkunChatGPT(ctx context.Context, akun *entity.AkunChatGPT) error {
-	wib := time.FixedZone("WIB", 7*60*60)
-	tanggal := akun.TanggalAktivasi.In(wib).Format("2006-01-02")
+	return r.AddAkunChatGPTBatch(ctx, []entity.AkunChatGPT{*akun})
+}
 
-	values := [][]interface{}{
-		{
+// AddAkunChatGPTBatch appends ChatGPT accounts to Akun ChatGPT sheet in a single Append.
+// Passwords are encrypted first.
+func (r *Repository) AddAkunChatGPTBatch(ctx context.Context, akuns []entity.AkunChatGPT) error {
+	if len(akuns) == 0 {
+		return nil
+	}
+
+	wib := time.FixedZone("WIB", 7*60*60)
+	values := make([][]interface{}, 0, len(akuns))
+	for _, akun := range akuns {
+		tanggal := akun.TanggalAktivasi.In(wib).Format("2006-01-02")
+		sandi, err := r.encryptSandi(akun.Sandi)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt password for %s: %w", akun.Email, err)
+		}
+		values = append(values, []interface{}{
 			akun.Email,          // A: Email
-			akun.Sandi,          // B: Sandi
+			sandi,               // B: Sandi
 			akun.Workspace,      // C: WorkSpace
 			akun.Status,         // D: Status (empty)
 			tanggal,             // E: Tanggal Aktivasi
 			akun.TanggalKenaBan, // F: Tanggal kena ban (empty)
-		},
+		})
 	}
 
 	valueRange := &sheets.ValueRange{Values: values}
-	appendRange := "'Akun ChatGPT'!A:F"
+	appendRange := fmt.Sprintf("'%s'!A:F", r.akunChatGPTSheet)
 
 	_, err := r.service.Spreadsheets.Values.Append(
 		r.spreadsheetID,
@@ -119,17 +155,21 @@ func (r *Repository) AddAkunChatGPT(ctx context.Context, akun *entity.AkunChatGP
 		return fmt.Errorf("failed to add Akun ChatGPT: %w", err)
 	}
 
-	r.logger.Printf("📊 Added Akun ChatGPT: %s (%s)", akun.Email, akun.Workspace)
+	if len(akuns) == 1 {
+		r.logger.Printf("📊 Added Akun ChatGPT: %s (%s)", akuns[0].Email, akuns[0].Workspace)
+	} else {
+		r.logger.Printf("📊 Added %d Akun ChatGPT", len(akuns))
+	}
 	return nil
 }
 
 // GetAkunGoogleList fetches all Google accounts from Akun Google sheet.
 // Columns: A=Email, B=Sandi, C=Tanggal Aktivasi, D=Tanggal Berakhir, E=Status Dibuat, F=YT Premium?, G=Keterangan
 func (r *Repository) GetAkunGoogleList(ctx context.Context) ([]entity.AkunGoogle, error) {
-	readRange := "'Akun Google'!A:G"
+	readRange := fmt.Sprintf("'%s'!A:G", r.akunGoogleSheet)
 	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read Akun Google sheet: %w", err)
+		return nil, fmt.Errorf("failed to read %s sheet: %w", r.akunGoogleSheet, err)
 	}
 
 	var accounts []entity.AkunGoogle
@@ -178,10 +218,10 @@ func (r *Repository) GetAkunGoogleList(ctx context.Context) ([]entity.AkunGoogle
 // GetAkunChatGPTList fetches all ChatGPT accounts from Akun ChatGPT sheet.
 // Columns: A=Email, B=Sandi, C=WorkSpace, D=Status, E=Tanggal Aktivasi, F=Tanggal kena ban
 func (r *Repository) GetAkunChatGPTList(ctx context.Context) ([]entity.AkunChatGPT, error) {
-	readRange := "'Akun ChatGPT'!A:F"
+	readRange := fmt.Sprintf("'%s'!A:F", r.akunChatGPTSheet)
 	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read Akun ChatGPT sheet: %w", err)
+		return nil, fmt.Errorf("failed to read %s sheet: %w", r.akunChatGPTSheet, err)
 	}
 
 	var accounts []entity.AkunChatGPT
@@ -257,3 +297,44 @@ func (r *Repository) GetAccountListResult(ctx context.Context) (*entity.AccountL
 
 	return result, nil
 }
+
+// GetAccountCredential finds an account by email (case-insensitive) in Akun Google,
+// then Akun ChatGPT, and returns it with the password decrypted.
+// Passwords written before encryption was enabled are returned as stored.
+func (r *Repository) GetAccountCredential(ctx context.Context, email string) (*entity.AccountCredential, error) {
+	var cred *entity.AccountCredential
+
+	googleAccounts, err := r.GetAkunGoogleList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Google accounts: %w", err)
+	}
+	for _, akun := range googleAccounts {
+		if strings.EqualFold(akun.Email, email) {
+			cred = &entity.AccountCredential{Tipe: entity.AccountTypeGoogle, Email: akun.Email, Sandi: akun.Sandi, Group: akun.StatusDibuat}
+			break
+		}
+	}
+
+	if cred == nil {
+		chatgptAccounts, err := r.GetAkunChatGPTList(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ChatGPT accounts: %w", err)
+		}
+		for _, akun := range chatgptAccounts {
+			if strings.EqualFold(akun.Email, email) {
+				cred = &entity.AccountCredential{Tipe: entity.AccountTypeChatGPT, Email: akun.Email, Sandi: akun.Sandi, Group: akun.Workspace}
+				break
+			}
+		}
+	}
+
+	if cred == nil {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	cred.Sandi, err = r.secrets.Decrypt(cred.Sandi)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt password for %s: %w", cred.Email, err)
+	}
+	return cred, nil
+}
@@ -0,0 +1,71 @@
+package sheets
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+)
+
+// GetCatalog reads the product price list from the Harga sheet.
+// Columns: A=Produk, B=Paket, C=Harga, D=Min, E=Max, F=Slot
+// Paket/Min/Max/Slot are optional; rows with unknown product, invalid Harga or
+// a Slot that is not a whole number are skipped.
+func (r *Repository) GetCatalog(ctx context.Context) ([]entity.CatalogItem, error) {
+	// Read from Harga sheet (skip header row 1)
+	readRange := "'Harga'!A2:F"
+	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Harga sheet: %w", err)
+	}
+
+	items := make([]entity.CatalogItem, 0, len(resp.Values))
+	for i, row := range resp.Values {
+		cell := func(col int) string {
+			if len(row) > col && row[col] != nil {
+				return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+			}
+			return ""
+		}
+
+		if cell(0) == "" {
+			continue
+		}
+
+		product, err := entity.ParseProduct(cell(0))
+		if err != nil {
+			r.logger.Printf("⚠️ Harga row %d skipped: %v", i+2, err)
+			continue
+		}
+		harga, err := parser.ParseRupiah(cell(2))
+		if err != nil {
+			r.logger.Printf("⚠️ Harga row %d skipped: %v", i+2, err)
+			continue
+		}
+
+		item := entity.CatalogItem{
+			Produk: product,
+			Paket:  cell(1),
+			Harga:  harga,
+		}
+		// Optional columns: ignore blanks/invalid values (defaults apply)
+		item.MinHarga, _ = parser.ParseRupiah(cell(3))
+		item.MaxHarga, _ = parser.ParseRupiah(cell(4))
+		// Slot is a count, not money: a bad value must not become a bogus capacity
+		if slots := cell(5); slots != "" {
+			n, err := strconv.Atoi(slots)
+			if err != nil || n < 0 {
+				r.logger.Printf("⚠️ Harga row %d skipped: invalid Slot %q", i+2, slots)
+				continue
+			}
+			item.MaxSlots = n
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
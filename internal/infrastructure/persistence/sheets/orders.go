@@ -3,9 +3,11 @@ package sheets
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
 	"google.golang.org/api/sheets/v4"
 )
 
@@ -14,12 +16,15 @@ import (
 //
 // Spreadsheet column mappings (verified from actual sheets):
 //
-// Gemini:      A=No, B=Nama, C=Email, D=Family, E=TglPesanan, G=Nominal, H=Kanal, I=Akun/Nomor
-// ChatGPT:     A=No, B=Nama, C=Email, D=WorkSpace, E=Paket, F=TglPesanan, H=Nominal, I=Kanal, J=Bukti
-// YouTube:     A=No, B=Nama, C=Email, D=Email Head, E=TglPesan, G=Status, H=Nominal, I=Kanal
+// Gemini:      A=No, B=Nama, C=Email, D=Family, E=TglPesanan, F=TglBerakhir, G=Nominal, H=Kanal, I=Akun/Nomor
+// ChatGPT:     A=No, B=Nama, C=Email, D=WorkSpace, E=Paket, F=TglPesanan, G=TglBerakhir, H=Nominal, I=Kanal, J=Bukti
+// YouTube:     A=No, B=Nama, C=Email, D=Email Head, E=TglPesan, F=TglBerakhir, G=Status, H=Nominal, I=Kanal
 // Perplexity:  A=No, B=Nama, C=Email, D=Kode Redeem, E=TglPesanan, G=Nominal, H=Kanal, I=Nomor/Username
 //
-// NOTE: Kolom F (Tanggal Berakhir) TIDAK DIISI oleh bot (diisi manual/formula)
+// Tanggal Berakhir (Gemini/YouTube F, ChatGPT G) is filled from product + paket
+// when the product tracks an end date (see subscriptionLayouts).
+// Orders paid with a voucher also get Voucher and Diskon in the two columns after
+// the last one above (Gemini/YouTube/Perplexity J-K, ChatGPT K-L).
 func (r *Repository) LogOrder(ctx context.Context, order *entity.Order) error {
 	// Determine target sheet from Produk field
 	targetSheet := r.resolveSheetName(order.Produk)
@@ -290,7 +295,7 @@ func (r *Repository) LogOrder(ctx context.Context, order *entity.Order) error {
 
 	case "Perplexity":
 		// D=Kode Redeem, E=TglPesanan, G=Nominal, H=Kanal, I=Nomor/Username
-		kodeRedeem := order.KodeRedeem // Empty initially, filled by admin later
+		kodeRedeem := order.KodeRedeem // Claimed on confirmation; empty if stock ran out
 
 		requests = append(requests,
 			// Update D (Kode Redeem)
@@ -355,6 +360,14 @@ func (r *Repository) LogOrder(ctx context.Context, order *entity.Order) error {
 		return fmt.Errorf("unsupported product: %s", order.Produk)
 	}
 
+	// Tanggal Berakhir (products with subscription tracking)
+	if req := endDateRequest(sheetID, lastRow, order); req != nil {
+		requests = append(requests, req)
+	}
+	if req := voucherRequest(sheetID, lastRow, order); req != nil {
+		requests = append(requests, req)
+	}
+
 	_, err = r.service.Spreadsheets.BatchUpdate(r.spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
 		Requests: requests,
 	}).Do()
@@ -413,3 +426,105 @@ func (r *Repository) findLastTableRowByColumn(sheetName string, column string) (
 
 	return lastDataRow + 1, nil
 }
+
+// orderColumns maps the per-product order columns of a product sheet (0-indexed, -1 = none).
+// Nama (B) and Email (C) are the same for all products (see LogOrder).
+type orderColumns struct {
+	owner   int // Family / WorkSpace / Email Head
+	kode    int // Kode Redeem
+	paket   int // Paket
+	ordered int // Tanggal Pesanan
+	end     int // Tanggal Berakhir
+	amount  int // Nominal
+	kanal   int // Kanal
+	akun    int // Akun/Nomor/Username/Bukti
+	voucher int // Voucher code; Diskon is the next column
+}
+
+// orderLayouts lists the order columns of every product sheet.
+var orderLayouts = map[entity.Product]orderColumns{
+	entity.ProductGemini:     {owner: 3, kode: -1, paket: -1, ordered: 4, end: 5, amount: 6, kanal: 7, akun: 8, voucher: 9},
+	entity.ProductChatGPT:    {owner: 3, kode: -1, paket: 4, ordered: 5, end: 6, amount: 7, kanal: 8, akun: 9, voucher: 10},
+	entity.ProductYouTube:    {owner: 3, kode: -1, paket: -1, ordered: 4, end: 5, amount: 7, kanal: 8, akun: -1, voucher: 9},
+	entity.ProductPerplexity: {owner: -1, kode: 3, paket: -1, ordered: 4, end: -1, amount: 6, kanal: 7, akun: 8, voucher: 9},
+}
+
+// voucherRequest writes the voucher code and discount of an order, or returns
+// nil when no voucher was used.
+func voucherRequest(sheetID, rowIndex int64, order *entity.Order) *sheets.Request {
+	if order.Voucher == "" {
+		return nil
+	}
+	layout, ok := orderLayouts[entity.Product(order.Produk)]
+	if !ok {
+		return nil
+	}
+
+	return &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:     sheetID,
+				RowIndex:    rowIndex,
+				ColumnIndex: int64(layout.voucher),
+			},
+			Rows: []*sheets.RowData{
+				{
+					Values: []*sheets.CellData{
+						{UserEnteredValue: &sheets.ExtendedValue{StringValue: &order.Voucher}},
+						{UserEnteredValue: &sheets.ExtendedValue{NumberValue: ptr64(float64(order.Discount))}},
+					},
+				},
+			},
+			Fields: "userEnteredValue",
+		},
+	}
+}
+
+// ListOrders reads all order rows of a product sheet.
+// Rows without an email in column C (headers, separators) are skipped.
+func (r *Repository) ListOrders(ctx context.Context, product entity.Product) ([]entity.Order, error) {
+	layout, ok := orderLayouts[product]
+	if !ok {
+		return nil, fmt.Errorf("no order layout for product %s", product)
+	}
+
+	sheetName := product.SheetName()
+	readRange := fmt.Sprintf("'%s'!A:L", sheetName)
+	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s sheet: %w", sheetName, err)
+	}
+
+	var orders []entity.Order
+	for _, row := range resp.Values {
+		cell := func(col int) string {
+			if col >= 0 && len(row) > col && row[col] != nil {
+				return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+			}
+			return ""
+		}
+
+		if !strings.Contains(cell(2), "@") {
+			continue
+		}
+		amount, _ := parser.ParseRupiah(cell(layout.amount))        // Empty/invalid nominal → 0
+		discount, _ := parser.ParseRupiah(cell(layout.voucher + 1)) // Diskon, next to Voucher
+		orders = append(orders, entity.Order{
+			Produk:          string(product),
+			Nama:            cell(1),
+			Email:           cell(2),
+			Family:          cell(layout.owner),
+			KodeRedeem:      cell(layout.kode),
+			Paket:           cell(layout.paket),
+			TanggalPesanan:  parseSheetDate(cell(layout.ordered)),
+			TanggalBerakhir: parseSheetDate(cell(layout.end)),
+			Amount:          amount,
+			Kanal:           cell(layout.kanal),
+			Akun:            cell(layout.akun),
+			Voucher:         cell(layout.voucher),
+			Discount:        discount,
+		})
+	}
+
+	return orders, nil
+}
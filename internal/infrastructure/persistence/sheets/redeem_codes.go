@@ -4,18 +4,21 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/exernia/botjanweb/internal/domain"
 	"github.com/exernia/botjanweb/internal/domain/entity"
 	"google.golang.org/api/sheets/v4"
 )
 
 // GetRedeemCodeAvailability returns available Perplexity redeem codes.
 // Reads from "Kode Perplexity" sheet.
-// Columns: A=No, B=Email, C=Kode redeem, D=Tanggal aktivasi, E=Tanggal berakhir
-// availableOnly: if true, only return codes where D (Tanggal aktivasi) is empty
+// Columns: A=No, B=Email, C=Kode redeem, D=Tanggal aktivasi, E=Tanggal berakhir,
+// F=Customer, G=Keterangan
+// availableOnly: if true, only return codes where D (Tanggal aktivasi) is empty and G is not "Invalid"
 func (r *Repository) GetRedeemCodeAvailability(ctx context.Context, availableOnly bool) (*entity.RedeemCodeResult, error) {
 	// Read from Kode Perplexity sheet (skip header row 1)
-	readRange := "'Kode Perplexity'!A2:E"
+	readRange := "'Kode Perplexity'!A2:G"
 	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Kode Perplexity sheet: %w", err)
@@ -30,27 +33,22 @@ func (r *Repository) GetRedeemCodeAvailability(ctx context.Context, availableOnl
 		if len(row) < 1 {
 			continue
 		}
-
-		code := entity.RedeemCodeInfo{
-			No: i + 2, // Row number (1-indexed, plus header)
+		cell := func(col int) string {
+			if len(row) > col && row[col] != nil {
+				return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+			}
+			return ""
 		}
 
-		// Parse columns
-		if len(row) > 0 && row[0] != nil {
-			// Column A: No (usually just row number, but use index)
-			code.No = i + 2
-		}
-		if len(row) > 1 && row[1] != nil {
-			code.Email = strings.TrimSpace(fmt.Sprintf("%v", row[1]))
-		}
-		if len(row) > 2 && row[2] != nil {
-			code.KodeRedeem = strings.TrimSpace(fmt.Sprintf("%v", row[2]))
-		}
-		if len(row) > 3 && row[3] != nil {
-			code.TanggalAktivasi = strings.TrimSpace(fmt.Sprintf("%v", row[3]))
-		}
-		if len(row) > 4 && row[4] != nil {
-			code.TanggalBerakhir = strings.TrimSpace(fmt.Sprintf("%v", row[4]))
+		// Column A: No (usually just row number, but use index)
+		code := entity.RedeemCodeInfo{
+			No:              i + 2, // Row number (1-indexed, plus header)
+			Email:           cell(1),
+			KodeRedeem:      cell(2),
+			TanggalAktivasi: cell(3),
+			TanggalBerakhir: cell(4),
+			Customer:        cell(5),
+			Keterangan:      cell(6),
 		}
 
 		// Skip empty rows (no email or kode)
@@ -60,11 +58,13 @@ func (r *Repository) GetRedeemCodeAvailability(ctx context.Context, availableOnl
 
 		result.TotalCodes++
 
-		// Check if available (Tanggal aktivasi is empty)
-		isAvailable := code.TanggalAktivasi == ""
+		isAvailable := code.IsAvailable()
 		if isAvailable {
 			result.AvailableCodes++
 		}
+		if code.IsInvalid() {
+			result.InvalidCodes++
+		}
 
 		// Filter based on availableOnly
 		if availableOnly && !isAvailable {
@@ -81,6 +81,15 @@ func (r *Repository) GetRedeemCodeAvailability(ctx context.Context, availableOnl
 // Inserts a new row with Email and Kode redeem.
 // Columns: A=No (auto), B=Email, C=Kode redeem, D=Tanggal aktivasi (empty), E=Tanggal berakhir (empty)
 func (r *Repository) AddRedeemCode(ctx context.Context, email, kodeRedeem string) error {
+	return r.AddRedeemCodes(ctx, []entity.RedeemCodeInfo{{Email: email, KodeRedeem: kodeRedeem}})
+}
+
+// AddRedeemCodes adds redeem codes (Email + KodeRedeem) to Kode Perplexity sheet
+// in a single BatchUpdate, numbering column A after the last row.
+func (r *Repository) AddRedeemCodes(ctx context.Context, codes []entity.RedeemCodeInfo) error {
+	if len(codes) == 0 {
+		return nil
+	}
 	sheetName := "Kode Perplexity"
 
 	// Get sheet ID
@@ -106,19 +115,38 @@ func (r *Repository) AddRedeemCode(ctx context.Context, email, kodeRedeem string
 	// Insert after last row
 	insertRow := lastRow + 1
 
-	// Calculate the No value (row number - 1 for header)
-	noValue := float64(insertRow) // No is just the row number minus header
+	rows := make([]*sheets.RowData, 0, len(codes))
+	for i := range codes {
+		code := &codes[i]
+		// No is just the row number minus header
+		noValue := float64(insertRow + int64(i))
 
-	// Insert row and update cells
+		rows = append(rows, &sheets.RowData{
+			Values: []*sheets.CellData{
+				// A: No
+				{UserEnteredValue: &sheets.ExtendedValue{NumberValue: &noValue}},
+				// B: Email (text biasa)
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: &code.Email}},
+				// C: Kode redeem
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: &code.KodeRedeem}},
+				// D: Tanggal aktivasi (empty)
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: ptr("")}},
+				// E: Tanggal berakhir (empty)
+				{UserEnteredValue: &sheets.ExtendedValue{StringValue: ptr("")}},
+			},
+		})
+	}
+
+	// Insert rows and update cells
 	requests := []*sheets.Request{
-		// Insert 1 row at insertRow position
+		// Insert len(codes) rows at insertRow position
 		{
 			InsertDimension: &sheets.InsertDimensionRequest{
 				Range: &sheets.DimensionRange{
 					SheetId:    sheetID,
 					Dimension:  "ROWS",
 					StartIndex: insertRow,
-					EndIndex:   insertRow + 1,
+					EndIndex:   insertRow + int64(len(codes)),
 				},
 				InheritFromBefore: true,
 			},
@@ -131,22 +159,7 @@ func (r *Repository) AddRedeemCode(ctx context.Context, email, kodeRedeem string
 					RowIndex:    insertRow,
 					ColumnIndex: 0, // Column A
 				},
-				Rows: []*sheets.RowData{
-					{
-						Values: []*sheets.CellData{
-							// A: No
-							{UserEnteredValue: &sheets.ExtendedValue{NumberValue: &noValue}},
-							// B: Email (text biasa)
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: &email}},
-							// C: Kode redeem
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: &kodeRedeem}},
-							// D: Tanggal aktivasi (empty)
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: ptr("")}},
-							// E: Tanggal berakhir (empty)
-							{UserEnteredValue: &sheets.ExtendedValue{StringValue: ptr("")}},
-						},
-					},
-				},
+				Rows:   rows,
 				Fields: "userEnteredValue",
 			},
 		},
@@ -160,6 +173,168 @@ func (r *Repository) AddRedeemCode(ctx context.Context, email, kodeRedeem string
 		return fmt.Errorf("failed to add redeem code: %w", err)
 	}
 
-	r.logger.Printf("📊 Added redeem code at row %d: %s (%s)", insertRow+1, email, kodeRedeem)
+	if len(codes) == 1 {
+		r.logger.Printf("📊 Added redeem code at row %d: %s (%s)", insertRow+1, codes[0].Email, codes[0].KodeRedeem)
+	} else {
+		r.logger.Printf("📊 Added %d redeem codes at rows %d-%d", len(codes), insertRow+1, insertRow+int64(len(codes)))
+	}
 	return nil
 }
+
+// ClaimRedeemCode claims the next available redeem code from Kode Perplexity sheet.
+// The first available row (Kode redeem set, empty Tanggal aktivasi, not flagged invalid)
+// is claimed by filling column D with today's date (WIB) and F with the customer. Claims are serialized so two payments
+// confirmed at the same time never receive the same code.
+func (r *Repository) ClaimRedeemCode(ctx context.Context, customerEmail string) (*entity.RedeemCodeInfo, error) {
+	r.redeemMu.Lock()
+	defer r.redeemMu.Unlock()
+
+	result, err := r.GetRedeemCodeAvailability(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var code *entity.RedeemCodeInfo
+	for i := range result.Codes {
+		if result.Codes[i].KodeRedeem != "" {
+			code = &result.Codes[i]
+			break
+		}
+	}
+	if code == nil {
+		return nil, fmt.Errorf("no available redeem code in Kode Perplexity")
+	}
+
+	code.TanggalAktivasi = formatSheetDate(time.Now())
+	code.Customer = customerEmail
+	if err := r.writeRedeemCodeState(code); err != nil {
+		return nil, fmt.Errorf("failed to claim redeem code at row %d: %w", code.No, err)
+	}
+
+	r.logger.Printf("🎫 Claimed redeem code at row %d for %s", code.No, customerEmail)
+	return code, nil
+}
+
+// AssignRedeemCode marks a code as used by a customer (#kode pakai):
+// D = activation date, F = customer. Invalid or already used codes are rejected.
+func (r *Repository) AssignRedeemCode(ctx context.Context, kode, customer string, activated time.Time) (*entity.RedeemCodeInfo, error) {
+	r.redeemMu.Lock()
+	defer r.redeemMu.Unlock()
+
+	code, err := r.findRedeemCode(ctx, kode)
+	if err != nil {
+		return nil, err
+	}
+	if code.IsInvalid() {
+		return code, domain.ErrRedeemCodeInvalid
+	}
+	if code.TanggalAktivasi != "" {
+		return code, domain.ErrRedeemCodeUsed
+	}
+
+	code.TanggalAktivasi = formatSheetDate(activated)
+	code.Customer = customer
+	if err := r.writeRedeemCodeState(code); err != nil {
+		return nil, fmt.Errorf("failed to assign redeem code at row %d: %w", code.No, err)
+	}
+
+	r.logger.Printf("🎫 Assigned redeem code at row %d to %s", code.No, customer)
+	return code, nil
+}
+
+// SetRedeemCodeDates sets the activation (D) and end (E) date of a code.
+// A zero end date leaves column E unchanged.
+func (r *Repository) SetRedeemCodeDates(ctx context.Context, kode string, activated, ends time.Time) (*entity.RedeemCodeInfo, error) {
+	r.redeemMu.Lock()
+	defer r.redeemMu.Unlock()
+
+	code, err := r.findRedeemCode(ctx, kode)
+	if err != nil {
+		return nil, err
+	}
+
+	code.TanggalAktivasi = formatSheetDate(activated)
+	if !ends.IsZero() {
+		code.TanggalBerakhir = formatSheetDate(ends)
+	}
+	if err := r.writeRedeemCodeState(code); err != nil {
+		return nil, fmt.Errorf("failed to update redeem code dates at row %d: %w", code.No, err)
+	}
+
+	r.logger.Printf("🎫 Updated redeem code dates at row %d: %s - %s", code.No, code.TanggalAktivasi, code.TanggalBerakhir)
+	return code, nil
+}
+
+// MarkRedeemCodeInvalid flags a code as invalid (G = "Invalid[: reason]") so it is never claimed.
+func (r *Repository) MarkRedeemCodeInvalid(ctx context.Context, kode, reason string) (*entity.RedeemCodeInfo, error) {
+	r.redeemMu.Lock()
+	defer r.redeemMu.Unlock()
+
+	code, err := r.findRedeemCode(ctx, kode)
+	if err != nil {
+		return nil, err
+	}
+
+	code.Keterangan = "Invalid"
+	if reason != "" {
+		code.Keterangan += ": " + reason
+	}
+	if err := r.writeRedeemCodeState(code); err != nil {
+		return nil, fmt.Errorf("failed to flag redeem code at row %d: %w", code.No, err)
+	}
+
+	r.logger.Printf("🎫 Flagged redeem code at row %d as invalid", code.No)
+	return code, nil
+}
+
+// ReleaseRedeemCode makes a code available again by clearing D (activation),
+// E (end), F (customer) and G (notes).
+func (r *Repository) ReleaseRedeemCode(ctx context.Context, kode string) (*entity.RedeemCodeInfo, error) {
+	r.redeemMu.Lock()
+	defer r.redeemMu.Unlock()
+
+	code, err := r.findRedeemCode(ctx, kode)
+	if err != nil {
+		return nil, err
+	}
+
+	code.TanggalAktivasi = ""
+	code.TanggalBerakhir = ""
+	code.Customer = ""
+	code.Keterangan = ""
+	if err := r.writeRedeemCodeState(code); err != nil {
+		return nil, fmt.Errorf("failed to release redeem code at row %d: %w", code.No, err)
+	}
+
+	r.logger.Printf("🎫 Released redeem code at row %d", code.No)
+	return code, nil
+}
+
+// findRedeemCode returns the row of a redeem code (case-insensitive).
+// Callers must hold redeemMu.
+func (r *Repository) findRedeemCode(ctx context.Context, kode string) (*entity.RedeemCodeInfo, error) {
+	result, err := r.GetRedeemCodeAvailability(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Codes {
+		if strings.EqualFold(result.Codes[i].KodeRedeem, kode) {
+			return &result.Codes[i], nil
+		}
+	}
+	return nil, domain.ErrRedeemCodeNotFound
+}
+
+// writeRedeemCodeState writes columns D-G (activation, end, customer, notes) of a code row.
+func (r *Repository) writeRedeemCodeState(code *entity.RedeemCodeInfo) error {
+	updateRange := fmt.Sprintf("'Kode Perplexity'!D%d:G%d", code.No, code.No)
+	_, err := r.service.Spreadsheets.Values.Update(r.spreadsheetID, updateRange, &sheets.ValueRange{
+		Values: [][]interface{}{{code.TanggalAktivasi, code.TanggalBerakhir, code.Customer, code.Keterangan}},
+	}).ValueInputOption("USER_ENTERED").Do()
+	return err
+}
+
+// formatSheetDate formats a date as YYYY-MM-DD in WIB.
+func formatSheetDate(t time.Time) string {
+	return t.In(time.FixedZone("WIB", 7*60*60)).Format("2006-01-02")
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 
+	"github.com/exernia/botjanweb/pkg/helper/secret"
 	"github.com/exernia/botjanweb/pkg/logger"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
@@ -21,12 +23,21 @@ type Repository struct {
 	ordersSheet      string
 	akunGoogleSheet  string
 	akunChatGPTSheet string
+	akunYouTubeSheet string
+
+	// secrets encrypts account passwords before writing (nil = stored as plain text)
+	secrets *secret.Cipher
+
+	// redeemMu serializes redeem code claims (read-then-write on Kode Perplexity)
+	redeemMu sync.Mutex
+	// voucherMu serializes voucher usage updates (read-then-write on Voucher)
+	voucherMu sync.Mutex
 }
 
 // NewRepository creates a new Sheets repository.
 // Accepts either credentialsPath (for local dev) or credentialsJSON (for cloud deployment like Heroku).
 // If credentialsJSON is provided, it takes precedence over credentialsPath.
-func NewRepository(spreadsheetID, credentialsPath, credentialsJSON, ordersSheet, akunGoogleSheet, akunChatGPTSheet string) (*Repository, error) {
+func NewRepository(spreadsheetID, credentialsPath, credentialsJSON, ordersSheet, akunGoogleSheet, akunChatGPTSheet, akunYouTubeSheet string) (*Repository, error) {
 	ctx := context.Background()
 
 	var srv *sheets.Service
@@ -52,5 +63,19 @@ func NewRepository(spreadsheetID, credentialsPath, credentialsJSON, ordersSheet,
 		ordersSheet:      ordersSheet,
 		akunGoogleSheet:  akunGoogleSheet,
 		akunChatGPTSheet: akunChatGPTSheet,
+		akunYouTubeSheet: akunYouTubeSheet,
 	}, nil
 }
+
+// SetCipher enables password encryption for Akun Google / Akun ChatGPT rows.
+func (r *Repository) SetCipher(c *secret.Cipher) {
+	r.secrets = c
+}
+
+// encryptSandi encrypts a password for column B (unchanged if encryption is off).
+func (r *Repository) encryptSandi(sandi string) (string, error) {
+	if r.secrets == nil {
+		return sandi, nil
+	}
+	return r.secrets.Encrypt(sandi)
+}
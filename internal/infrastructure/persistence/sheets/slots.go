@@ -6,195 +6,135 @@ import (
 	"strings"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"google.golang.org/api/sheets/v4"
 )
 
-// ValidateFamily checks if a family email exists in Akun Google sheet (column A).
-// Returns true if found, false otherwise.
-func (r *Repository) ValidateFamily(ctx context.Context, familyEmail string) (bool, error) {
-	// Read column A (email) from Akun Google sheet
-	readRange := "'Akun Google'!A:A"
+// accountHeaderRows is the header row count of every account sheet (Email in column A).
+const accountHeaderRows = 1
+
+// SlotOwnerExists checks if a slot owner is listed in the product's account sheet (column A).
+// Matching is case-insensitive. Returns true if found, false otherwise.
+func (r *Repository) SlotOwnerExists(ctx context.Context, product entity.Product, owner string) (bool, error) {
+	info := product.Info()
+	if !info.HasSlots() {
+		return false, fmt.Errorf("product %q has no slots", product)
+	}
+
+	readRange := fmt.Sprintf("'%s'!A:A", info.AccountSheet)
 	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
 	if err != nil {
-		return false, fmt.Errorf("failed to read Akun Google sheet: %w", err)
+		return false, fmt.Errorf("failed to read %s sheet: %w", info.AccountSheet, err)
 	}
 
-	// Search for the email (case-insensitive)
-	familyLower := strings.ToLower(strings.TrimSpace(familyEmail))
-	for _, row := range resp.Values {
-		if len(row) > 0 {
-			cellValue := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", row[0])))
-			if cellValue == familyLower {
-				return true, nil
-			}
+	ownerLower := strings.ToLower(strings.TrimSpace(owner))
+	for i, row := range resp.Values {
+		if i < accountHeaderRows || len(row) < 1 {
+			continue
+		}
+		if strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", row[0]))) == ownerLower {
+			return true, nil
 		}
 	}
 
 	return false, nil
 }
 
-// CountFamilySlots counts how many slots are used for a family in Gemini sheet (column D).
-// Returns the count of non-empty rows with matching family value.
-func (r *Repository) CountFamilySlots(ctx context.Context, family string) (int, error) {
-	// Read columns B, C, and D from Gemini sheet
-	// C = Email (to check if slot is used), D = Family
-	readRange := "'Gemini'!B:D"
-	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
+// CountSlots counts how many slots are used for an owner in the product sheet.
+// A slot is used when the member column is filled; rows are read like
+// GetSlotAvailability, so #cekslot and the #qris slot check agree.
+func (r *Repository) CountSlots(ctx context.Context, product entity.Product, owner string) (int, error) {
+	rows, err := r.slotRows(product)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read Gemini sheet: %w", err)
+		return 0, err
 	}
 
 	count := 0
-	familyLower := strings.ToLower(strings.TrimSpace(family))
-
-	// Skip header row (index 0)
-	for i, row := range resp.Values {
-		if i == 0 {
-			continue // Skip header
-		}
-		if len(row) < 3 {
-			continue // Need at least columns B, C, D
-		}
-
-		// Column C = Email (index 1 in this range)
-		// Column D = Family (index 2 in this range)
-		email := strings.TrimSpace(fmt.Sprintf("%v", row[1]))
-		familyCell := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", row[2])))
-
-		// Count if family matches AND slot is filled (email not empty)
-		if familyCell == familyLower && email != "" {
+	ownerLower := strings.ToLower(strings.TrimSpace(owner))
+	for _, row := range rows {
+		if strings.ToLower(row.owner) == ownerLower && row.member != "" {
 			count++
 		}
 	}
-
 	return count, nil
 }
 
-// ValidateWorkspaceEmail checks if workspace owner email exists in Akun ChatGPT sheet (column A).
-// Returns true if found, false otherwise.
-func (r *Repository) ValidateWorkspaceEmail(ctx context.Context, ownerEmail string) (bool, error) {
-	// Read column A (Email) from Akun ChatGPT sheet
-	readRange := "'Akun ChatGPT'!A:A"
-	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
-	if err != nil {
-		return false, fmt.Errorf("failed to read Akun ChatGPT sheet: %w", err)
-	}
-
-	// Search for the owner email (case-insensitive)
-	emailLower := strings.ToLower(strings.TrimSpace(ownerEmail))
-	for i, row := range resp.Values {
-		if i == 0 {
-			continue // Skip header row
-		}
-		if len(row) < 1 {
-			continue
-		}
+// slotRow is one member row of a product sheet, reduced to its slot columns.
+type slotRow struct {
+	owner  string // Slot owner (family/workspace/head)
+	member string // Member email; empty = free slot
+}
 
-		emailCell := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", row[0])))
-		if emailCell == emailLower {
-			return true, nil
-		}
+// slotRows reads the owner and member columns of a product sheet below its header rows.
+// Columns and header rows come from the product registry (see ProductInfo).
+func (r *Repository) slotRows(product entity.Product) ([]slotRow, error) {
+	info := product.Info()
+	if !info.HasSlots() {
+		return nil, fmt.Errorf("product %q has no slots", product)
 	}
 
-	return false, nil // Email not found
-}
-
-// CountWorkspaceSlots counts how many slots are used for owner email in ChatGPT sheet (column D).
-// Column D now contains owner email (not workspace name).
-// Returns the count of non-empty rows with matching owner email.
-func (r *Repository) CountWorkspaceSlots(ctx context.Context, ownerEmail string) (int, error) {
-	// Read columns B, C, and D from ChatGPT sheet
-	// C = Email (to check if slot is used), D = Owner Email
-	readRange := "'ChatGPT'!B:D"
+	last := info.OwnerColumn
+	if info.MemberColumn > last {
+		last = info.MemberColumn
+	}
+	readRange := fmt.Sprintf("'%s'!A:%s", info.SheetName, columnLetter(last))
 	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
 	if err != nil {
-		return 0, fmt.Errorf("failed to read ChatGPT sheet: %w", err)
+		return nil, fmt.Errorf("failed to read %s sheet: %w", info.SheetName, err)
 	}
 
-	count := 0
-	emailLower := strings.ToLower(strings.TrimSpace(ownerEmail))
-
-	// Skip header rows (index 0 and 1 - title and header)
+	var rows []slotRow
 	for i, row := range resp.Values {
-		if i < 2 {
-			continue // Skip title and header rows
-		}
-		if len(row) < 3 {
-			continue // Need at least columns B, C, D
+		if i < info.HeaderRows {
+			continue
 		}
-
-		// Column C = Email (index 1 in this range)
-		// Column D = Owner Email (index 2 in this range)
-		email := strings.TrimSpace(fmt.Sprintf("%v", row[1]))
-		ownerEmailCell := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", row[2])))
-
-		// Count if owner email matches AND slot is filled (email not empty)
-		if ownerEmailCell == emailLower && email != "" {
-			count++
+		cell := func(col int) string {
+			if len(row) > col && row[col] != nil {
+				return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+			}
+			return ""
 		}
+		rows = append(rows, slotRow{owner: cell(info.OwnerColumn), member: cell(info.MemberColumn)})
 	}
-
-	return count, nil
+	return rows, nil
 }
 
-// GetSlotAvailability returns slot availability for families/workspaces.
-// Reads ALL rows from column D (except header) in the target sheet.
-// product: "ChatGPT" or "Gemini"
+// GetSlotAvailability returns slot availability for families/workspaces/heads.
+// Reads ALL member rows (below the header rows) in the target sheet.
+// product: any registered product with slots ("Gemini", "ChatGPT", "YouTube")
+// maxSlots: slot limit per owner (catalog override or product default)
 // availableOnly: if true, only return items with available slots
-func (r *Repository) GetSlotAvailability(ctx context.Context, product string, availableOnly bool) (*entity.SlotAvailabilityResult, error) {
-	// Validate product
-	if product != "ChatGPT" && product != "Gemini" {
-		return nil, fmt.Errorf("product must be 'ChatGPT' or 'Gemini', got: %s", product)
+func (r *Repository) GetSlotAvailability(ctx context.Context, product string, maxSlots int, availableOnly bool) (*entity.SlotAvailabilityResult, error) {
+	// Validate product and get slot rules from registry
+	info := entity.Product(product).Info()
+	if !info.HasSlots() {
+		return nil, fmt.Errorf("product %q has no slots", product)
 	}
 
-	// Define slot limits per product
-	maxSlots := 5 // Gemini default
-	if product == "ChatGPT" {
-		maxSlots = 4
-	}
-
-	// Read ALL rows from column B, C, and D (except header)
-	// Column C = Email, Column D = Family/Workspace
-	readRange := fmt.Sprintf("'%s'!B:D", product)
-	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
+	rows, err := r.slotRows(entity.Product(product))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read slot area: %w", err)
+		return nil, err
 	}
 
 	// Group by family/workspace name
 	slotCounts := make(map[string]*entity.SlotInfo)
-
-	// Skip header rows (first 2 rows: title and column headers)
-	for i, row := range resp.Values {
-		if i < 2 {
-			continue
-		}
-
-		// Column C (Email) is index 1, Column D (Family) is index 2
-		var email, familyVal string
-		if len(row) > 1 && row[1] != nil {
-			email = strings.TrimSpace(fmt.Sprintf("%v", row[1]))
-		}
-		if len(row) > 2 && row[2] != nil {
-			familyVal = strings.TrimSpace(fmt.Sprintf("%v", row[2]))
-		}
-
-		// Skip empty family values
-		if familyVal == "" {
+	for _, row := range rows {
+		// Skip empty owner values
+		if row.owner == "" {
 			continue
 		}
 
 		// Initialize slot info if not exists
-		if _, exists := slotCounts[familyVal]; !exists {
-			slotCounts[familyVal] = &entity.SlotInfo{
-				Name:       familyVal,
+		if _, exists := slotCounts[row.owner]; !exists {
+			slotCounts[row.owner] = &entity.SlotInfo{
+				Name:       row.owner,
 				Product:    product,
 				TotalSlots: maxSlots,
 			}
 		}
 
-		// Increment used slots if email is filled
-		if email != "" {
-			slotCounts[familyVal].UsedSlots++
+		// Increment used slots if member email is filled
+		if row.member != "" {
+			slotCounts[row.owner].UsedSlots++
 		}
 	}
 
@@ -221,3 +161,88 @@ func (r *Repository) GetSlotAvailability(ctx context.Context, product string, av
 
 	return result, nil
 }
+
+// CountMembersByOwner counts filled member rows per slot owner in a product sheet.
+// Keys are lowercased owner values; rows are read like CountSlots.
+func (r *Repository) CountMembersByOwner(ctx context.Context, product entity.Product) (map[string]int, error) {
+	rows, err := r.slotRows(product)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, row := range rows {
+		if row.owner == "" || !strings.Contains(row.member, "@") {
+			continue
+		}
+		counts[strings.ToLower(row.owner)]++
+	}
+
+	return counts, nil
+}
+
+// ListMembers returns all member rows (email in column C) of a product sheet.
+// Dates and Akun are filled from the product's columns when it has them (see subscriptionLayouts).
+func (r *Repository) ListMembers(ctx context.Context, product entity.Product) ([]entity.WorkspaceMember, error) {
+	sheetName := product.SheetName()
+	readRange := fmt.Sprintf("'%s'!A:J", sheetName)
+	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s sheet: %w", sheetName, err)
+	}
+
+	layout, ok := subscriptionLayouts[product]
+	if !ok {
+		layout = subscriptionColumns{ordered: -1, end: -1, paket: -1, akun: -1}
+	}
+
+	var members []entity.WorkspaceMember
+	for i, row := range resp.Values {
+		cell := func(col int) string {
+			if col >= 0 && len(row) > col && row[col] != nil {
+				return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+			}
+			return ""
+		}
+
+		// Header rows have no email in column C
+		if !strings.Contains(cell(2), "@") {
+			continue
+		}
+		members = append(members, entity.WorkspaceMember{
+			Produk:          product,
+			Row:             i + 1,
+			Nama:            cell(1),
+			Email:           cell(2),
+			Owner:           cell(3),
+			Akun:            cell(layout.akun),
+			TanggalPesanan:  cell(layout.ordered),
+			TanggalBerakhir: cell(layout.end),
+			EndsAt:          parseSheetDate(cell(layout.end)),
+		})
+	}
+
+	return members, nil
+}
+
+// UpdateMemberOwner writes a new owner (column D) for a member row.
+// The row is checked against the customer email first (see findMemberRow).
+func (r *Repository) UpdateMemberOwner(ctx context.Context, product entity.Product, row int, email, owner string) error {
+	sheetName := product.SheetName()
+
+	row, err := r.findMemberRow(sheetName, row, email)
+	if err != nil {
+		return err
+	}
+
+	updateRange := fmt.Sprintf("'%s'!D%d", sheetName, row)
+	_, err = r.service.Spreadsheets.Values.Update(r.spreadsheetID, updateRange, &sheets.ValueRange{
+		Values: [][]interface{}{{owner}},
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return fmt.Errorf("failed to update %s row %d: %w", sheetName, row, err)
+	}
+
+	r.logger.Printf("🔀 Moved %s row %d (%s) to %s", sheetName, row, email, owner)
+	return nil
+}
@@ -0,0 +1,169 @@
+package sheets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"google.golang.org/api/sheets/v4"
+)
+
+// subscriptionColumns maps the member columns of a product sheet (0-indexed, -1 = none).
+// Nama (B), Email (C) and Family/WorkSpace/Email Head (D) are the same for all products.
+type subscriptionColumns struct {
+	ordered int // Tanggal Pesanan
+	end     int // Tanggal Berakhir
+	paket   int // Paket
+	akun    int // Akun/Nomor (customer WA number if filled)
+}
+
+// subscriptionLayouts lists products whose sheet has a Tanggal Berakhir column
+// (see LogOrder for the full column mappings).
+var subscriptionLayouts = map[entity.Product]subscriptionColumns{
+	entity.ProductGemini:  {ordered: 4, end: 5, paket: -1, akun: 8},  // E=Pesanan, F=Berakhir, I=Akun/Nomor
+	entity.ProductChatGPT: {ordered: 5, end: 6, paket: 4, akun: 9},   // E=Paket, F=Pesanan, G=Berakhir, J=Bukti
+	entity.ProductYouTube: {ordered: 4, end: 5, paket: -1, akun: -1}, // E=Pesan, F=Berakhir
+}
+
+// columnLetter converts a 0-indexed column to its letter (A-Z).
+func columnLetter(col int) string {
+	return string(rune('A' + col))
+}
+
+// ListSubscriptions reads all members with a Tanggal Berakhir from the product sheets.
+// Rows without a parseable end date (headers, manual rows) are skipped.
+func (r *Repository) ListSubscriptions(ctx context.Context) ([]entity.Subscription, error) {
+	var subs []entity.Subscription
+
+	for _, product := range entity.AllProducts() {
+		layout, ok := subscriptionLayouts[product]
+		if !ok {
+			continue
+		}
+
+		sheetName := product.SheetName()
+		readRange := fmt.Sprintf("'%s'!A:J", sheetName)
+		resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s sheet: %w", sheetName, err)
+		}
+
+		for i, row := range resp.Values {
+			cell := func(col int) string {
+				if col >= 0 && len(row) > col && row[col] != nil {
+					return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+				}
+				return ""
+			}
+
+			end := parseSheetDate(cell(layout.end))
+			if end.IsZero() || cell(2) == "" {
+				continue
+			}
+
+			subs = append(subs, entity.Subscription{
+				Produk:          product,
+				Row:             i + 1,
+				Nama:            cell(1),
+				Email:           cell(2),
+				Owner:           cell(3),
+				Paket:           cell(layout.paket),
+				Akun:            cell(layout.akun),
+				TanggalBerakhir: end,
+			})
+		}
+	}
+
+	return subs, nil
+}
+
+// ExtendSubscription writes a new Tanggal Berakhir for a member row.
+// The row is checked against the customer email first (see findMemberRow).
+func (r *Repository) ExtendSubscription(ctx context.Context, produk string, row int, email string, end time.Time) error {
+	product, err := entity.ParseProduct(produk)
+	if err != nil {
+		return err
+	}
+	layout, ok := subscriptionLayouts[product]
+	if !ok {
+		return fmt.Errorf("product %s has no Tanggal Berakhir column", produk)
+	}
+	sheetName := product.SheetName()
+
+	row, err = r.findMemberRow(sheetName, row, email)
+	if err != nil {
+		return err
+	}
+
+	wib := time.FixedZone("WIB", 7*60*60)
+	updateRange := fmt.Sprintf("'%s'!%s%d", sheetName, columnLetter(layout.end), row)
+	_, err = r.service.Spreadsheets.Values.Update(r.spreadsheetID, updateRange, &sheets.ValueRange{
+		Values: [][]interface{}{{end.In(wib).Format("2006-01-02")}},
+	}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return fmt.Errorf("failed to extend subscription at %s row %d: %w", sheetName, row, err)
+	}
+
+	r.logger.Printf("📅 Extended %s row %d (%s) until %s", sheetName, row, email, end.In(wib).Format("2006-01-02"))
+	return nil
+}
+
+// findMemberRow checks a member row against the customer email (column C).
+// If rows were moved, the last row with that email is returned instead.
+func (r *Repository) findMemberRow(sheetName string, row int, email string) (int, error) {
+	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, fmt.Sprintf("'%s'!C:C", sheetName)).Do()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s sheet: %w", sheetName, err)
+	}
+	emailAt := func(rowNum int) string {
+		if rowNum < 1 || rowNum > len(resp.Values) || len(resp.Values[rowNum-1]) == 0 {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", resp.Values[rowNum-1][0]))
+	}
+
+	if strings.EqualFold(emailAt(row), email) {
+		return row, nil
+	}
+	for i := len(resp.Values); i >= 1; i-- {
+		if strings.EqualFold(emailAt(i), email) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("member %s not found in %s sheet", email, sheetName)
+}
+
+// endDateRequest builds the update for the Tanggal Berakhir cell of a new order row.
+// Returns nil if the product has no end date column or the order has no end date.
+func endDateRequest(sheetID, rowIndex int64, order *entity.Order) *sheets.Request {
+	product, err := entity.ParseProduct(order.Produk)
+	if err != nil || order.TanggalBerakhir.IsZero() {
+		return nil
+	}
+	layout, ok := subscriptionLayouts[product]
+	if !ok {
+		return nil
+	}
+
+	wib := time.FixedZone("WIB", 7*60*60)
+	berakhir := order.TanggalBerakhir.In(wib).Format("2006-01-02")
+	return &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:     sheetID,
+				RowIndex:    rowIndex,
+				ColumnIndex: int64(layout.end),
+			},
+			Rows: []*sheets.RowData{
+				{
+					Values: []*sheets.CellData{
+						{UserEnteredValue: &sheets.ExtendedValue{StringValue: &berakhir}},
+					},
+				},
+			},
+			Fields: "userEnteredValue",
+		},
+	}
+}
@@ -0,0 +1,183 @@
+package sheets
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	voucherSheet      = "Voucher"
+	voucherUsageSheet = "Pemakaian Voucher"
+)
+
+// GetVouchers reads all vouchers from the Voucher sheet.
+// Columns: A=Kode, B=Tipe (persen/potongan), C=Nilai, D=Produk (comma-separated, empty = semua),
+// E=Mulai, F=Sampai, G=Kuota, H=Terpakai, I=Maks/Customer
+// Rows with empty Kode or invalid Tipe/Nilai are skipped.
+func (r *Repository) GetVouchers(ctx context.Context) ([]entity.Voucher, error) {
+	// Read from Voucher sheet (skip header row 1)
+	readRange := fmt.Sprintf("'%s'!A2:I", voucherSheet)
+	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s sheet: %w", voucherSheet, err)
+	}
+
+	vouchers := make([]entity.Voucher, 0, len(resp.Values))
+	for i, row := range resp.Values {
+		cell := func(col int) string {
+			if len(row) > col && row[col] != nil {
+				return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+			}
+			return ""
+		}
+
+		if cell(0) == "" {
+			continue
+		}
+		rowNum := i + 2
+
+		v := entity.Voucher{
+			Kode: strings.ToUpper(cell(0)),
+			Row:  rowNum,
+		}
+
+		nilai := cell(2)
+		switch strings.ToLower(cell(1)) {
+		case "persen", "percent", "%":
+			v.Tipe = entity.VoucherPercent
+		case "potongan", "fixed", "rp":
+			v.Tipe = entity.VoucherFixed
+		case "":
+			// Infer from value: "10%" = persen, otherwise potongan
+			if strings.HasSuffix(nilai, "%") {
+				v.Tipe = entity.VoucherPercent
+			} else {
+				v.Tipe = entity.VoucherFixed
+			}
+		default:
+			r.logger.Printf("⚠️ Voucher row %d skipped: tipe '%s' tidak valid", rowNum, cell(1))
+			continue
+		}
+
+		v.Nilai, err = parser.ParseRupiah(strings.TrimSuffix(nilai, "%"))
+		if err != nil || (v.Tipe == entity.VoucherPercent && v.Nilai > 100) {
+			r.logger.Printf("⚠️ Voucher row %d skipped: nilai '%s' tidak valid", rowNum, nilai)
+			continue
+		}
+
+		if scope := cell(3); scope != "" && !strings.EqualFold(scope, "semua") {
+			for _, name := range strings.Split(scope, ",") {
+				product, err := entity.ParseProduct(name)
+				if err != nil {
+					r.logger.Printf("⚠️ Voucher row %d: %v", rowNum, err)
+					continue
+				}
+				v.Produk = append(v.Produk, product)
+			}
+			if len(v.Produk) == 0 {
+				continue // Scope set but no valid product: never applicable
+			}
+		}
+
+		// Optional columns: ignore blanks/invalid values (no limit)
+		v.Mulai = parseSheetDate(cell(4))
+		v.Sampai = parseSheetDate(cell(5))
+		v.Kuota, _ = strconv.Atoi(cell(6))
+		v.Terpakai, _ = strconv.Atoi(cell(7))
+		v.MaxPerCustomer, _ = strconv.Atoi(cell(8))
+
+		vouchers = append(vouchers, v)
+	}
+
+	return vouchers, nil
+}
+
+// CountVoucherUsage counts confirmed uses of a voucher by a customer email.
+// Reads from "Pemakaian Voucher" sheet.
+// Columns: A=Tanggal, B=Kode, C=Email, D=Produk, E=Nama, F=Nominal, G=Potongan
+func (r *Repository) CountVoucherUsage(ctx context.Context, kode, email string) (int, error) {
+	readRange := fmt.Sprintf("'%s'!B2:C", voucherUsageSheet)
+	resp, err := r.service.Spreadsheets.Values.Get(r.spreadsheetID, readRange).Do()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s sheet: %w", voucherUsageSheet, err)
+	}
+
+	count := 0
+	for _, row := range resp.Values {
+		if len(row) < 2 {
+			continue
+		}
+		rowKode := strings.TrimSpace(fmt.Sprintf("%v", row[0]))
+		rowEmail := strings.TrimSpace(fmt.Sprintf("%v", row[1]))
+		if strings.EqualFold(rowKode, kode) && strings.EqualFold(rowEmail, email) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// RecordVoucherUsage appends a usage row to "Pemakaian Voucher" and increments
+// Terpakai (column H) on the Voucher sheet. Updates are serialized so concurrent
+// confirmations never lose a count.
+func (r *Repository) RecordVoucherUsage(ctx context.Context, usage *entity.VoucherUsage) error {
+	r.voucherMu.Lock()
+	defer r.voucherMu.Unlock()
+
+	wib := time.FixedZone("WIB", 7*60*60)
+	tanggal := usage.Tanggal.In(wib).Format("2006-01-02")
+
+	appendRange := fmt.Sprintf("'%s'!A:G", voucherUsageSheet)
+	_, err := r.service.Spreadsheets.Values.Append(r.spreadsheetID, appendRange, &sheets.ValueRange{
+		Values: [][]interface{}{{
+			tanggal, usage.Kode, usage.Email, usage.Produk, usage.Nama, usage.Amount, usage.Discount,
+		}},
+	}).ValueInputOption("USER_ENTERED").InsertDataOption("INSERT_ROWS").Do()
+	if err != nil {
+		return fmt.Errorf("failed to record voucher usage: %w", err)
+	}
+
+	vouchers, err := r.GetVouchers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, v := range vouchers {
+		if !v.Matches(usage.Kode) {
+			continue
+		}
+		// H: Terpakai (row number is 1-indexed)
+		updateRange := fmt.Sprintf("'%s'!H%d", voucherSheet, v.Row)
+		_, err = r.service.Spreadsheets.Values.Update(r.spreadsheetID, updateRange, &sheets.ValueRange{
+			Values: [][]interface{}{{v.Terpakai + 1}},
+		}).ValueInputOption("USER_ENTERED").Do()
+		if err != nil {
+			return fmt.Errorf("failed to update voucher usage at row %d: %w", v.Row, err)
+		}
+		r.logger.Printf("🎟️ Voucher %s used by %s (%d/%d)", v.Kode, usage.Email, v.Terpakai+1, v.Kuota)
+		return nil
+	}
+
+	return fmt.Errorf("voucher %s not found in %s sheet", usage.Kode, voucherSheet)
+}
+
+// parseSheetDate parses a date cell (YYYY-MM-DD or DD/MM/YYYY or DD-MM-YYYY) as WIB midnight.
+// Returns zero time for empty or unrecognized values.
+func parseSheetDate(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	wib := time.FixedZone("WIB", 7*60*60)
+	for _, layout := range []string{"2006-01-02", "02/01/2006", "02-01-2006"} {
+		if t, err := time.ParseInLocation(layout, s, wib); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -11,10 +11,87 @@ const (
 	DefaultKanal = "Threads"
 )
 
-// Family plan constants.
+// Catalog constants.
 const (
-	MaxFamilySlots    = 5 // Gemini family (max 5 members)
-	MaxWorkspaceSlots = 4 // ChatGPT workspace (max 4 members)
+	PriceTolerancePercent = 10 // Default accepted deviation from list price when Min/Max not set
+	CatalogCacheMinutes   = 5  // How long the price list is cached before reloading
+)
+
+// Unique amount constants (payments are matched by amount only).
+const (
+	UniqueCodeMax            = 99 // Auto-filled nominals get +0..N rupiah so no two unpaid QRIS share an amount
+	AmountReservationMinutes = 5  // A reserved amount is released if no QRIS is registered in time
+)
+
+// Renewal reminder constants.
+const (
+	RenewalReminderDays = 3 // Remind customers this many days before Tanggal Berakhir
+	RenewalReminderHour = 9 // Hour of day (WIB) the reminder job runs
+)
+
+// Account monitor constants.
+const (
+	AccountMonitorHours   = 6 // Scan Akun Google / Akun ChatGPT every N hours
+	AccountExpiryWarnDays = 7 // Warn when an account ends within N days
+)
+
+// Account list constants.
+const (
+	ListAkunPageSize = 20 // Accounts per #listakun page
+)
+
+// Message constants.
+const (
+	MaxMessageLength = 4000 // Longer replies are split across messages (characters)
+)
+
+// Member migration constants.
+const (
+	MigrationPlanMinutes = 15 // How long a #pindah plan waits for "#pindah ok"
+)
+
+// Redeem code constants.
+const (
+	RedeemLowStockThreshold = 5 // Alert the group when available codes fall below this
+)
+
+// Bulk import constants.
+const (
+	ImportMaxFileBytes     = 2 << 20 // Documents larger than this (2 MB) are not downloaded
+	ImportMaxRows          = 500     // Max data rows per #import file
+	ImportPreviewMinutes   = 15      // How long an #import preview waits for "#import ok"
+	ImportMaxInvalidListed = 20      // Invalid rows listed in the preview (rest are counted)
+)
+
+// Order wizard constants.
+const (
+	OrderWizardMinutes = 10 // An #order conversation ends after this long without an answer
+)
+
+// Incoming message constants.
+const (
+	ProcessedMessageHours    = 24 // Handled message IDs are remembered this long; redeliveries are skipped
+	MessageEditWindowMinutes = 15 // WhatsApp only allows editing a message for this long
+)
+
+// WhatsApp connection supervisor constants.
+const (
+	ReconnectBaseSeconds    = 2   // First reconnect delay, doubled after each failed attempt
+	ReconnectMaxSeconds     = 120 // Upper bound of the reconnect delay
+	ConnectionAlertAttempts = 5   // Alert via the fallback channel after N failed reconnects
+	AlertTimeoutSeconds     = 10  // Timeout for fallback alert delivery (webhook / SMTP)
+)
+
+// Outbound message queue constants.
+const (
+	OutboundQueueMax           = 200  // Messages waiting to be sent (all chats)
+	OutboundMaxAgeMinutes      = 10   // Queued messages older than this are dropped, not sent late
+	OutboundBurst              = 5    // Messages sent back-to-back before the rate limit applies
+	OutboundIntervalMillis     = 1000 // Then at most one message per interval (all chats)
+	OutboundMaxAttempts        = 5    // Send attempts for transient errors before giving up
+	OutboundRetryBaseSeconds   = 2    // First retry delay, doubled after each failed attempt
+	OutboundRetryMaxSeconds    = 60   // Upper bound of the retry delay
+	OutboundSendTimeoutSeconds = 30   // Timeout of a single send attempt
 )
 
 // Time constants.
@@ -39,4 +116,12 @@ const (
 	LogPrefixConfirmation = "[CONFIRMATION] "
 	LogPrefixQRIS         = "[QRIS] "
 	LogPrefixBot          = "[BOT] "
+	LogPrefixRenewal      = "[RENEWAL] "
+	LogPrefixMonitor      = "[MONITOR] "
+	LogPrefixMigration    = "[MIGRATION] "
+	LogPrefixImport       = "[IMPORT] "
+	LogPrefixRedeem       = "[REDEEM] "
+	LogPrefixAlert        = "[ALERT] "
+	LogPrefixRole         = "[ROLE] "
+	LogPrefixOrder        = "[ORDER] "
 )
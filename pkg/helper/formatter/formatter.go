@@ -4,6 +4,7 @@ package formatter
 import (
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 var digitOnlyRegex = regexp.MustCompile(`\D`)
@@ -86,6 +87,37 @@ func FormatPhone(phone string) string {
 	return phone
 }
 
+// MaskPhone hides the middle digits of a phone number, keeping the first 4 and last 3.
+// Examples:
+//   - "08123456789" → "0812****789"
+//   - "" → ""
+func MaskPhone(phone string) string {
+	digits := digitOnlyRegex.ReplaceAllString(phone, "")
+	if len(digits) <= 7 {
+		return strings.Repeat("*", len(digits))
+	}
+	return digits[:4] + strings.Repeat("*", len(digits)-7) + digits[len(digits)-3:]
+}
+
+// Email formatting functions.
+
+// MaskEmail hides the local part of an email, keeping its first 2 characters.
+// Examples:
+//   - "budi.santoso@gmail.com" → "bu***@gmail.com"
+//   - "ab@gmail.com" → "a***@gmail.com"
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return strings.Repeat("*", len([]rune(email)))
+	}
+	local := []rune(email[:at])
+	keep := 2
+	if len(local) <= keep {
+		keep = len(local) / 2
+	}
+	return string(local[:keep]) + "***" + email[at:]
+}
+
 // Currency formatting functions.
 
 // FormatRupiah formats integer amount to Rupiah currency string.
@@ -128,3 +160,35 @@ func TruncateWithEllipsis(text string, maxLen int) string {
 	}
 	return string(runes[:maxLen-3]) + "..."
 }
+
+// SplitMessage splits text into parts of at most maxLen characters, breaking
+// between lines so list entries stay intact. Lines longer than maxLen are cut.
+// Uses rune counting like TruncateWithEllipsis (emoji never split).
+func SplitMessage(text string, maxLen int) []string {
+	if maxLen <= 0 || len([]rune(text)) <= maxLen {
+		return []string{text}
+	}
+
+	var parts []string
+	var cur []rune
+	flush := func() {
+		if s := strings.TrimRight(string(cur), "\n"); s != "" {
+			parts = append(parts, s)
+		}
+		cur = cur[:0]
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		runes := []rune(line)
+		if len(cur)+len(runes) > maxLen {
+			flush()
+		}
+		for len(runes) > maxLen {
+			parts = append(parts, string(runes[:maxLen]))
+			runes = runes[maxLen:]
+		}
+		cur = append(cur, runes...)
+	}
+	flush()
+	return parts
+}
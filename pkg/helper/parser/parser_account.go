@@ -3,9 +3,13 @@ package parser
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"github.com/exernia/botjanweb/pkg/helper/validator"
 )
 
 // ParseAddAkunCommand parses a #addakun command string.
@@ -55,6 +59,8 @@ func parseAddAkunFormFormat(text string, accountType ProductParam) (*entity.AddA
 		AccountType: string(accountType),
 	}
 
+	result := &entity.ValidationResult{}
+
 	// Set Tipe based on parameter
 	switch accountType {
 	case ProductParamGoogle:
@@ -82,7 +88,8 @@ func parseAddAkunFormFormat(text string, accountType ProductParam) (*entity.AddA
 		case fieldTipe:
 			accType, ok := entity.ParseAccountType(value)
 			if !ok {
-				return nil, fmt.Errorf("tipe akun tidak valid: '%s'. Pilihan: Google atau ChatGPT", value)
+				result.Addf("Tipe", "tipe akun tidak valid: '%s'. Pilihan: Google atau ChatGPT", value)
+				continue
 			}
 			cmd.Tipe = accType
 		case fieldEmail:
@@ -94,58 +101,292 @@ func parseAddAkunFormFormat(text string, accountType ProductParam) (*entity.AddA
 		}
 	}
 
-	// Validate required fields
-	if cmd.Tipe == "" {
-		return nil, fmt.Errorf("field 'Tipe' wajib diisi. Pilihan: Google atau ChatGPT")
+	validateAddAkun(cmd, result)
+	if err := result.Err(); err != nil {
+		return nil, err
 	}
-	if cmd.Email == "" {
-		return nil, fmt.Errorf("field 'Email' wajib diisi")
+
+	return cmd, nil
+}
+
+// ValidateAddAkunCommand checks the #addakun fields (also used for each row
+// of #import akun). All field errors are returned as *entity.ValidationResult.
+func ValidateAddAkunCommand(cmd *entity.AddAkunCommand) error {
+	result := &entity.ValidationResult{}
+	validateAddAkun(cmd, result)
+	return result.Err()
+}
+
+// validateAddAkun adds the errors of an #addakun form to result.
+func validateAddAkun(cmd *entity.AddAkunCommand, result *entity.ValidationResult) {
+	if cmd.Tipe == "" && !result.Has("Tipe") {
+		result.Add("Tipe", "wajib diisi. Pilihan: Google atau ChatGPT")
+	}
+	switch {
+	case cmd.Email == "":
+		result.Add("Email", "wajib diisi")
+	case !validator.ValidateEmail(cmd.Email):
+		result.Addf("Email", "format email tidak valid: '%s'", cmd.Email)
 	}
 	if cmd.Sandi == "" {
-		return nil, fmt.Errorf("field 'Sandi' wajib diisi")
+		result.Add("Sandi", "wajib diisi")
 	}
 
 	// Workspace is required for ChatGPT
 	if cmd.Tipe == entity.AccountTypeChatGPT && cmd.Workspace == "" {
-		return nil, fmt.Errorf("field 'Workspace' wajib diisi untuk akun ChatGPT")
+		result.Add("Workspace", "wajib diisi untuk akun ChatGPT")
 	}
+}
 
-	return cmd, nil
+// ValidateInputKodeCommand checks the #inputkode fields (also used for each
+// row of #import kode). All field errors are returned as *entity.ValidationResult.
+func ValidateInputKodeCommand(cmd *entity.InputKodeCommand) error {
+	result := &entity.ValidationResult{}
+	switch {
+	case cmd.Email == "":
+		result.Add("Email", "wajib diisi")
+	case !validator.ValidateEmail(cmd.Email):
+		result.Addf("Email", "format email tidak valid: '%s'", cmd.Email)
+	}
+	if cmd.KodeRedeem == "" {
+		result.Add("Kode", "wajib diisi")
+	}
+	return result.Err()
+}
+
+// listAkunFilters maps #listakun parameters to account state filters.
+var listAkunFilters = map[string]entity.AccountFilter{
+	"tersedia": entity.AccountFilterAvailable,
+	"kekunci":  entity.AccountFilterLocked,
+	"terkunci": entity.AccountFilterLocked,
+	"banned":   entity.AccountFilterBanned,
+	"berakhir": entity.AccountFilterExpiring,
 }
 
 // ParseListAkunCommand parses a #listakun command string.
-// Supports:
-// - #listakun → Show all accounts summary
-// - #listakun google → Show only Google accounts
-// - #listakun chatgpt → Show only ChatGPT accounts
+// Supports (parameters in any order, all optional):
+// - google | chatgpt → account type
+// - tersedia | kekunci | banned | berakhir → account state
+// - family <nama> / workspace <nama> → family or workspace name contains
+// - cari <teks> → email contains
+// - urut → sort by Tanggal Berakhir (soonest first)
+// - hal <n> → page number
+//
+// Example: #listakun google tersedia urut hal 2
 func ParseListAkunCommand(text string) (*entity.ListAkunCommand, error) {
 	text = strings.TrimSpace(text)
-	if !strings.HasPrefix(strings.ToLower(text), "#listakun") {
+	if !strings.HasPrefix(strings.ToLower(text), entity.CmdListAkun) {
 		return nil, fmt.Errorf("not a #listakun command")
 	}
 
-	rest := strings.TrimSpace(text[9:])
-	if rest == "" {
-		// No filter, show all
-		return &entity.ListAkunCommand{}, nil
+	args := strings.Fields(text[len(entity.CmdListAkun):])
+	cmd := &entity.ListAkunCommand{Page: 1}
+
+	for i := 0; i < len(args); i++ {
+		arg := strings.ToLower(args[i])
+
+		// Keywords followed by a value
+		switch arg {
+		case "family", "workspace", "cari", "hal":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("'%s' perlu diisi, contoh: #listakun %s %s", arg, arg, listAkunExample(arg))
+			}
+			i++
+			value := args[i]
+			switch arg {
+			case "family", "workspace":
+				cmd.Group = value
+			case "cari":
+				cmd.Search = value
+			case "hal":
+				page, err := strconv.Atoi(value)
+				if err != nil || page < 1 {
+					return nil, fmt.Errorf("nomor halaman tidak valid: '%s'", value)
+				}
+				cmd.Page = page
+			}
+			continue
+		}
+
+		if filter, ok := listAkunFilters[arg]; ok {
+			cmd.Filter = filter
+			continue
+		}
+		if tipe, ok := entity.ParseAccountType(arg); ok {
+			cmd.Tipe = tipe
+			cmd.AccountType = arg
+			continue
+		}
+		if arg == "urut" {
+			cmd.SortByExpiry = true
+			continue
+		}
+
+		// Unknown parameter, show help
+		return &entity.ListAkunCommand{IsHelpMode: true}, nil
 	}
 
-	// Check for account type filter
-	firstLine := strings.ToLower(strings.TrimSpace(rest))
+	return cmd, nil
+}
 
-	cmd := &entity.ListAkunCommand{
-		AccountType: firstLine,
+// listAkunExample returns an example value for a #listakun keyword.
+func listAkunExample(keyword string) string {
+	switch keyword {
+	case "hal":
+		return "2"
+	case "cari":
+		return "gmail"
 	}
+	return "family01"
+}
 
-	switch firstLine {
-	case "google":
-		cmd.Tipe = entity.AccountTypeGoogle
-	case "chatgpt":
-		cmd.Tipe = entity.AccountTypeChatGPT
+// ParsePindahCommand parses a #pindah command string.
+// Supports:
+// - #pindah → Show help with banned workspaces
+// - #pindah <owner email / workspace> → Propose a migration plan
+// - #pindah ok → Apply the pending plan
+// - #pindah batal → Drop the pending plan
+func ParsePindahCommand(text string) (*entity.PindahCommand, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(strings.ToLower(text), entity.CmdPindah) {
+		return nil, fmt.Errorf("not a #pindah command")
+	}
+
+	rest := strings.TrimSpace(text[len(entity.CmdPindah):])
+	switch strings.ToLower(rest) {
+	case "":
+		return &entity.PindahCommand{IsHelpMode: true}, nil
+	case "ok", "ya", "yes":
+		return &entity.PindahCommand{Confirm: true}, nil
+	case "batal", "cancel":
+		return &entity.PindahCommand{Cancel: true}, nil
+	}
+
+	return &entity.PindahCommand{Workspace: rest}, nil
+}
+
+// ParseSandiCommand parses a #sandi command string.
+// Format: #sandi <email> → reveal the password of an account privately
+func ParseSandiCommand(text string) (*entity.SandiCommand, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(strings.ToLower(text), entity.CmdSandi) {
+		return nil, fmt.Errorf("not a #sandi command")
+	}
+
+	email := strings.TrimSpace(text[len(entity.CmdSandi):])
+	if email == "" {
+		return &entity.SandiCommand{IsHelpMode: true}, nil
+	}
+	if !validator.ValidateEmail(email) {
+		return nil, fmt.Errorf("email tidak valid: '%s'", email)
+	}
+
+	return &entity.SandiCommand{Email: email}, nil
+}
+
+// ParseKodeCommand parses a #kode command string.
+// Supports:
+// - #kode → Show help
+// - #kode pakai <kode> <email customer> → Mark used today
+// - #kode tanggal <kode> <aktivasi> [berakhir] → Set dates (YYYY-MM-DD)
+// - #kode invalid <kode> [alasan] → Flag invalid
+// - #kode lepas <kode> → Release back to stock
+func ParseKodeCommand(text string) (*entity.KodeCommand, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(strings.ToLower(text), entity.CmdKode) {
+		return nil, fmt.Errorf("not a #kode command")
+	}
+
+	args := strings.Fields(text[len(entity.CmdKode):])
+	if len(args) < 2 {
+		return &entity.KodeCommand{IsHelpMode: true}, nil
+	}
+
+	cmd := &entity.KodeCommand{Action: entity.KodeAction(strings.ToLower(args[0])), Kode: args[1]}
+	rest := args[2:]
+	wib := time.FixedZone("WIB", 7*60*60)
+
+	switch cmd.Action {
+	case entity.KodePakai:
+		if len(rest) != 1 {
+			return &entity.KodeCommand{IsHelpMode: true}, nil
+		}
+		if !validator.ValidateEmail(rest[0]) {
+			return nil, fmt.Errorf("email customer tidak valid: '%s'", rest[0])
+		}
+		cmd.Customer = rest[0]
+	case entity.KodeTanggal:
+		if len(rest) < 1 || len(rest) > 2 {
+			return &entity.KodeCommand{IsHelpMode: true}, nil
+		}
+		var err error
+		if cmd.Activated, err = time.ParseInLocation("2006-01-02", rest[0], wib); err != nil {
+			return nil, fmt.Errorf("tanggal aktivasi tidak valid: '%s' (format: YYYY-MM-DD)", rest[0])
+		}
+		if len(rest) == 2 {
+			if cmd.Ends, err = time.ParseInLocation("2006-01-02", rest[1], wib); err != nil {
+				return nil, fmt.Errorf("tanggal berakhir tidak valid: '%s' (format: YYYY-MM-DD)", rest[1])
+			}
+			if !cmd.Ends.After(cmd.Activated) {
+				return nil, fmt.Errorf("tanggal berakhir harus setelah tanggal aktivasi")
+			}
+		}
+	case entity.KodeInvalid:
+		cmd.Reason = strings.Join(rest, " ")
+	case entity.KodeLepas:
+		if len(rest) != 0 {
+			return &entity.KodeCommand{IsHelpMode: true}, nil
+		}
 	default:
-		// Unknown filter, show help
-		cmd.IsHelpMode = true
+		return &entity.KodeCommand{IsHelpMode: true}, nil
+	}
+
+	return cmd, nil
+}
+
+// ParseRoleCommand parses the #role command.
+// Formats:
+//   - #role                        → list assignments
+//   - #role beri <nomor> <role>    → grant a role
+//   - #role hapus <nomor>          → revoke (back to the default role)
+func ParseRoleCommand(text string) (*entity.RoleCommand, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(strings.ToLower(text), entity.CmdRole) {
+		return nil, fmt.Errorf("not a #role command")
 	}
 
+	args := strings.Fields(text[len(entity.CmdRole):])
+	if len(args) == 0 {
+		return &entity.RoleCommand{Action: entity.RoleList}, nil
+	}
+
+	cmd := &entity.RoleCommand{Action: entity.RoleAction(strings.ToLower(args[0]))}
+	rest := args[1:]
+
+	switch cmd.Action {
+	case entity.RoleGrant:
+		if len(rest) < 2 {
+			return &entity.RoleCommand{IsHelpMode: true}, nil
+		}
+		role, err := entity.ParseRole(rest[len(rest)-1])
+		if err != nil {
+			return nil, err
+		}
+		cmd.Role = role
+		rest = rest[:len(rest)-1]
+	case entity.RoleRevoke:
+		if len(rest) == 0 {
+			return &entity.RoleCommand{IsHelpMode: true}, nil
+		}
+	default:
+		return &entity.RoleCommand{IsHelpMode: true}, nil
+	}
+
+	// Phone may be written with spaces/dashes (e.g. "+62 812-3456-7890")
+	phone := strings.Join(rest, "")
+	if !validator.ValidatePhone(phone) {
+		return nil, fmt.Errorf("nomor tidak valid: '%s'", strings.Join(rest, " "))
+	}
+	cmd.Phone = formatter.NormalizePhone(phone)
 	return cmd, nil
 }
@@ -6,23 +6,19 @@ package parser
 // ============================================================================
 
 // Form field names (case-insensitive).
+// Product order form fields (nama, family, head, ...) are declared per product
+// in the registry (entity.Products) and resolved via entity.LookupFormField.
 const (
 	fieldProduk    = "produk"
-	fieldNama      = "nama"
 	fieldEmail     = "email"
-	fieldFamily    = "family"
-	fieldNominal   = "nominal"
-	fieldKanal     = "kanal"
-	fieldAkun      = "akun"
 	fieldWorkspace = "workspace"
-	fieldPaket     = "paket"
 	fieldTipe      = "tipe"
 	fieldSandi     = "sandi"
 	fieldUntuk     = "untuk" // Target phone for self-QRIS
 )
 
-// ProductParam represents the product/account type from command parameter.
-// Used for both #qris and #addakun commands.
+// ProductParam represents the account type from #addakun command parameter.
+// #qris product parameters come from the product registry (entity.ProductByParam).
 type ProductParam string
 
 const (
@@ -0,0 +1,124 @@
+// Package parser provides command and form parsing utilities.
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// exportKinds maps #export parameters to export kinds.
+var exportKinds = map[string]entity.ExportKind{
+	"orders":  entity.ExportOrders,
+	"order":   entity.ExportOrders,
+	"pesanan": entity.ExportOrders,
+	"slots":   entity.ExportSlots,
+	"slot":    entity.ExportSlots,
+	"kode":    entity.ExportKode,
+	"akun":    entity.ExportAkun,
+}
+
+// ParseExportCommand parses an #export command string.
+// Supports:
+// - #export → Show help
+// - #export orders [produk] [range] [xlsx] → Orders by Tanggal Pesanan
+// - #export slots [produk] [tersedia] [xlsx] → Slot availability
+// - #export kode [tersedia] [xlsx] → Perplexity redeem codes
+// - #export akun [google|chatgpt] [xlsx] → Akun Google / Akun ChatGPT
+//
+// Range: 2026-10 (month), 2026-10-05 (day), 2026-10-01..2026-10-15, or 7h (last 7 days).
+func ParseExportCommand(text string, now time.Time) (*entity.ExportCommand, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(strings.ToLower(text), entity.CmdExport) {
+		return nil, fmt.Errorf("not a #export command")
+	}
+
+	args := strings.Fields(strings.ToLower(text[len(entity.CmdExport):]))
+	if len(args) == 0 {
+		return &entity.ExportCommand{IsHelpMode: true}, nil
+	}
+	kind, ok := exportKinds[args[0]]
+	if !ok {
+		return &entity.ExportCommand{IsHelpMode: true}, nil
+	}
+
+	cmd := &entity.ExportCommand{Kind: kind, Format: entity.ExportFormatCSV}
+	for _, arg := range args[1:] {
+		switch {
+		case arg == entity.ExportFormatCSV || arg == entity.ExportFormatXLSX:
+			cmd.Format = arg
+		case arg == "tersedia" && (kind == entity.ExportSlots || kind == entity.ExportKode):
+			cmd.AvailableOnly = true
+		case kind == entity.ExportAkun:
+			tipe, ok := entity.ParseAccountType(arg)
+			if !ok {
+				return nil, fmt.Errorf("tipe akun tidak dikenal: '%s'. Pilihan: google atau chatgpt", arg)
+			}
+			cmd.AccountType = tipe
+		case kind == entity.ExportOrders || kind == entity.ExportSlots:
+			if info, ok := entity.ProductByParam(arg); ok {
+				cmd.Product = info.Key
+				continue
+			}
+			if kind == entity.ExportSlots {
+				return nil, fmt.Errorf("produk tidak dikenal: '%s'", arg)
+			}
+			r, err := ParseDateRange(arg, now)
+			if err != nil {
+				return nil, err
+			}
+			cmd.Range = r
+		default:
+			return nil, fmt.Errorf("parameter tidak dikenal: '%s'", arg)
+		}
+	}
+
+	return cmd, nil
+}
+
+// ParseDateRange parses a period in WIB:
+//   - 2026-10 → the whole month
+//   - 2026-10-05 → that day
+//   - 2026-10-01..2026-10-15 → both ends inclusive (each end may be a month or a day)
+//   - 7h → the last 7 days including today
+func ParseDateRange(s string, now time.Time) (entity.DateRange, error) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	invalid := fmt.Errorf("periode tidak valid: '%s'. Contoh: 2026-10, 2026-10-05, 2026-10-01..2026-10-15 atau 7h", s)
+
+	if days, ok := strings.CutSuffix(s, "h"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return entity.DateRange{}, invalid
+		}
+		y, m, d := now.In(wib).Date()
+		today := time.Date(y, m, d, 0, 0, 0, 0, wib)
+		return entity.DateRange{From: today.AddDate(0, 0, 1-n), To: today.AddDate(0, 0, 1), Label: s}, nil
+	}
+
+	first, last, isRange := strings.Cut(s, "..")
+	from, to, err := parsePeriod(first, wib)
+	if err != nil {
+		return entity.DateRange{}, invalid
+	}
+	if isRange {
+		if _, to, err = parsePeriod(last, wib); err != nil || !to.After(from) {
+			return entity.DateRange{}, invalid
+		}
+	}
+	return entity.DateRange{From: from, To: to, Label: s}, nil
+}
+
+// parsePeriod parses "YYYY-MM" or "YYYY-MM-DD" into [start, end) in loc.
+func parsePeriod(s string, loc *time.Location) (time.Time, time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	t, err := time.ParseInLocation("2006-01", s, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return t, t.AddDate(0, 1, 0), nil
+}
@@ -0,0 +1,102 @@
+// Package parser provides command and form parsing utilities.
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// compactLabel lowercases a form label and drops everything but letters and
+// digits, so "E-mail", "No. HP" and "Nominal " match "email", "nohp" and "nominal".
+func compactLabel(label string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(label) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// lookupFormField resolves an order form label to its field key. Labels match
+// field keys, labels and aliases ignoring case and punctuation; otherwise the
+// closest name within a small edit distance wins ("emial", "nominl"), unless
+// two different fields are equally close.
+func lookupFormField(label string) (string, bool) {
+	if key, ok := entity.LookupFormField(label); ok {
+		return key, true
+	}
+
+	label = compactLabel(label)
+	if label == "" {
+		return "", false
+	}
+
+	names := entity.FormFieldNames()
+	for name, key := range names {
+		if compactLabel(name) == label {
+			return key, true
+		}
+	}
+
+	limit := typoLimit(label)
+	if limit == 0 {
+		return "", false
+	}
+	best, bestKey, ambiguous := limit+1, "", false
+	for name, key := range names {
+		d := editDistance(label, compactLabel(name))
+		switch {
+		case d < best:
+			best, bestKey, ambiguous = d, key, false
+		case d == best && key != bestKey:
+			ambiguous = true
+		}
+	}
+	if bestKey == "" || ambiguous {
+		return "", false
+	}
+	return bestKey, true
+}
+
+// typoLimit returns how many typos a label of this length may contain.
+// Short labels must match exactly to avoid false hits.
+func typoLimit(label string) int {
+	switch n := len([]rune(label)); {
+	case n < 3:
+		return 0
+	case n < 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the edit distance between a and b, counting an
+// insertion, deletion, substitution or swap of two neighbours ("emial") as one.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -1,17 +1,13 @@
 // Package parser provides command and form parsing utilities.
 package parser
 
-import (
-	"strings"
-)
+import "strings"
 
 // isFormFormat checks if text contains form format (has field: value pattern).
 func isFormFormat(text string) bool {
+	// Non-order fields; product form fields are resolved from the registry
 	formFields := map[string]bool{
-		fieldProduk: true, fieldNama: true, fieldEmail: true,
-		fieldFamily: true, fieldNominal: true, fieldKanal: true,
-		fieldAkun: true, fieldWorkspace: true, fieldPaket: true,
-		fieldTipe: true, fieldSandi: true, fieldUntuk: true,
+		fieldProduk: true, fieldTipe: true, fieldSandi: true, fieldUntuk: true,
 	}
 
 	lines := strings.Split(text, "\n")
@@ -25,6 +21,9 @@ func isFormFormat(text string) bool {
 			if formFields[field] {
 				return true
 			}
+			if _, ok := lookupFormField(field); ok {
+				return true
+			}
 		}
 	}
 	return false
@@ -0,0 +1,142 @@
+// Package parser provides command and form parsing utilities.
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/tabular"
+)
+
+// ParseImportCommand parses an #import command string (document caption).
+// Supports:
+// - #import → Show help
+// - #import akun google / akun chatgpt / kode → Preview the attached file
+// - #import ok → Append the previewed rows
+// - #import batal → Drop the preview
+func ParseImportCommand(text string) (*entity.ImportCommand, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(strings.ToLower(text), entity.CmdImport) {
+		return nil, fmt.Errorf("not a #import command")
+	}
+
+	rest := strings.ToLower(strings.Join(strings.Fields(text[len(entity.CmdImport):]), " "))
+	switch rest {
+	case "ok", "ya", "yes":
+		return &entity.ImportCommand{Confirm: true}, nil
+	case "batal", "cancel":
+		return &entity.ImportCommand{Cancel: true}, nil
+	case "akun google", "google":
+		return &entity.ImportCommand{Kind: entity.ImportAkunGoogle}, nil
+	case "akun chatgpt", "chatgpt":
+		return &entity.ImportCommand{Kind: entity.ImportAkunChatGPT}, nil
+	case "kode", "kode perplexity", "perplexity":
+		return &entity.ImportCommand{Kind: entity.ImportKode}, nil
+	}
+
+	return &entity.ImportCommand{IsHelpMode: true}, nil
+}
+
+// Import column keys.
+const (
+	importColEmail     = "email"
+	importColSandi     = "sandi"
+	importColWorkspace = "workspace"
+	importColKode      = "kode"
+)
+
+// importHeaderAliases maps header cells (lowercase) to column keys.
+var importHeaderAliases = map[string]string{
+	"email":       importColEmail,
+	"e-mail":      importColEmail,
+	"sandi":       importColSandi,
+	"password":    importColSandi,
+	"workspace":   importColWorkspace,
+	"kode":        importColKode,
+	"kode redeem": importColKode,
+	"kode_redeem": importColKode,
+	"code":        importColKode,
+}
+
+// importDefaultColumns is the column order used when the file has no header row.
+var importDefaultColumns = map[entity.ImportKind][]string{
+	entity.ImportAkunGoogle:  {importColEmail, importColSandi},
+	entity.ImportAkunChatGPT: {importColEmail, importColSandi, importColWorkspace},
+	entity.ImportKode:        {importColEmail, importColKode},
+}
+
+// ParseImportRows converts file rows into import rows, validating each with the
+// same rules as #addakun / #inputkode. A first row containing an "Email" cell
+// is treated as header (columns in any order); otherwise the default column
+// order for the kind is used.
+func ParseImportRows(kind entity.ImportKind, rows []tabular.Row) []entity.ImportRow {
+	columns := importHeader(rows)
+	if columns != nil {
+		rows = rows[1:]
+	} else {
+		columns = make(map[string]int)
+		for i, key := range importDefaultColumns[kind] {
+			columns[key] = i
+		}
+	}
+
+	result := make([]entity.ImportRow, 0, len(rows))
+	for _, row := range rows {
+		cell := func(key string) string {
+			if col, ok := columns[key]; ok {
+				return row.Cell(col)
+			}
+			return ""
+		}
+
+		item := entity.ImportRow{Number: row.Number}
+		var err error
+		switch kind {
+		case entity.ImportKode:
+			item.Code = &entity.InputKodeCommand{
+				Email:      cell(importColEmail),
+				KodeRedeem: cell(importColKode),
+			}
+			err = ValidateInputKodeCommand(item.Code)
+		default:
+			tipe := entity.AccountTypeGoogle
+			if kind == entity.ImportAkunChatGPT {
+				tipe = entity.AccountTypeChatGPT
+			}
+			item.Account = &entity.AddAkunCommand{
+				Tipe:      tipe,
+				Email:     cell(importColEmail),
+				Sandi:     cell(importColSandi),
+				Workspace: cell(importColWorkspace),
+			}
+			err = ValidateAddAkunCommand(item.Account)
+		}
+
+		if err != nil {
+			item.Error = err.Error()
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// importHeader returns the column index per key if the first row is a header, or nil.
+func importHeader(rows []tabular.Row) map[string]int {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columns := make(map[string]int)
+	for i, c := range rows[0].Cells {
+		if key, ok := importHeaderAliases[strings.ToLower(c)]; ok {
+			if _, dup := columns[key]; !dup {
+				columns[key] = i
+			}
+		}
+	}
+	if _, ok := columns[importColEmail]; !ok {
+		return nil
+	}
+	return columns
+}
@@ -0,0 +1,72 @@
+// Package parser provides command and form parsing utilities.
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/validator"
+)
+
+// ParseOrderCommand parses a #order command.
+// Format: #order | #order <produk> | #order batal
+func ParseOrderCommand(text string) (*entity.OrderCommand, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(strings.ToLower(text), entity.CmdOrder) {
+		return nil, fmt.Errorf("not a #order command")
+	}
+
+	arg := strings.TrimSpace(text[len(entity.CmdOrder):])
+	if arg == "" {
+		return &entity.OrderCommand{}, nil
+	}
+	if strings.EqualFold(arg, entity.OrderReplyCancel) {
+		return &entity.OrderCommand{Cancel: true}, nil
+	}
+
+	p, err := ParseOrderProduct(arg)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.OrderCommand{Produk: string(p)}, nil
+}
+
+// ParseOrderProduct parses the product answer of the #order wizard: a #qris
+// parameter ("google"), a product name ("Gemini") or its number in the list.
+func ParseOrderProduct(answer string) (entity.Product, error) {
+	answer = strings.TrimSpace(answer)
+	products := entity.AllProducts()
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n < 1 || n > len(products) {
+			return "", fmt.Errorf("pilih nomor 1-%d", len(products))
+		}
+		return products[n-1], nil
+	}
+	if info, ok := entity.ProductByParam(answer); ok {
+		return info.Key, nil
+	}
+	return entity.ParseProduct(answer)
+}
+
+// ParseOrderAnswer checks a wizard answer for one form field and stores it in cmd.
+// The value must not be empty; skipping optional fields is handled by the caller.
+func ParseOrderAnswer(cmd *entity.QrisCommand, field entity.FormField, value string) error {
+	value = strings.TrimSpace(value)
+	switch field.Key {
+	case entity.FieldNominal:
+		amount, err := ParseRupiah(value)
+		if err != nil {
+			return err
+		}
+		cmd.Amount = amount
+		return nil
+	case entity.FieldEmail:
+		if !validator.ValidateEmail(value) {
+			return fmt.Errorf("format email tidak valid: %s", value)
+		}
+	}
+	cmd.SetField(field.Key, value)
+	return nil
+}
@@ -9,26 +9,58 @@ import (
 	"github.com/exernia/botjanweb/pkg/helper/formatter"
 )
 
+// rupiahUnits are shorthand suffixes for thousands and millions, longest first.
+var rupiahUnits = []struct {
+	suffix string
+	factor float64
+}{
+	{"ribu", 1e3}, {"juta", 1e6}, {"rb", 1e3}, {"jt", 1e6}, {"k", 1e3},
+}
+
 // ParseRupiah parses Rupiah string to integer amount.
-// Handles formats: "Rp 10.000", "10000", "10.000", etc.
+// Handles formats: "Rp 10.000", "10000", "10.000", "Rp 10.000,-", shorthand
+// like "50rb" / "50k" / "1,5jt", etc.
 // Examples:
 //   - "Rp 10.000" → 10000
 //   - "10.000" → 10000
-//   - "10000" → 10000
+//   - "Rp 50.000,-" / "50.000,00" → 50000
+//   - "50rb" / "50 ribu" / "50k" → 50000
+//   - "1,5jt" → 1500000
 func ParseRupiah(str string) (int, error) {
-	// Remove "Rp" prefix and whitespace
-	str = strings.TrimSpace(str)
-	str = strings.TrimPrefix(str, "Rp")
-	str = strings.TrimSpace(str)
+	original := strings.TrimSpace(str)
 
-	// Remove thousand separators
+	// Remove "Rp"/"IDR" prefix, whitespace and a trailing ",-" / ".-"
+	str = strings.ToLower(original)
+	str = strings.TrimPrefix(str, "rp")
+	str = strings.TrimPrefix(str, "idr")
+	str = strings.ReplaceAll(str, " ", "")
+	str = strings.TrimPrefix(str, ".")
+	str = strings.TrimSuffix(str, "-")
+	str = strings.TrimRight(str, ".,")
+
+	// Shorthand: 50rb, 50k, 1,5jt (comma or dot as decimal separator)
+	for _, unit := range rupiahUnits {
+		if number, ok := strings.CutSuffix(str, unit.suffix); ok && number != "" {
+			value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", "."), 64)
+			if err != nil || value <= 0 {
+				return 0, fmt.Errorf("nominal tidak valid: %s", original)
+			}
+			return int(value*unit.factor + 0.5), nil
+		}
+	}
+
+	// Drop zero cents ("50.000,00"), then thousand separators
+	if len(str) > 3 && strings.ContainsAny(str[:len(str)-3], ".,") &&
+		(strings.HasSuffix(str, ",00") || strings.HasSuffix(str, ".00")) {
+		str = str[:len(str)-3]
+	}
 	str = strings.ReplaceAll(str, ".", "")
 	str = strings.ReplaceAll(str, ",", "")
 
 	// Parse to integer
 	amount, err := strconv.Atoi(str)
 	if err != nil {
-		return 0, fmt.Errorf("nominal tidak valid: %s", str)
+		return 0, fmt.Errorf("nominal tidak valid: %s", original)
 	}
 
 	if amount <= 0 {
@@ -3,19 +3,18 @@ package parser
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/validator"
 )
 
 // ParseQrisCommand parses a #qris command string for group orders.
+// Products and their form fields come from the product registry (entity.Products).
 // Supports:
 // - #qris → Show general help
-// - #qris google → Show Gemini form template
-// - #qris chatgpt → Show ChatGPT form template
-// - #qris google\n<form data> → Process Gemini order
-// - #qris chatgpt\n<form data> → Process ChatGPT order
+// - #qris <param> → Show product form template (e.g. #qris google, #qris youtube)
+// - #qris <param>\n<form data> → Process product order
 // - Legacy: #qris 25000 desc (self-QRIS only, not for group)
 func ParseQrisCommand(text string, defaultKanal string) (*entity.QrisCommand, error) {
 	text = strings.TrimSpace(text)
@@ -29,27 +28,18 @@ func ParseQrisCommand(text string, defaultKanal string) (*entity.QrisCommand, er
 	}
 
 	// Check for product parameter (first word)
-	productType := ProductParamUnknown
+	var product entity.Product
 	lines := strings.SplitN(rest, "\n", 2)
-	firstLine := strings.ToLower(strings.TrimSpace(lines[0]))
-
-	switch firstLine {
-	case "google":
-		productType = ProductParamGoogle
+	if info, ok := entity.ProductByParam(lines[0]); ok {
+		product = info.Key
 		if len(lines) == 1 || strings.TrimSpace(lines[1]) == "" || !isFormFormat(lines[1]) {
-			return &entity.QrisCommand{IsHelpMode: true, ProductType: string(productType)}, nil
-		}
-		rest = lines[1]
-	case "chatgpt":
-		productType = ProductParamChatGPT
-		if len(lines) == 1 || strings.TrimSpace(lines[1]) == "" || !isFormFormat(lines[1]) {
-			return &entity.QrisCommand{IsHelpMode: true, ProductType: string(productType)}, nil
+			return &entity.QrisCommand{IsHelpMode: true, ProductType: info.Param}, nil
 		}
 		rest = lines[1]
 	}
 
 	if isFormFormat(rest) {
-		return parseQrisFormFormat(rest, productType, defaultKanal)
+		return parseQrisFormFormat(rest, product, defaultKanal)
 	}
 
 	// Legacy format: #qris <amount> <description>
@@ -57,20 +47,15 @@ func ParseQrisCommand(text string, defaultKanal string) (*entity.QrisCommand, er
 }
 
 // parseQrisFormFormat parses the form-based format for group orders.
-func parseQrisFormFormat(text string, productType ProductParam, defaultKanal string) (*entity.QrisCommand, error) {
+// product may be empty when the form carries a legacy "Produk:" line instead.
+// All field errors are returned together as *entity.ValidationResult.
+func parseQrisFormFormat(text string, product entity.Product, defaultKanal string) (*entity.QrisCommand, error) {
 	cmd := &entity.QrisCommand{
-		IsFormMode:  true,
-		ProductType: string(productType),
-		Kanal:       defaultKanal,
-	}
-
-	// Set product based on productType parameter
-	switch productType {
-	case ProductParamGoogle:
-		cmd.Produk = string(entity.ProductGemini)
-	case ProductParamChatGPT:
-		cmd.Produk = string(entity.ProductChatGPT)
+		IsFormMode: true,
+		Produk:     string(product),
+		Kanal:      defaultKanal,
 	}
+	result := &entity.ValidationResult{}
 
 	lines := strings.Split(text, "\n")
 	for _, line := range lines {
@@ -87,85 +72,99 @@ func parseQrisFormFormat(text string, productType ProductParam, defaultKanal str
 		field := strings.ToLower(strings.TrimSpace(parts[0]))
 		value := strings.TrimSpace(parts[1])
 
-		switch field {
-		case fieldProduk:
+		if compactLabel(field) == fieldProduk {
 			// Legacy: Allow override via Produk field
-			product, err := entity.ParseProduct(value)
+			p, err := entity.ParseProduct(value)
 			if err != nil {
-				return nil, err
+				result.Add("Produk", err.Error())
+				continue
 			}
-			cmd.Produk = string(product)
-		case fieldNama:
-			cmd.Nama = value
-		case fieldEmail:
-			cmd.Email = value
-		case fieldFamily:
-			cmd.Family = value
-		case fieldWorkspace:
-			cmd.Workspace = value
-		case fieldPaket:
-			cmd.Paket = value
-		case fieldNominal:
-			// Remove thousands separator
-			amountStr := strings.ReplaceAll(value, ".", "")
-			amountStr = strings.ReplaceAll(amountStr, ",", "")
-			amount, err := strconv.Atoi(amountStr)
-			if err != nil {
-				return nil, fmt.Errorf("nominal tidak valid: %s", value)
+			cmd.Produk = string(p)
+			continue
+		}
+
+		key, ok := lookupFormField(field)
+		if !ok {
+			cmd.Unknown = append(cmd.Unknown, strings.TrimSpace(parts[0]))
+			continue
+		}
+
+		if key == entity.FieldNominal {
+			// Empty nominal: auto-filled from the price catalog
+			if value == "" {
+				continue
 			}
-			if amount <= 0 {
-				return nil, fmt.Errorf("nominal harus lebih dari 0")
+			amount, err := ParseRupiah(value)
+			if err != nil {
+				result.Add("Nominal", err.Error())
+				continue
 			}
 			cmd.Amount = amount
-		case fieldKanal:
-			if value != "" {
-				cmd.Kanal = value
-			}
-		case fieldAkun:
-			if value != "" {
-				cmd.Akun = value
-			}
+			continue
 		}
+		cmd.SetField(key, value)
 	}
 
-	// Validate required fields
+	validateQrisForm(cmd, result)
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// CompleteQrisForm checks the fields of a form order and fills ProductType
+// and Deskripsi. Used by form messages and the #order wizard.
+// All field errors are returned together as *entity.ValidationResult.
+func CompleteQrisForm(cmd *entity.QrisCommand) error {
+	result := &entity.ValidationResult{}
+	validateQrisForm(cmd, result)
+	return result.Err()
+}
+
+// validateQrisForm adds the errors of a form order to result: product, required
+// fields and email format. Unrecognized labels are listed when something failed,
+// since they are often a misspelt required field.
+func validateQrisForm(cmd *entity.QrisCommand, result *entity.ValidationResult) {
 	if cmd.Produk == "" {
-		return nil, fmt.Errorf("field 'Produk' wajib diisi. Pilihan: ChatGPT, Gemini, YouTube, Perplexity")
+		if !result.Has("Produk") {
+			result.Addf("Produk", "wajib diisi. Pilihan: %s", productKeyList())
+		}
+		return
 	}
-	if cmd.Nama == "" {
-		return nil, fmt.Errorf("field 'Nama' wajib diisi")
+	info := entity.Product(cmd.Produk).Info()
+	cmd.ProductType = info.Param
+	for _, f := range info.Fields {
+		if f.Required && cmd.Field(f.Key) == "" && !result.Has(f.Label) {
+			result.Add(f.Label, "wajib diisi")
+		}
 	}
-	if cmd.Email == "" {
-		return nil, fmt.Errorf("field 'Email' wajib diisi")
+	if cmd.Email != "" && !validator.ValidateEmail(cmd.Email) {
+		result.Addf("Email", "format email tidak valid: '%s'", cmd.Email)
 	}
-	if cmd.Amount <= 0 {
-		return nil, fmt.Errorf("field 'Nominal' wajib diisi dengan nilai positif")
+	if !result.Valid() {
+		for _, label := range cmd.Unknown {
+			result.Add(label, "label tidak dikenali")
+		}
+		return
 	}
+	// Nominal is optional here: Amount == 0 means "use catalog price" (resolved by handler)
 
-	// Product-specific validation and description building
-	switch productType {
-	case ProductParamGoogle:
-		if cmd.Family == "" {
-			return nil, fmt.Errorf("field 'Family' wajib diisi untuk produk Gemini")
-		}
-		cmd.Deskripsi = fmt.Sprintf("%s - %s (%s)", cmd.Produk, cmd.Nama, cmd.Family)
-	case ProductParamChatGPT:
-		if cmd.Workspace == "" {
-			return nil, fmt.Errorf("field 'Workspace' wajib diisi untuk produk ChatGPT")
-		}
-		if cmd.Paket == "" {
-			return nil, fmt.Errorf("field 'Paket' wajib diisi untuk produk ChatGPT")
-		}
-		cmd.Deskripsi = fmt.Sprintf("%s - %s (%s)", cmd.Produk, cmd.Nama, cmd.Workspace)
-	default:
-		// Other products require Family
-		if cmd.Family == "" {
-			return nil, fmt.Errorf("field 'Family' wajib diisi")
-		}
-		cmd.Deskripsi = fmt.Sprintf("%s - %s (%s)", cmd.Produk, cmd.Nama, cmd.Family)
+	// Description: "<Produk> - <Nama>" plus slot owner if the product has one
+	if owner := cmd.SlotOwner(); owner != "" {
+		cmd.Deskripsi = fmt.Sprintf("%s - %s (%s)", cmd.Produk, cmd.Nama, owner)
+	} else {
+		cmd.Deskripsi = fmt.Sprintf("%s - %s", cmd.Produk, cmd.Nama)
 	}
+}
 
-	return cmd, nil
+// productKeyList returns registered product keys for error messages.
+func productKeyList() string {
+	products := entity.AllProducts()
+	keys := make([]string, 0, len(products))
+	for _, p := range products {
+		keys = append(keys, string(p))
+	}
+	return strings.Join(keys, ", ")
 }
 
 // parseQrisLegacyFormat parses the old format: #qris <amount> <description>
@@ -21,7 +21,7 @@ import (
 //	untuk: 08123456789
 //
 // Required fields: amount (from first line), produk
-// Optional fields: nama, email, family, workspace, paket, kanal, untuk/ke/target
+// Optional fields: nama, email, family, workspace, head, paket, kanal, untuk/ke/target
 func ParseSelfQrisCommand(text string) (*entity.QrisCommand, error) {
 	text = strings.TrimSpace(text)
 	if !strings.HasPrefix(strings.ToLower(text), "#qris") {
@@ -75,24 +75,6 @@ func ParseSelfQrisCommand(text string) (*entity.QrisCommand, error) {
 			if err == nil {
 				cmd.Produk = string(product)
 			}
-		case fieldNama:
-			cmd.Nama = value
-		case fieldEmail:
-			cmd.Email = value
-		case fieldFamily:
-			cmd.Family = value
-		case fieldWorkspace:
-			cmd.Workspace = value
-		case fieldPaket:
-			cmd.Paket = value
-		case fieldKanal:
-			if value != "" {
-				cmd.Kanal = value
-			}
-		case fieldAkun:
-			if value != "" {
-				cmd.Akun = value
-			}
 		case fieldUntuk, "ke", "target", "phone", "nomor":
 			// Normalize phone number: remove spaces, dashes, leading +
 			value = strings.ReplaceAll(value, " ", "")
@@ -107,6 +89,11 @@ func ParseSelfQrisCommand(text string) (*entity.QrisCommand, error) {
 			if value != "" {
 				cmd.Deskripsi = value
 			}
+		default:
+			// Product form fields (nama, email, family, workspace, head, ...) from registry
+			if key, ok := lookupFormField(field); ok && key != entity.FieldNominal {
+				cmd.SetField(key, value)
+			}
 		}
 	}
 
@@ -117,10 +104,8 @@ func ParseSelfQrisCommand(text string) (*entity.QrisCommand, error) {
 			parts = append(parts, cmd.Produk)
 		}
 		parts = append(parts, cmd.Nama)
-		if cmd.Family != "" {
-			parts = append(parts, "("+cmd.Family+")")
-		} else if cmd.Workspace != "" {
-			parts = append(parts, "("+cmd.Workspace+")")
+		if owner := cmd.SlotOwner(); owner != "" {
+			parts = append(parts, "("+owner+")")
 		}
 		cmd.Deskripsi = strings.Join(parts, " - ")
 	}
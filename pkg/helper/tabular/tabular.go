@@ -0,0 +1,241 @@
+// Package tabular reads and writes rows as CSV and XLSX documents (first worksheet only).
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxXMLBytes caps the decompressed size of one XLSX part, so a small
+// zip bomb can't exhaust memory.
+const maxXMLBytes = 32 << 20
+
+// Row is a non-empty row of a document with trimmed cells.
+type Row struct {
+	Number int      // 1-based row number in the document
+	Cells  []string // Cell values, trimmed
+}
+
+// Cell returns the trimmed value at col, or "" if the row is shorter.
+func (r Row) Cell(col int) string {
+	if col < 0 || col >= len(r.Cells) {
+		return ""
+	}
+	return r.Cells[col]
+}
+
+// Read parses a CSV or XLSX document into rows of trimmed cells.
+// The format is picked from the file name extension; empty rows are dropped.
+func Read(fileName string, data []byte) ([]Row, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv", ".txt":
+		return ReadCSV(data)
+	case ".xlsx":
+		return ReadXLSX(data)
+	}
+	return nil, fmt.Errorf("format file tidak didukung: %s (gunakan .csv atau .xlsx)", fileName)
+}
+
+// ReadCSV parses CSV data. The delimiter (comma or semicolon) is detected
+// from the first line, since spreadsheet exports in id-ID locale use ';'.
+func ReadCSV(data []byte) ([]Row, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var rows []Row
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca CSV: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		rows = appendRow(rows, line, record)
+	}
+	return rows, nil
+}
+
+// xlsxSharedStrings is xl/sharedStrings.xml.
+type xlsxSharedStrings struct {
+	Items []struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+// xlsxSheet is xl/worksheets/sheetN.xml.
+type xlsxSheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline struct {
+				Text string `xml:"t"`
+			} `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX parses the first worksheet of an XLSX file.
+// Only cell values are read (formulas use their cached result, no styles/dates).
+func ReadXLSX(data []byte) ([]Row, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca XLSX: %w", err)
+	}
+
+	var shared []string
+	if f := findZipFile(zr, "xl/sharedStrings.xml"); f != nil {
+		var ss xlsxSharedStrings
+		if err := decodeZipXML(f, &ss); err != nil {
+			return nil, fmt.Errorf("gagal membaca XLSX: %w", err)
+		}
+		for _, item := range ss.Items {
+			text := item.Text
+			for _, run := range item.Runs {
+				text += run.Text
+			}
+			shared = append(shared, text)
+		}
+	}
+
+	sheetFile := findZipFile(zr, "xl/worksheets/sheet1.xml")
+	if sheetFile == nil {
+		// Fall back to the first worksheet in the archive
+		for _, f := range zr.File {
+			if strings.HasPrefix(f.Name, "xl/worksheets/sheet") && strings.HasSuffix(f.Name, ".xml") {
+				sheetFile = f
+				break
+			}
+		}
+	}
+	if sheetFile == nil {
+		return nil, fmt.Errorf("gagal membaca XLSX: worksheet tidak ditemukan")
+	}
+
+	var sheet xlsxSheet
+	if err := decodeZipXML(sheetFile, &sheet); err != nil {
+		return nil, fmt.Errorf("gagal membaca XLSX: %w", err)
+	}
+
+	var rows []Row
+	for n, row := range sheet.Rows {
+		var cells []string
+		for i, c := range row.Cells {
+			col := columnIndex(c.Ref)
+			if col < 0 {
+				col = i
+			}
+			for len(cells) < col {
+				cells = append(cells, "")
+			}
+
+			value := c.Value
+			switch c.Type {
+			case "s":
+				if idx, err := strconv.Atoi(c.Value); err == nil && idx >= 0 && idx < len(shared) {
+					value = shared[idx]
+				}
+			case "inlineStr":
+				value = c.Inline.Text
+			}
+			cells = append(cells, value)
+		}
+
+		number := row.Number
+		if number == 0 {
+			number = n + 1
+		}
+		rows = appendRow(rows, number, cells)
+	}
+	return rows, nil
+}
+
+// findZipFile returns the archive entry with the given name, or nil.
+func findZipFile(zr *zip.Reader, name string) *zip.File {
+	for _, f := range zr.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// decodeZipXML decodes an XML archive entry into v.
+// Entries larger than maxXMLBytes are rejected; the declared size is checked
+// first and the read is capped too, since the header can lie.
+func decodeZipXML(f *zip.File, v interface{}) error {
+	if f.UncompressedSize64 > maxXMLBytes {
+		return fmt.Errorf("%s terlalu besar", f.Name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	body, err := io.ReadAll(io.LimitReader(rc, maxXMLBytes+1))
+	if err != nil {
+		return err
+	}
+	if len(body) > maxXMLBytes {
+		return fmt.Errorf("%s terlalu besar", f.Name)
+	}
+	return xml.Unmarshal(body, v)
+}
+
+// columnIndex converts a cell reference ("C7") to a 0-indexed column (2), or -1.
+func columnIndex(ref string) int {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return -1
+	}
+	return col - 1
+}
+
+// appendRow trims the cells and appends them as a row, unless every cell is empty.
+func appendRow(rows []Row, number int, cells []string) []Row {
+	empty := true
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+		if cells[i] != "" {
+			empty = false
+		}
+	}
+	if empty {
+		return rows
+	}
+	return append(rows, Row{Number: number, Cells: cells})
+}
@@ -0,0 +1,127 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildXLSX zips the given parts into an XLSX archive.
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadXLSX(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/sharedStrings.xml": `<sst>
+			<si><t>Email</t></si>
+			<si><r><t>Sa</t></r><r><t>ndi</t></r></si>
+			<si><t>a@x.com</t></si>
+		</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2" t="inlineStr"><is><t> rahasia </t></is></c></row>
+			<row r="3"><c r="A3"><v> </v></c></row>
+			<row r="5"><c r="B5"><v>42</v></c></row>
+		</sheetData></worksheet>`,
+	})
+
+	rows, err := ReadXLSX(data)
+	if err != nil {
+		t.Fatalf("ReadXLSX: %v", err)
+	}
+	want := []Row{
+		{Number: 1, Cells: []string{"Email", "Sandi"}},
+		{Number: 2, Cells: []string{"a@x.com", "", "rahasia"}},
+		{Number: 5, Cells: []string{"", "42"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %#v, want %#v", rows, want)
+	}
+}
+
+func TestReadXLSXFallbackSheet(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/worksheets/sheet2.xml": `<worksheet><sheetData>
+			<row><c><v>a</v></c><c><v>b</v></c></row>
+		</sheetData></worksheet>`,
+	})
+
+	rows, err := ReadXLSX(data)
+	if err != nil {
+		t.Fatalf("ReadXLSX: %v", err)
+	}
+	want := []Row{{Number: 1, Cells: []string{"a", "b"}}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %#v, want %#v", rows, want)
+	}
+}
+
+func TestReadXLSXRejectsOversizePart(t *testing.T) {
+	huge := `<worksheet><sheetData>` + strings.Repeat(" ", maxXMLBytes) + `</sheetData></worksheet>`
+	data := buildXLSX(t, map[string]string{"xl/worksheets/sheet1.xml": huge})
+
+	if _, err := ReadXLSX(data); err == nil || !strings.Contains(err.Error(), "terlalu besar") {
+		t.Errorf("err = %v, want size error", err)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []Row
+	}{
+		{
+			name: "comma",
+			data: "Email,Kode\na@x.com, K1\n",
+			want: []Row{
+				{Number: 1, Cells: []string{"Email", "Kode"}},
+				{Number: 2, Cells: []string{"a@x.com", "K1"}},
+			},
+		},
+		{
+			name: "semicolon with BOM and empty rows",
+			data: "\xef\xbb\xbfEmail;Sandi\n;\na@x.com;p,w\n\nb@x.com\n",
+			want: []Row{
+				{Number: 1, Cells: []string{"Email", "Sandi"}},
+				{Number: 3, Cells: []string{"a@x.com", "p,w"}},
+				{Number: 5, Cells: []string{"b@x.com"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ReadCSV([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ReadCSV: %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("rows = %#v, want %#v", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadUnsupportedFormat(t *testing.T) {
+	if _, err := Read("data.xls", nil); err == nil {
+		t.Error("expected error for .xls")
+	}
+}
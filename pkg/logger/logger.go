@@ -19,6 +19,14 @@ var (
 	Confirmation = log.New(os.Stdout, constants.LogPrefixConfirmation, log.LstdFlags)
 	QRIS         = log.New(os.Stdout, constants.LogPrefixQRIS, log.LstdFlags)
 	Bot          = log.New(os.Stdout, constants.LogPrefixBot, log.LstdFlags)
+	Renewal      = log.New(os.Stdout, constants.LogPrefixRenewal, log.LstdFlags)
+	Monitor      = log.New(os.Stdout, constants.LogPrefixMonitor, log.LstdFlags)
+	Migration    = log.New(os.Stdout, constants.LogPrefixMigration, log.LstdFlags)
+	Import       = log.New(os.Stdout, constants.LogPrefixImport, log.LstdFlags)
+	Redeem       = log.New(os.Stdout, constants.LogPrefixRedeem, log.LstdFlags)
+	Alert        = log.New(os.Stdout, constants.LogPrefixAlert, log.LstdFlags)
+	Role         = log.New(os.Stdout, constants.LogPrefixRole, log.LstdFlags)
+	Order        = log.New(os.Stdout, constants.LogPrefixOrder, log.LstdFlags)
 )
 
 // New creates a new logger with the given prefix.
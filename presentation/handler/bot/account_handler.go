@@ -3,22 +3,35 @@ package bot
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
+	"github.com/exernia/botjanweb/internal/domain"
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
 	"github.com/exernia/botjanweb/pkg/helper/parser"
 	"github.com/exernia/botjanweb/presentation/template"
 )
 
 // handleAddAkunCommand processes #addakun commands.
+// Anything but a help request carries form data that may include a password,
+// so the message is revoked from the chat even when the form is invalid.
 func (h *Handler) handleAddAkunCommand(ctx context.Context, msg *entity.Message, text string) {
 	cmd, err := parser.ParseAddAkunCommand(text)
-	if err != nil {
-		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+	if err == nil && cmd.IsHelpMode {
+		h.handleAddAkunHelp(ctx, msg, cmd.AccountType)
 		return
 	}
+	defer h.revokeSensitiveMessage(ctx, msg)
 
-	if cmd.IsHelpMode {
-		h.handleAddAkunHelp(ctx, msg, cmd.AccountType)
+	var result *entity.ValidationResult
+	if errors.As(err, &result) {
+		h.sendErrorReply(ctx, msg, template.BuildValidationErrors("Data akun belum valid", result, "Ketik *#addakun google* atau *#addakun chatgpt* untuk template form."))
+		return
+	}
+	if err != nil {
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
 		return
 	}
 
@@ -50,7 +63,7 @@ func (h *Handler) handleAddAkunHelp(ctx context.Context, msg *entity.Message, ac
 		return
 	}
 
-	if err := h.messaging.SendTextTo(ctx, msg.ChatID, help); err != nil {
+	if err := h.messaging.SendTextTo(ctx, msg.ChatID, template.BuildAddAkunFormHelp(help, h.passwordsEncrypted)); err != nil {
 		h.logger.Printf("Gagal kirim help: %v", err)
 	}
 }
@@ -80,6 +93,79 @@ func (h *Handler) handleAddAkunForm(ctx context.Context, msg *entity.Message, cm
 	h.logger.Printf("Akun %s berhasil ditambahkan: %s", cmd.Tipe, cmd.Email)
 }
 
+// revokeSensitiveMessage deletes a message containing a password for everyone.
+// Revoking another member's message in a group requires the bot to be a group admin.
+func (h *Handler) revokeSensitiveMessage(ctx context.Context, msg *entity.Message) {
+	senderID := msg.SenderID
+	if msg.IsSelfMessage {
+		senderID = ""
+	}
+	err := h.messaging.RevokeMessage(ctx, msg.ChatID, senderID, msg.ID)
+	if errors.Is(err, domain.ErrMessageQueued) {
+		h.logger.Printf("⏳ Hapus pesan berisi sandi %s masih antre", msg.ID)
+		return
+	}
+	if err != nil {
+		h.logger.Printf("⚠️ Gagal hapus pesan berisi sandi (bot perlu jadi admin grup?): %v", err)
+		if err := h.messaging.SendTextTo(ctx, msg.ChatID, "⚠️ Pesan berisi sandi gagal dihapus otomatis. Mohon hapus manual."); err != nil {
+			h.logger.Printf("Gagal kirim peringatan hapus pesan: %v", err)
+		}
+	}
+}
+
+// handleSandiCommand processes #sandi commands.
+// The decrypted password is only sent in a private chat to the requester.
+func (h *Handler) handleSandiCommand(ctx context.Context, msg *entity.Message, text string) {
+	cmd, err := parser.ParseSandiCommand(text)
+	if err != nil {
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+		return
+	}
+	if cmd.IsHelpMode {
+		h.sendErrorReply(ctx, msg, template.SandiHelp)
+		return
+	}
+
+	if h.accountUC == nil {
+		h.sendErrorReply(ctx, msg, "❌ Fitur lihat sandi tidak tersedia. Google Sheets belum dikonfigurasi.")
+		return
+	}
+
+	cred, err := h.accountUC.GetCredential(ctx, cmd.Email)
+	if errors.Is(err, domain.ErrAccountNotFound) {
+		h.sendErrorReply(ctx, msg, "❌ Akun tidak ditemukan di Akun Google / Akun ChatGPT: "+cmd.Email)
+		return
+	}
+	if err != nil {
+		h.logger.Printf("Gagal ambil sandi %s: %v", cmd.Email, err)
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+		return
+	}
+
+	h.logger.Printf("🔐 Sandi %s diminta oleh %s", cred.Email, msg.SenderPhone)
+	err = h.messaging.SendSensitiveTextTo(ctx, privateChatID(msg), template.BuildSandiReveal(cred))
+	if errors.Is(err, domain.ErrMessageQueued) {
+		h.logger.Printf("⏳ Sandi %s masih antre untuk %s", cred.Email, msg.SenderPhone)
+	} else if err != nil {
+		h.logger.Printf("Gagal kirim sandi: %v", err)
+		h.sendErrorReply(ctx, msg, "❌ Gagal kirim sandi lewat chat pribadi.")
+		return
+	}
+
+	if !msg.IsPrivateChat {
+		h.sendErrorReply(ctx, msg, template.SandiSentPrivately)
+	}
+}
+
+// privateChatID returns the private chat of the message sender.
+// Self messages go to the bot's own chat (never to the customer of a private chat).
+func privateChatID(msg *entity.Message) string {
+	if strings.HasSuffix(msg.SenderID, "@lid") && strings.HasPrefix(msg.SenderID, msg.SenderPhone) {
+		return msg.SenderID // LID could not be resolved to a phone number
+	}
+	return msg.SenderPhone + "@s.whatsapp.net"
+}
+
 // handleListAkunCommand processes #listakun commands.
 func (h *Handler) handleListAkunCommand(ctx context.Context, msg *entity.Message, text string) {
 	cmd, err := parser.ParseListAkunCommand(text)
@@ -98,15 +184,17 @@ func (h *Handler) handleListAkunCommand(ctx context.Context, msg *entity.Message
 
 // sendListAkunHelp sends help message for #listakun.
 func (h *Handler) sendListAkunHelp(ctx context.Context, msg *entity.Message) {
-	help := template.ListAkunHelp
+	help := template.BuildListAkunHelp(constants.ListAkunPageSize)
 	if err := h.messaging.SendTextReply(ctx, msg.ChatID, help, msg.ID, msg.SenderID); err != nil {
 		h.logger.Printf("Gagal kirim listakun help: %v", err)
 	}
 }
 
-// sendListAkunResult fetches and sends account list.
+// sendListAkunResult fetches and sends one page of the account list.
+// Long pages are split across several messages.
 func (h *Handler) sendListAkunResult(ctx context.Context, msg *entity.Message, cmd *entity.ListAkunCommand) {
-	h.logger.Printf("📋 ListAkun: filter=%s", cmd.Tipe)
+	h.logger.Printf("📋 ListAkun: tipe=%s filter=%s group=%q cari=%q urut=%v hal=%d",
+		cmd.Tipe, cmd.Filter, cmd.Group, cmd.Search, cmd.SortByExpiry, cmd.Page)
 
 	// Check if account use case is available
 	if h.accountUC == nil {
@@ -114,15 +202,12 @@ func (h *Handler) sendListAkunResult(ctx context.Context, msg *entity.Message, c
 		return
 	}
 
-	result, err := h.accountUC.ListAccounts(ctx, cmd)
+	page, err := h.accountUC.ListAccounts(ctx, cmd, time.Now())
 	if err != nil {
 		h.logger.Printf("Gagal list akun: %v", err)
 		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
 		return
 	}
 
-	response := template.BuildListAkunResult(result, cmd.Tipe)
-	if err := h.messaging.SendTextReply(ctx, msg.ChatID, response, msg.ID, msg.SenderID); err != nil {
-		h.logger.Printf("Gagal kirim hasil list: %v", err)
-	}
+	h.sendLongReply(ctx, msg, template.BuildListAkunResult(page, cmd))
 }
@@ -0,0 +1,64 @@
+// Package bot provides WhatsApp bot message parsing and handling.
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// handleHargaCommand handles the #harga command.
+// Format: #harga [produk]
+// Example: #harga, #harga gemini, #harga chatgpt
+func (h *Handler) handleHargaCommand(ctx context.Context, msg *entity.Message, text string) {
+	if h.catalogUC == nil {
+		h.sendErrorReply(ctx, msg, "❌ Katalog harga belum dikonfigurasi (CATALOG_FILE atau sheet Harga)")
+		return
+	}
+
+	// Parse optional product filter
+	text = strings.TrimPrefix(strings.ToLower(text), entity.CmdHarga)
+	text = strings.TrimSpace(text)
+
+	var filter entity.Product
+	if text != "" {
+		product, ok := parseProductFilter(text)
+		if !ok {
+			h.sendErrorReply(ctx, msg, "❌ Produk tidak dikenal. Pilihan: "+productParamList())
+			return
+		}
+		filter = product
+	}
+
+	items, err := h.catalogUC.List(ctx)
+	if err != nil {
+		h.logger.Printf("❌ Gagal memuat katalog: %v", err)
+		h.sendErrorReply(ctx, msg, "❌ Gagal memuat katalog harga")
+		return
+	}
+
+	h.sendErrorReply(ctx, msg, template.BuildPriceList(items, filter))
+}
+
+// parseProductFilter maps a command parameter (google, gpt, Gemini, ...) to a Product.
+func parseProductFilter(text string) (entity.Product, bool) {
+	if info, ok := entity.ProductByParam(strings.Fields(text)[0]); ok {
+		return info.Key, true
+	}
+	if product, err := entity.ParseProduct(text); err == nil {
+		return product, true
+	}
+	return "", false
+}
+
+// productParamList returns registered product parameters for help/error messages.
+func productParamList() string {
+	products := entity.AllProducts()
+	params := make([]string, 0, len(products))
+	for _, p := range products {
+		params = append(params, p.Info().Param)
+	}
+	return strings.Join(params, ", ")
+}
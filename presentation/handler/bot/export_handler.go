@@ -0,0 +1,57 @@
+// Package bot provides WhatsApp bot message parsing and handling.
+package bot
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// handleExportCommand processes #export commands (send data as a CSV/XLSX document).
+func (h *Handler) handleExportCommand(ctx context.Context, msg *entity.Message, text string) {
+	cmd, err := parser.ParseExportCommand(text, time.Now())
+	if err != nil {
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+		return
+	}
+
+	if cmd.IsHelpMode {
+		h.sendErrorReply(ctx, msg, template.ExportHelp)
+		return
+	}
+
+	if h.exportUC == nil {
+		h.sendErrorReply(ctx, msg, "❌ Fitur export tidak tersedia. Google Sheets belum dikonfigurasi.")
+		return
+	}
+
+	h.logger.Printf("📤 Export: %s (%s)", cmd.Kind, cmd.Format)
+
+	file, err := h.exportUC.Export(ctx, cmd, time.Now())
+	if err != nil {
+		h.logger.Printf("Gagal export %s: %v", cmd.Kind, err)
+		h.sendErrorReply(ctx, msg, "❌ Gagal export data: "+err.Error())
+		return
+	}
+	if file.Rows == 0 {
+		h.sendErrorReply(ctx, msg, template.BuildExportEmpty(cmd))
+		return
+	}
+
+	_, err = h.messaging.SendDocument(ctx, msg.ChatID, file.Data, file.FileName, file.MimeType, template.BuildExportCaption(cmd, file))
+	if errors.Is(err, domain.ErrMessageQueued) {
+		h.logger.Printf("⏳ %s to %s queued, not sent yet", file.FileName, msg.ChatID)
+		return
+	}
+	if err != nil {
+		h.logger.Printf("Gagal kirim file export: %v", err)
+		h.sendErrorReply(ctx, msg, "❌ Gagal mengirim file: "+err.Error())
+		return
+	}
+	h.logger.Printf("📤 Sent %s (%d rows) to %s", file.FileName, file.Rows, msg.ChatID)
+}
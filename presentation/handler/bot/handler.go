@@ -5,61 +5,105 @@ import (
 	"context"
 	"log"
 	"strings"
+	"sync"
 
 	"github.com/exernia/botjanweb/pkg/logger"
 
 	service "github.com/exernia/botjanweb/internal/application/service"
 	accountuc "github.com/exernia/botjanweb/internal/application/service/account"
-	familyuc "github.com/exernia/botjanweb/internal/application/service/family"
+	cataloguc "github.com/exernia/botjanweb/internal/application/service/catalog"
+	duplicateuc "github.com/exernia/botjanweb/internal/application/service/duplicate"
+	exportuc "github.com/exernia/botjanweb/internal/application/service/export"
+	importeruc "github.com/exernia/botjanweb/internal/application/service/importer"
+	migrationuc "github.com/exernia/botjanweb/internal/application/service/migration"
+	orderuc "github.com/exernia/botjanweb/internal/application/service/order"
 	paymentuc "github.com/exernia/botjanweb/internal/application/service/payment"
 	qrisuc "github.com/exernia/botjanweb/internal/application/service/qris"
-	workspaceuc "github.com/exernia/botjanweb/internal/application/service/workspace"
+	redeemuc "github.com/exernia/botjanweb/internal/application/service/redeem"
+	roleuc "github.com/exernia/botjanweb/internal/application/service/role"
+	voucheruc "github.com/exernia/botjanweb/internal/application/service/voucher"
 	"github.com/exernia/botjanweb/internal/domain/entity"
 	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"github.com/exernia/botjanweb/presentation/template"
 )
 
 // Handler processes incoming WhatsApp messages and routes them to appropriate use cases.
 type Handler struct {
-	qrisUC           *qrisuc.UseCase
-	paymentUC        *paymentuc.UseCase
-	familyUC         *familyuc.UseCase
-	workspaceUC      *workspaceuc.UseCase
-	accountUC        *accountuc.UseCase
-	inventoryRepo    service.InventoryPort
-	messaging        service.MessagingPort
-	allowedSenders   []string
-	logger           *log.Logger
-	sheetAkunGoogle  string
-	sheetAkunChatGPT string
-	defaultKanal     string
+	qrisUC             *qrisuc.UseCase
+	paymentUC          *paymentuc.UseCase
+	accountUC          *accountuc.UseCase
+	catalogUC          *cataloguc.UseCase
+	voucherUC          *voucheruc.UseCase
+	migrationUC        *migrationuc.UseCase
+	duplicateUC        *duplicateuc.UseCase
+	importerUC         *importeruc.UseCase
+	exportUC           *exportuc.UseCase
+	redeemUC           *redeemuc.UseCase
+	roleUC             *roleuc.UseCase
+	orderUC            *orderuc.UseCase
+	inventoryRepo      service.InventoryPort
+	slotValidators     map[entity.Product]entity.SlotValidator // Missing product = no slot validation
+	slotCapacity       service.SlotCapacityPort
+	messaging          service.MessagingPort
+	commands           map[string]commandHandler
+	groups             *entity.GroupDirectory
+	logger             *log.Logger
+	sheetAkunGoogle    string
+	sheetAkunChatGPT   string
+	passwordsEncrypted bool // ENCRYPTION_KEY is set (shown in #addakun help)
+
+	// Per #qris message locks, so an edit waits for the original form (see lockForm)
+	formMu    sync.Mutex
+	formLocks map[string]*formLock
 }
 
 // NewHandler creates a new message handler controller.
 func NewHandler(
 	qrisUC *qrisuc.UseCase,
 	paymentUC *paymentuc.UseCase,
-	familyUC *familyuc.UseCase,
-	workspaceUC *workspaceuc.UseCase,
 	accountUC *accountuc.UseCase,
+	catalogUC *cataloguc.UseCase,
+	voucherUC *voucheruc.UseCase,
+	migrationUC *migrationuc.UseCase,
+	duplicateUC *duplicateuc.UseCase,
+	importerUC *importeruc.UseCase,
+	exportUC *exportuc.UseCase,
+	redeemUC *redeemuc.UseCase,
+	roleUC *roleuc.UseCase,
+	orderUC *orderuc.UseCase,
 	inventoryRepo service.InventoryPort,
-	allowedSenders []string,
+	slotValidators map[entity.Product]entity.SlotValidator,
+	slotCapacity service.SlotCapacityPort,
+	groups *entity.GroupDirectory,
 	sheetAkunGoogle string,
 	sheetAkunChatGPT string,
-	defaultKanal string,
+	passwordsEncrypted bool,
 ) *Handler {
-	return &Handler{
-		qrisUC:           qrisUC,
-		paymentUC:        paymentUC,
-		familyUC:         familyUC,
-		workspaceUC:      workspaceUC,
-		accountUC:        accountUC,
-		inventoryRepo:    inventoryRepo,
-		allowedSenders:   allowedSenders,
-		logger:           logger.Bot,
-		sheetAkunGoogle:  sheetAkunGoogle,
-		sheetAkunChatGPT: sheetAkunChatGPT,
-		defaultKanal:     defaultKanal,
+	h := &Handler{
+		qrisUC:             qrisUC,
+		paymentUC:          paymentUC,
+		accountUC:          accountUC,
+		catalogUC:          catalogUC,
+		voucherUC:          voucherUC,
+		migrationUC:        migrationUC,
+		duplicateUC:        duplicateUC,
+		importerUC:         importerUC,
+		exportUC:           exportUC,
+		redeemUC:           redeemUC,
+		roleUC:             roleUC,
+		orderUC:            orderUC,
+		inventoryRepo:      inventoryRepo,
+		slotValidators:     slotValidators,
+		slotCapacity:       slotCapacity,
+		groups:             groups,
+		logger:             logger.Bot,
+		sheetAkunGoogle:    sheetAkunGoogle,
+		sheetAkunChatGPT:   sheetAkunChatGPT,
+		passwordsEncrypted: passwordsEncrypted,
+		formLocks:          make(map[string]*formLock),
 	}
+	h.commands = h.commandHandlers()
+	return h
 }
 
 // SetMessaging sets the messaging service (must be called before HandleMessage).
@@ -69,58 +113,134 @@ func (h *Handler) SetMessaging(m service.MessagingPort) {
 
 // HandleMessage processes an incoming message and dispatches to appropriate handler.
 func (h *Handler) HandleMessage(ctx context.Context, msg *entity.Message) {
-	// For self-messages (bot sending to customer), always allow
-	// For other messages, check if sender is allowed
-	if !msg.IsSelfMessage && !h.isFromAllowedSender(msg.SenderPhone) {
+	// Settings of the chat: its group config, or the global one for private chats
+	group, ok := h.groups.Lookup(msg.ChatID)
+	if !ok {
+		return // Group not listed in GROUPS_FILE
+	}
+
+	text := strings.TrimSpace(msg.Text)
+	if text == "" {
+		return
+	}
+
+	// Route via the command registry (aliases are rewritten to the command name)
+	spec, text, ok := entity.MatchCommand(text)
+	if msg.IsEdit && spec.Name != entity.CmdQris {
+		// Only a corrected #qris form is processed again; other edits are ignored
+		return
+	}
+	if !ok {
+		// Plain text may answer a running #order wizard
+		h.handleOrderAnswer(ctx, msg, group, text)
+		return
+	}
+	cmd := spec.Name
+	handle := h.commands[cmd]
+
+	role, allowed := h.senderRole(ctx, group, msg)
+	if !allowed {
 		h.logger.Printf("🚫 [DEBUG] Sender not allowed: %s (normalized: %s)",
 			msg.SenderPhone, formatter.NormalizePhone(msg.SenderPhone))
 		return
 	}
+	h.logger.Printf("🔍 [DEBUG] Command detected: %q from %s (%s)", text, msg.SenderPhone, role)
+	if handle == nil {
+		h.logger.Printf("⚠️ %s has no handler", cmd)
+		h.sendErrorReply(ctx, msg, template.BuildUnknownCommand(cmd))
+		return
+	}
 
-	text := strings.TrimSpace(msg.Text)
-	if text == "" {
+	if !h.permits(ctx, msg, group, role, cmd) {
 		return
 	}
+	handle(ctx, msg, text)
+}
 
-	// Log command detection
-	lowerText := strings.ToLower(text)
-	if strings.HasPrefix(lowerText, "#") {
-		h.logger.Printf("🔍 [DEBUG] Command detected: %q from %s", text, msg.SenderPhone)
+// permits reports whether cmd is enabled in the chat and allowed for the role,
+// replying with the reason when it isn't.
+func (h *Handler) permits(ctx context.Context, msg *entity.Message, group entity.GroupConfig, role entity.Role, cmd string) bool {
+	if !group.AllowsCommand(cmd) {
+		h.logger.Printf("🚫 %s disabled in group %q", cmd, group.Name)
+		h.sendErrorReply(ctx, msg, template.BuildCommandDisabled(cmd))
+		return false
 	}
+	if !role.Can(cmd) {
+		h.logger.Printf("🚫 %s denied for %s (%s)", cmd, msg.SenderPhone, role)
+		h.sendErrorReply(ctx, msg, template.BuildPermissionDenied(cmd, role))
+		return false
+	}
+	return true
+}
 
-	// Route based on command prefix
-	switch {
-	case strings.HasPrefix(lowerText, "#qris"):
-		h.handleQrisCommand(ctx, msg, text)
-	case strings.HasPrefix(lowerText, "#addakun"):
-		h.handleAddAkunCommand(ctx, msg, text)
-	case strings.HasPrefix(lowerText, "#listakun"):
-		h.handleListAkunCommand(ctx, msg, text)
-	case strings.HasPrefix(lowerText, "#cekslot"):
-		h.handleCekSlotCommand(ctx, msg, text)
-	case strings.HasPrefix(lowerText, "#cekkode"):
-		h.handleCekKodeCommand(ctx, msg, text)
-	case strings.HasPrefix(lowerText, "#inputkode"):
-		h.handleInputKodeCommand(ctx, msg, text)
+// commandHandler handles one command. text starts with the command name.
+type commandHandler func(ctx context.Context, msg *entity.Message, text string)
+
+// commandHandlers binds the command registry (entity.Commands) to handler methods.
+// Registry entries without a handler are answered as unknown commands.
+func (h *Handler) commandHandlers() map[string]commandHandler {
+	handlers := map[string]commandHandler{
+		entity.CmdHelp:      h.handleHelpCommand,
+		entity.CmdQris:      h.handleQrisCommand,
+		entity.CmdAddAkun:   h.handleAddAkunCommand,
+		entity.CmdSandi:     h.handleSandiCommand,
+		entity.CmdListAkun:  h.handleListAkunCommand,
+		entity.CmdCekSlot:   h.handleCekSlotCommand,
+		entity.CmdMember:    h.handleMemberCommand,
+		entity.CmdCekKode:   h.handleCekKodeCommand,
+		entity.CmdInputKode: h.handleInputKodeCommand,
+		entity.CmdKode:      h.handleKodeCommand,
+		entity.CmdHarga:     h.handleHargaCommand,
+		entity.CmdPindah:    h.handlePindahCommand,
+		entity.CmdImport:    h.handleImportCommand,
+		entity.CmdExport:    h.handleExportCommand,
+		entity.CmdRole:      h.handleRoleCommand,
+		entity.CmdOrder:     h.handleOrderCommand,
+	}
+	for _, spec := range entity.Commands {
+		if handlers[spec.Name] == nil {
+			h.logger.Printf("⚠️ Command %s is registered without a handler", spec.Name)
+		}
 	}
+	return handlers
 }
 
-// isFromAllowedSender checks if the sender is allowed to use the bot.
-func (h *Handler) isFromAllowedSender(phone string) bool {
-	// No restriction if empty or wildcard "*"
-	if len(h.allowedSenders) == 0 {
-		return true
+// senderRole returns the sender's role and whether they may use the bot in this chat.
+// Self-messages act as owner. Everyone else must be an allowed sender of the chat,
+// even with an assigned role (OWNER_PHONES or #role); senders without one get the
+// default role.
+func (h *Handler) senderRole(ctx context.Context, group entity.GroupConfig, msg *entity.Message) (entity.Role, bool) {
+	if msg.IsSelfMessage {
+		return entity.RoleOwner, true
+	}
+	if !h.isFromAllowedSender(group, msg.SenderPhone) {
+		return "", false
 	}
-	if len(h.allowedSenders) == 1 && h.allowedSenders[0] == "*" {
+	if role, ok := h.roleUC.Assigned(ctx, formatter.NormalizePhone(msg.SenderPhone)); ok {
+		return role, true
+	}
+	return h.roleUC.DefaultRole(), true
+}
+
+// isFromAllowedSender checks if the sender is allowed to use the bot in a chat.
+func (h *Handler) isFromAllowedSender(group entity.GroupConfig, phone string) bool {
+	if len(group.AllowedSenders) == 1 && group.AllowedSenders[0] == "*" {
 		h.logger.Printf("✅ [DEBUG] Wildcard mode: allowing all senders")
-		return true
 	}
+	return group.AllowsSender(formatter.NormalizePhone(phone))
+}
 
-	senderNormalized := formatter.NormalizePhone(phone)
-	for _, allowed := range h.allowedSenders {
-		if formatter.NormalizePhone(allowed) == senderNormalized {
-			return true
-		}
+// kanalFor returns the default sales channel for orders from a chat.
+func (h *Handler) kanalFor(msg *entity.Message) string {
+	group, _ := h.groups.Lookup(msg.ChatID)
+	return group.Kanal
+}
+
+// qrisGroupFor returns the group that receives a group order's QRIS and payment
+// notices: the group the command came from, or the default group (private chats).
+func (h *Handler) qrisGroupFor(msg *entity.Message) string {
+	if group, ok := h.groups.Find(msg.ChatID); ok {
+		return group.JID
 	}
-	return false
+	return h.groups.Default().JID
 }
@@ -0,0 +1,39 @@
+// Package bot provides WhatsApp bot message parsing and handling.
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// handleHelpCommand handles the #help command, generated from the command registry.
+// Format: #help | #help <perintah>
+func (h *Handler) handleHelpCommand(ctx context.Context, msg *entity.Message, text string) {
+	group, _ := h.groups.Lookup(msg.ChatID)
+	role, _ := h.senderRole(ctx, group, msg)
+	usable := func(spec entity.CommandSpec) bool {
+		return role.Can(spec.Name) && group.AllowsCommand(spec.Name)
+	}
+
+	if arg := strings.TrimSpace(strings.TrimPrefix(text, entity.CmdHelp)); arg != "" {
+		name := strings.Fields(arg)[0]
+		spec, ok := entity.FindCommand(name)
+		if !ok {
+			h.sendErrorReply(ctx, msg, template.BuildUnknownCommand(name))
+			return
+		}
+		h.sendErrorReply(ctx, msg, template.BuildCommandHelp(spec, usable(spec)))
+		return
+	}
+
+	var specs []entity.CommandSpec
+	for _, spec := range entity.Commands {
+		if usable(spec) {
+			specs = append(specs, spec)
+		}
+	}
+	h.sendLongReply(ctx, msg, template.BuildHelp(specs, role))
+}
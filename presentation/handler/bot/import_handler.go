@@ -0,0 +1,84 @@
+// Package bot provides WhatsApp bot message parsing and handling.
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// handleImportCommand processes #import commands (bulk import from a CSV/XLSX document).
+func (h *Handler) handleImportCommand(ctx context.Context, msg *entity.Message, text string) {
+	cmd, err := parser.ParseImportCommand(text)
+	if err != nil {
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+		return
+	}
+
+	if h.importerUC == nil {
+		h.sendErrorReply(ctx, msg, "❌ Fitur import tidak tersedia. Google Sheets belum dikonfigurasi.")
+		return
+	}
+
+	switch {
+	case cmd.Cancel:
+		if h.importerUC.Cancel(msg.ChatID) {
+			h.sendErrorReply(ctx, msg, "🚫 Import dibatalkan.")
+		} else {
+			h.sendErrorReply(ctx, msg, "ℹ️ Tidak ada import yang menunggu konfirmasi.")
+		}
+	case cmd.Confirm:
+		h.confirmImport(ctx, msg)
+	case cmd.IsHelpMode || msg.Document == nil:
+		h.sendErrorReply(ctx, msg, template.BuildImportHelp(constants.ImportMaxRows))
+	default:
+		h.previewImport(ctx, msg, cmd.Kind)
+	}
+}
+
+// previewImport validates the attached document and replies with the dry-run summary.
+func (h *Handler) previewImport(ctx context.Context, msg *entity.Message, kind entity.ImportKind) {
+	h.logger.Printf("📥 Import: %s (%s, %d bytes)", msg.Document.FileName, kind, len(msg.Document.Data))
+	if kind.HasPasswords() {
+		// The file holds plaintext passwords; the preview keeps its own copy
+		defer h.revokeSensitiveMessage(ctx, msg)
+	}
+
+	preview, err := h.importerUC.Preview(ctx, msg.ChatID, kind, msg.Document)
+	switch {
+	case errors.Is(err, domain.ErrImportNoRows):
+		h.sendErrorReply(ctx, msg, "❌ File tidak berisi data.")
+		return
+	case errors.Is(err, domain.ErrImportTooManyRows):
+		h.sendErrorReply(ctx, msg, fmt.Sprintf("❌ File terlalu besar. Maksimal %d baris per import.", constants.ImportMaxRows))
+		return
+	case err != nil:
+		h.logger.Printf("Gagal membaca file import: %v", err)
+		h.sendErrorReply(ctx, msg, "❌ Gagal membaca file: "+err.Error())
+		return
+	}
+
+	h.sendErrorReply(ctx, msg, template.BuildImportPreview(preview, constants.ImportMaxInvalidListed, constants.ImportPreviewMinutes))
+}
+
+// confirmImport appends the previewed rows of this chat.
+func (h *Handler) confirmImport(ctx context.Context, msg *entity.Message) {
+	preview, err := h.importerUC.Commit(ctx, msg.ChatID)
+	if errors.Is(err, domain.ErrNoImportPreview) {
+		h.sendErrorReply(ctx, msg, "ℹ️ Tidak ada import yang menunggu (atau sudah kedaluwarsa). Kirim file dengan caption #import dulu.")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("Gagal import: %v", err)
+		h.sendErrorReply(ctx, msg, "❌ Gagal menyimpan ke sheet: "+err.Error()+"\nBalas *#import ok* untuk mencoba lagi.")
+		return
+	}
+
+	h.sendErrorReply(ctx, msg, template.BuildImportResult(preview))
+}
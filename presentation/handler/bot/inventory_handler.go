@@ -3,16 +3,21 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
 
+	"github.com/exernia/botjanweb/internal/domain"
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+	"github.com/exernia/botjanweb/presentation/template"
 )
 
 // handleCekSlotCommand handles the #cekslot command.
 // Format: #cekslot <product>
-// Example: #cekslot gemini, #cekslot chatgpt
+// Example: #cekslot google, #cekslot chatgpt, #cekslot youtube
 func (h *Handler) handleCekSlotCommand(ctx context.Context, msg *entity.Message, text string) {
 	cmd := h.parseCekSlotCommand(text)
 
@@ -22,7 +27,8 @@ func (h *Handler) handleCekSlotCommand(ctx context.Context, msg *entity.Message,
 	}
 
 	// Get slot availability
-	result, err := h.inventoryRepo.GetSlotAvailability(ctx, cmd.Product, cmd.AvailableOnly)
+	maxSlots := h.slotCapacity.SlotCapacity(ctx, entity.Product(cmd.Product))
+	result, err := h.inventoryRepo.GetSlotAvailability(ctx, cmd.Product, maxSlots, cmd.AvailableOnly)
 	if err != nil {
 		h.sendErrorReply(ctx, msg, fmt.Sprintf("❌ Gagal mengecek slot: %v", err))
 		return
@@ -47,16 +53,11 @@ func (h *Handler) parseCekSlotCommand(text string) *entity.CekSlotCommand {
 		return cmd
 	}
 
-	// Parse product
-	switch {
-	case strings.Contains(text, "chatgpt") || strings.Contains(text, "gpt"):
-		cmd.Product = "ChatGPT"
-	case strings.Contains(text, "gemini") || strings.Contains(text, "google"):
-		cmd.Product = "Gemini"
-	case strings.Contains(text, "all") || strings.Contains(text, "semua"):
-		// Show both - we'll handle this by not filtering
-		cmd.Product = ""
-	default:
+	// Parse product (first word, any registered product with slots)
+	fields := strings.Fields(text)
+	if info, ok := entity.ProductByParam(fields[0]); ok && info.HasSlots() {
+		cmd.Product = string(info.Key)
+	} else {
 		cmd.IsHelpMode = true
 	}
 
@@ -69,24 +70,31 @@ func (h *Handler) parseCekSlotCommand(text string) *entity.CekSlotCommand {
 }
 
 // sendCekSlotHelp sends help message for #cekslot command.
+// Product list is built from the product registry.
 func (h *Handler) sendCekSlotHelp(ctx context.Context, msg *entity.Message) {
-	help := `📊 *Command #cekslot*
-
-Cek ketersediaan slot Family/Workspace.
-
-*Format:*
-#cekslot <produk>
-
-*Contoh:*
-#cekslot gemini
-#cekslot chatgpt
-#cekslot gemini all (tampilkan semua termasuk yang penuh)
+	var sb strings.Builder
+	sb.WriteString("📊 *Command #cekslot*\n\n")
+	sb.WriteString("Cek ketersediaan slot Family/Workspace/Head.\n\n")
+	sb.WriteString("*Format:*\n#cekslot <produk>\n\n")
+	sb.WriteString("*Contoh:*\n")
+
+	var products []entity.ProductInfo
+	for _, p := range entity.AllProducts() {
+		if info := p.Info(); info.HasSlots() {
+			products = append(products, info)
+			sb.WriteString(fmt.Sprintf("#cekslot %s\n", info.Param))
+		}
+	}
+	if len(products) > 0 {
+		sb.WriteString(fmt.Sprintf("#cekslot %s all (tampilkan semua termasuk yang penuh)\n", products[0].Param))
+	}
 
-*Produk:*
-• gemini - Cek slot Family Gemini (max 5)
-• chatgpt - Cek slot Workspace ChatGPT (max 4)`
+	sb.WriteString("\n*Produk:*")
+	for _, info := range products {
+		sb.WriteString(fmt.Sprintf("\n• %s - Cek slot %s %s (max %d)", info.Param, info.SlotLabel, info.Key, h.slotCapacity.SlotCapacity(ctx, info.Key)))
+	}
 
-	h.sendErrorReply(ctx, msg, help)
+	h.sendErrorReply(ctx, msg, sb.String())
 }
 
 // sendSlotAvailabilityResult formats and sends slot availability result.
@@ -128,6 +136,117 @@ func (h *Handler) sendSlotAvailabilityResult(ctx context.Context, msg *entity.Me
 	h.sendErrorReply(ctx, msg, sb.String())
 }
 
+// handleMemberCommand handles the #member command.
+// Format: #member <family / workspace owner email>
+// Emails and contacts are masked unless the command comes from the admin group.
+func (h *Handler) handleMemberCommand(ctx context.Context, msg *entity.Message, text string) {
+	cmd := h.parseMemberCommand(text)
+
+	if cmd.IsHelpMode {
+		h.sendMemberHelp(ctx, msg)
+		return
+	}
+
+	if h.inventoryRepo == nil {
+		h.sendErrorReply(ctx, msg, "❌ Fitur cek member tidak tersedia. Google Sheets belum dikonfigurasi.")
+		return
+	}
+
+	// Collect members of this owner from every product sheet with slots
+	var members []entity.WorkspaceMember
+	for _, p := range entity.AllProducts() {
+		if !p.Info().HasSlots() {
+			continue
+		}
+		rows, err := h.inventoryRepo.ListMembers(ctx, p)
+		if err != nil {
+			h.sendErrorReply(ctx, msg, fmt.Sprintf("❌ Gagal mengecek member: %v", err))
+			return
+		}
+		for _, m := range rows {
+			if strings.EqualFold(m.Owner, cmd.Owner) {
+				members = append(members, m)
+			}
+		}
+	}
+
+	// Full details only inside the bot's groups
+	_, inGroup := h.groups.Find(msg.ChatID)
+	mask := !inGroup
+	h.sendMemberListResult(ctx, msg, cmd.Owner, members, mask)
+}
+
+// parseMemberCommand parses the #member command.
+func (h *Handler) parseMemberCommand(text string) *entity.MemberCommand {
+	owner := strings.TrimSpace(text[len(entity.CmdMember):])
+	if owner == "" {
+		return &entity.MemberCommand{IsHelpMode: true}
+	}
+	return &entity.MemberCommand{Owner: owner}
+}
+
+// sendMemberHelp sends help message for #member command.
+func (h *Handler) sendMemberHelp(ctx context.Context, msg *entity.Message) {
+	help := `👥 *Command #member*
+
+Menampilkan daftar member sebuah Family/Workspace/Head.
+
+*Format:*
+#member <email owner>
+
+*Contoh:*
+#member family01@gmail.com
+
+*Keterangan:*
+• Email owner: isi kolom Family/WorkSpace/Email Head di sheet produk
+• Di luar grup admin, email dan nomor customer disamarkan`
+
+	h.sendErrorReply(ctx, msg, help)
+}
+
+// sendMemberListResult formats and sends the member list of an owner.
+func (h *Handler) sendMemberListResult(ctx context.Context, msg *entity.Message, owner string, members []entity.WorkspaceMember, mask bool) {
+	if len(members) == 0 {
+		h.sendErrorReply(ctx, msg, fmt.Sprintf("👥 Tidak ada member dengan owner %s.", owner))
+		return
+	}
+
+	dash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	maskEmail := func(s string) string {
+		if mask {
+			return formatter.MaskEmail(s)
+		}
+		return s
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("👥 *Member %s*\n", maskEmail(owner)))
+
+	for i, m := range members {
+		sb.WriteString(fmt.Sprintf("\n%d. *%s* (%s)\n", i+1, dash(m.Nama), m.Produk))
+		sb.WriteString(fmt.Sprintf("   📧 %s\n", maskEmail(m.Email)))
+		sb.WriteString(fmt.Sprintf("   📅 Pesan: %s | Berakhir: %s\n", dash(m.TanggalPesanan), dash(m.TanggalBerakhir)))
+		if m.Akun != "" {
+			contact := m.Akun
+			if mask {
+				contact = formatter.MaskPhone(contact)
+			}
+			sb.WriteString(fmt.Sprintf("   📱 %s\n", contact))
+		}
+	}
+
+	// Summary (capacity of the first product, owners belong to one product sheet)
+	maxSlots := h.slotCapacity.SlotCapacity(ctx, members[0].Produk)
+	sb.WriteString(fmt.Sprintf("\n📈 *Total:* %d/%d slot terpakai", len(members), maxSlots))
+
+	h.sendErrorReply(ctx, msg, sb.String())
+}
+
 // handleCekKodeCommand handles the #cekkode command.
 // Format: #cekkode [all]
 func (h *Handler) handleCekKodeCommand(ctx context.Context, msg *entity.Message, text string) {
@@ -163,30 +282,81 @@ func (h *Handler) sendRedeemCodeResult(ctx context.Context, msg *entity.Message,
 			sb.WriteString("📭 Belum ada kode yang diinput.\n")
 		}
 	} else {
-		for _, code := range result.Codes {
-			emoji := "✅"
-			status := "Tersedia"
-			if code.TanggalAktivasi != "" {
-				emoji = "❌"
-				status = fmt.Sprintf("Dipakai %s", code.TanggalAktivasi)
+		for i := range result.Codes {
+			code := &result.Codes[i]
+			sb.WriteString(fmt.Sprintf("#%d: %s\n   📧 Email: %s\n", code.No, code.KodeRedeem, code.Email))
+			for _, line := range strings.Split(template.BuildRedeemCodeStatus(code), "\n") {
+				sb.WriteString("   " + line + "\n")
 			}
-
-			sb.WriteString(fmt.Sprintf("%s #%d: %s\n   Email: %s\n   Status: %s\n\n",
-				emoji, code.No, code.KodeRedeem, code.Email, status))
+			sb.WriteString("\n")
 		}
 	}
 
 	// Summary
 	sb.WriteString(fmt.Sprintf("📈 *Ringkasan:* %d total, %d tersedia",
 		result.TotalCodes, result.AvailableCodes))
+	if result.InvalidCodes > 0 {
+		sb.WriteString(fmt.Sprintf(", %d invalid", result.InvalidCodes))
+	}
 
 	h.sendErrorReply(ctx, msg, sb.String())
 }
 
+// handleKodeCommand handles the #kode command (redeem code lifecycle).
+// Format: #kode <pakai|tanggal|invalid|lepas> <kode> [...]
+func (h *Handler) handleKodeCommand(ctx context.Context, msg *entity.Message, text string) {
+	cmd, err := parser.ParseKodeCommand(text)
+	if err != nil {
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+		return
+	}
+	if cmd.IsHelpMode {
+		h.sendErrorReply(ctx, msg, template.KodeHelp)
+		return
+	}
+
+	if h.redeemUC == nil {
+		h.sendErrorReply(ctx, msg, "❌ Fitur kelola kode tidak tersedia. Google Sheets belum dikonfigurasi.")
+		return
+	}
+
+	var code *entity.RedeemCodeInfo
+	switch cmd.Action {
+	case entity.KodePakai:
+		code, err = h.redeemUC.Assign(ctx, cmd.Kode, cmd.Customer)
+	case entity.KodeTanggal:
+		code, err = h.redeemUC.SetDates(ctx, cmd.Kode, cmd.Activated, cmd.Ends)
+	case entity.KodeInvalid:
+		code, err = h.redeemUC.MarkInvalid(ctx, cmd.Kode, cmd.Reason)
+	case entity.KodeLepas:
+		code, err = h.redeemUC.Release(ctx, cmd.Kode)
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrRedeemCodeNotFound):
+		h.sendErrorReply(ctx, msg, "❌ Kode tidak ditemukan di sheet Kode Perplexity: "+cmd.Kode)
+	case errors.Is(err, domain.ErrRedeemCodeUsed):
+		h.sendErrorReply(ctx, msg, "⚠️ Kode sudah dipakai. Gunakan *#kode lepas "+cmd.Kode+"* dulu jika ingin dipakai ulang.\n\n"+
+			template.BuildRedeemCodeStatus(code))
+	case errors.Is(err, domain.ErrRedeemCodeInvalid):
+		h.sendErrorReply(ctx, msg, "⚠️ Kode ditandai invalid dan tidak bisa dipakai.\n\n"+template.BuildRedeemCodeStatus(code))
+	case err != nil:
+		h.logger.Printf("Gagal update kode %s: %v", cmd.Kode, err)
+		h.sendErrorReply(ctx, msg, fmt.Sprintf("❌ Gagal update kode: %v", err))
+	default:
+		h.sendErrorReply(ctx, msg, template.BuildKodeUpdated(cmd.Action, code))
+	}
+}
+
 // handleInputKodeCommand handles the #inputkode command.
 // Format: #inputkode <email> <kode>
 func (h *Handler) handleInputKodeCommand(ctx context.Context, msg *entity.Message, text string) {
-	cmd := h.parseInputKodeCommand(text)
+	cmd, err := h.parseInputKodeCommand(text)
+	var result *entity.ValidationResult
+	if errors.As(err, &result) {
+		h.sendErrorReply(ctx, msg, template.BuildValidationErrors("Kode belum valid", result, "Format: #inputkode <email> <kode_redeem>"))
+		return
+	}
 
 	if cmd.IsHelpMode {
 		h.sendInputKodeHelp(ctx, msg)
@@ -194,7 +364,7 @@ func (h *Handler) handleInputKodeCommand(ctx context.Context, msg *entity.Messag
 	}
 
 	// Add redeem code
-	err := h.inventoryRepo.AddRedeemCode(ctx, cmd.Email, cmd.KodeRedeem)
+	err = h.inventoryRepo.AddRedeemCode(ctx, cmd.Email, cmd.KodeRedeem)
 	if err != nil {
 		h.sendErrorReply(ctx, msg, fmt.Sprintf("❌ Gagal menambah kode: %v", err))
 		return
@@ -211,7 +381,8 @@ func (h *Handler) handleInputKodeCommand(ctx context.Context, msg *entity.Messag
 }
 
 // parseInputKodeCommand parses the #inputkode command.
-func (h *Handler) parseInputKodeCommand(text string) *entity.InputKodeCommand {
+// Field errors are returned as *entity.ValidationResult.
+func (h *Handler) parseInputKodeCommand(text string) (*entity.InputKodeCommand, error) {
 	cmd := &entity.InputKodeCommand{}
 
 	// Remove prefix
@@ -220,26 +391,24 @@ func (h *Handler) parseInputKodeCommand(text string) *entity.InputKodeCommand {
 
 	if text == "" {
 		cmd.IsHelpMode = true
-		return cmd
+		return cmd, nil
 	}
 
 	// Split by whitespace
 	parts := strings.Fields(text)
 	if len(parts) < 2 {
 		cmd.IsHelpMode = true
-		return cmd
+		return cmd, nil
 	}
 
 	cmd.Email = parts[0]
 	cmd.KodeRedeem = parts[1]
 
-	// Validate email format (basic check)
-	if !strings.Contains(cmd.Email, "@") {
-		cmd.IsHelpMode = true
-		return cmd
+	if err := parser.ValidateInputKodeCommand(cmd); err != nil {
+		return nil, err
 	}
 
-	return cmd
+	return cmd, nil
 }
 
 // sendInputKodeHelp sends help message for #inputkode command.
@@ -0,0 +1,90 @@
+// Package bot provides WhatsApp bot message parsing and handling.
+package bot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// handlePindahCommand processes #pindah commands (move members off a banned workspace).
+func (h *Handler) handlePindahCommand(ctx context.Context, msg *entity.Message, text string) {
+	cmd, err := parser.ParsePindahCommand(text)
+	if err != nil {
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+		return
+	}
+
+	if h.migrationUC == nil {
+		h.sendErrorReply(ctx, msg, "❌ Fitur pindah member tidak tersedia. Google Sheets belum dikonfigurasi.")
+		return
+	}
+
+	switch {
+	case cmd.IsHelpMode:
+		h.sendPindahHelp(ctx, msg)
+	case cmd.Cancel:
+		if h.migrationUC.Cancel(msg.ChatID) {
+			h.sendErrorReply(ctx, msg, "🚫 Rencana pindah member dibatalkan.")
+		} else {
+			h.sendErrorReply(ctx, msg, "ℹ️ Tidak ada rencana pindah member yang menunggu konfirmasi.")
+		}
+	case cmd.Confirm:
+		h.confirmPindah(ctx, msg)
+	default:
+		h.planPindah(ctx, msg, cmd.Workspace)
+	}
+}
+
+// sendPindahHelp sends the #pindah help with the banned workspaces that still have members.
+func (h *Handler) sendPindahHelp(ctx context.Context, msg *entity.Message) {
+	banned, err := h.migrationUC.BannedWorkspaces(ctx)
+	if err != nil {
+		h.logger.Printf("Gagal cek workspace banned: %v", err)
+		h.sendErrorReply(ctx, msg, template.PindahHelp)
+		return
+	}
+	h.sendErrorReply(ctx, msg, template.BuildBannedWorkspaceList(banned))
+}
+
+// planPindah proposes a migration plan for a banned workspace.
+func (h *Handler) planPindah(ctx context.Context, msg *entity.Message, workspace string) {
+	h.logger.Printf("🔀 Pindah: planning for %s", workspace)
+
+	plan, err := h.migrationUC.Plan(ctx, msg.ChatID, workspace)
+	switch {
+	case errors.Is(err, domain.ErrWorkspaceNotFound):
+		h.sendErrorReply(ctx, msg, "❌ Workspace tidak ditemukan di sheet Akun ChatGPT: "+workspace)
+		return
+	case errors.Is(err, domain.ErrWorkspaceNotBanned):
+		h.sendErrorReply(ctx, msg, "⚠️ Workspace "+workspace+" tidak berstatus banned. Member tidak dipindah.")
+		return
+	case err != nil:
+		h.logger.Printf("Gagal buat rencana pindah: %v", err)
+		h.sendErrorReply(ctx, msg, "❌ Gagal membuat rencana pindah: "+err.Error())
+		return
+	}
+
+	h.sendErrorReply(ctx, msg, template.BuildMigrationPlan(plan, constants.MigrationPlanMinutes))
+}
+
+// confirmPindah applies the pending migration plan of this chat.
+func (h *Handler) confirmPindah(ctx context.Context, msg *entity.Message) {
+	result, err := h.migrationUC.Confirm(ctx, msg.ChatID)
+	if errors.Is(err, domain.ErrNoMigrationPlan) {
+		h.sendErrorReply(ctx, msg, "ℹ️ Tidak ada rencana pindah yang menunggu (atau sudah kedaluwarsa). Kirim #pindah <workspace> dulu.")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("Gagal pindah member: %v", err)
+		h.sendErrorReply(ctx, msg, "❌ Gagal pindah member: "+err.Error())
+		return
+	}
+
+	h.sendErrorReply(ctx, msg, template.BuildMigrationResult(result))
+}
@@ -0,0 +1,196 @@
+// Package bot provides WhatsApp bot message parsing and handling.
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// handleOrderCommand starts or cancels the step-by-step order wizard.
+// Format: #order | #order <produk> | #order batal
+func (h *Handler) handleOrderCommand(ctx context.Context, msg *entity.Message, text string) {
+	if msg.IsSelfMessage {
+		h.sendErrorReply(ctx, msg, "❌ #order tidak bisa dipakai dari nomor bot. Gunakan #qris.")
+		return
+	}
+
+	cmd, err := parser.ParseOrderCommand(text)
+	if err != nil {
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+		return
+	}
+
+	phone := formatter.NormalizePhone(msg.SenderPhone)
+	if cmd.Cancel {
+		if h.orderUC.End(msg.ChatID, phone) {
+			h.sendErrorReply(ctx, msg, template.BuildOrderCancelled())
+		} else {
+			h.sendErrorReply(ctx, msg, template.BuildOrderNoSession())
+		}
+		return
+	}
+
+	stock := &entity.ValidationResult{}
+	if h.checkRedeemCodeStock(ctx, cmd.Produk, stock); !stock.Valid() {
+		h.sendErrorReply(ctx, msg, template.BuildValidationErrors("Order belum bisa dimulai", stock, ""))
+		return
+	}
+
+	s, release := h.orderUC.Start(msg.ChatID, phone, entity.Product(cmd.Produk), h.kanalFor(msg))
+	defer release()
+	h.askOrderQuestion(ctx, msg, s)
+}
+
+// handleOrderAnswer treats a non-command message as the answer to the sender's
+// running #order wizard. Messages without a running wizard are ignored.
+// The session stays locked until the answer is handled, so quick replies are
+// applied one after another. The sender's role and the chat allowlist are checked
+// again on every answer, since they may have changed since #order was sent.
+func (h *Handler) handleOrderAnswer(ctx context.Context, msg *entity.Message, group entity.GroupConfig, text string) {
+	if msg.IsSelfMessage {
+		return
+	}
+
+	phone := formatter.NormalizePhone(msg.SenderPhone)
+	s, release, err := h.orderUC.Get(msg.ChatID, phone)
+	if errors.Is(err, domain.ErrOrderSessionExpired) {
+		h.sendErrorReply(ctx, msg, template.BuildOrderExpired(constants.OrderWizardMinutes))
+		return
+	}
+	if s == nil {
+		return
+	}
+	defer release()
+
+	role, allowed := h.senderRole(ctx, group, msg)
+	if !allowed {
+		h.orderUC.Finish(s)
+		return
+	}
+	if !h.permits(ctx, msg, group, role, entity.CmdOrder) {
+		h.orderUC.Finish(s)
+		return
+	}
+
+	answer := strings.TrimSpace(text)
+	switch strings.ToLower(answer) {
+	case entity.OrderReplyCancel:
+		h.orderUC.Finish(s)
+		h.sendErrorReply(ctx, msg, template.BuildOrderCancelled())
+		return
+	case entity.OrderReplyBack:
+		if !s.Back() {
+			h.sendErrorReply(ctx, msg, "ℹ️ Ini sudah pertanyaan pertama.")
+		}
+		h.askOrderQuestion(ctx, msg, s)
+		return
+	}
+
+	// First question when #order was sent without a product
+	field, ok := s.Current()
+	if !ok {
+		p, err := parser.ParseOrderProduct(answer)
+		if err != nil {
+			h.sendErrorReply(ctx, msg, "❌ "+err.Error()+"\n\n"+template.BuildOrderProductQuestion())
+			return
+		}
+		stock := &entity.ValidationResult{}
+		if h.checkRedeemCodeStock(ctx, string(p), stock); !stock.Valid() {
+			h.sendErrorReply(ctx, msg, template.BuildValidationErrors("Order belum bisa dimulai", stock, ""))
+			return
+		}
+		s.SetProduct(p)
+		h.askOrderQuestion(ctx, msg, s)
+		return
+	}
+
+	if result := h.applyOrderAnswer(ctx, s, field, answer); !result.Valid() {
+		h.sendErrorReply(ctx, msg, template.BuildOrderAnswerInvalid(result, s))
+		return
+	}
+
+	s.Next()
+	if !s.Done() {
+		h.askOrderQuestion(ctx, msg, s)
+		return
+	}
+
+	h.orderUC.Finish(s)
+	if !h.permits(ctx, msg, group, role, entity.CmdQris) {
+		return
+	}
+	var result *entity.ValidationResult
+	if err := parser.CompleteQrisForm(s.Cmd); errors.As(err, &result) {
+		h.sendErrorReply(ctx, msg, template.BuildValidationErrors("Order belum valid", result, "Ketik *#order* untuk mulai lagi."))
+		return
+	}
+	h.logger.Printf("📝 Order wizard finished: %s (%s)", phone, s.Cmd.Deskripsi)
+	h.sendErrorReply(ctx, msg, template.BuildOrderProcessing(s.Cmd))
+	h.handleQrisForm(ctx, msg, s.Cmd)
+}
+
+// applyOrderAnswer stores the answer to field and validates it right away, using
+// the same checks as the #qris form (email, duplicate, slot, price, voucher).
+// Checks run on a copy so they don't change the answers.
+func (h *Handler) applyOrderAnswer(ctx context.Context, s *entity.OrderSession, field entity.FormField, answer string) *entity.ValidationResult {
+	result := &entity.ValidationResult{}
+
+	if entity.IsOrderSkip(answer) {
+		if field.Required {
+			result.Add(field.Label, "wajib diisi")
+			return result
+		}
+		switch field.Key {
+		case entity.FieldNominal:
+			s.Cmd.Amount = 0 // Use the catalog price
+		case entity.FieldKanal:
+			s.Cmd.Kanal = s.DefaultKanal
+		case entity.FieldAkun:
+			s.Cmd.Akun = ""
+		default:
+			s.Cmd.SetField(field.Key, "")
+		}
+	} else if err := parser.ParseOrderAnswer(s.Cmd, field, answer); err != nil {
+		result.Add(field.Label, err.Error())
+		return result
+	}
+
+	probe := *s.Cmd
+	info := entity.Product(probe.Produk).Info()
+	switch {
+	case field.Key == entity.FieldEmail:
+		h.checkDuplicate(ctx, &probe, result)
+
+	case field.Key == info.SlotField:
+		// Renewals keep their slot, so only new members need a free one
+		if h.checkDuplicate(ctx, &probe, result); result.Valid() && probe.RenewalRow == 0 {
+			h.validateSlot(ctx, &probe, result)
+		}
+
+	case field.Key == entity.FieldNominal:
+		h.resolveAmount(ctx, &probe, result)
+
+	case field.Key == entity.FieldVoucher && probe.Voucher != "":
+		if h.resolveAmount(ctx, &probe, result) {
+			h.applyVoucher(ctx, &probe, result)
+		}
+	}
+	return result
+}
+
+// askOrderQuestion sends the current question of an order wizard.
+func (h *Handler) askOrderQuestion(ctx context.Context, msg *entity.Message, s *entity.OrderSession) {
+	if _, ok := s.Current(); !ok {
+		h.sendErrorReply(ctx, msg, template.BuildOrderProductQuestion())
+		return
+	}
+	h.sendErrorReply(ctx, msg, template.BuildOrderQuestion(s))
+}
@@ -3,10 +3,14 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/exernia/botjanweb/internal/domain"
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
 	"github.com/exernia/botjanweb/pkg/helper/formatter"
 	"github.com/exernia/botjanweb/pkg/helper/parser"
 	"github.com/exernia/botjanweb/presentation/template"
@@ -20,7 +24,12 @@ func (h *Handler) handleQrisCommand(ctx context.Context, msg *entity.Message, te
 		return
 	}
 
-	cmd, err := parser.ParseQrisCommand(text, h.defaultKanal)
+	cmd, err := parser.ParseQrisCommand(text, h.kanalFor(msg))
+	var result *entity.ValidationResult
+	if errors.As(err, &result) {
+		h.sendErrorReply(ctx, msg, template.BuildValidationErrors("Form order belum valid", result, "Ketik *#qris <produk>* untuk template form."))
+		return
+	}
 	if err != nil {
 		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
 		return
@@ -32,38 +41,52 @@ func (h *Handler) handleQrisCommand(ctx context.Context, msg *entity.Message, te
 	}
 
 	if cmd.IsFormMode {
+		if len(cmd.Unknown) > 0 {
+			h.sendErrorReply(ctx, msg, template.BuildQrisUnknownFields(cmd.Unknown, entity.Product(cmd.Produk).Info()))
+		}
 		h.handleQrisForm(ctx, msg, cmd)
 		return
 	}
 
 	// Legacy format not allowed in group
-	h.sendErrorReply(ctx, msg, "❌ Format tidak valid. Gunakan format:\n\n#qris google\natau\n#qris chatgpt\n\nuntuk melihat form yang benar.")
+	h.sendErrorReply(ctx, msg, template.BuildQrisInvalidFormat())
 }
 
 // handleQrisForm handles form-based #qris for products (Gemini, ChatGPT, etc.).
 func (h *Handler) handleQrisForm(ctx context.Context, msg *entity.Message, cmd *entity.QrisCommand) {
+	defer h.lockForm(msg)()
 	h.logger.Printf("💳 QRIS Form: %s | %s | %s", cmd.Produk, cmd.Nama, cmd.Email)
 
-	// Validate Family if provided (for Gemini)
-	if _, errorMsg, err := h.validateFamilyOrWorkspace(ctx, "Family", cmd.Family); err != nil {
+	// QRIS and payment notices go to the group the order came from
+	groupJID := h.qrisGroupFor(msg)
+
+	old, skip := h.editedQris(msg, cmd)
+	if skip {
+		if msg.IsEdit {
+			h.sendErrorReply(ctx, msg, template.BuildQrisEditPaid())
+		}
+		return
+	}
+
+	// Check email, slot, price, voucher and code stock; report every problem at once
+	dup, check := h.validateOrder(ctx, cmd, old)
+	if !check.Valid() {
+		hint := ""
+		if old != nil {
+			hint = template.BuildQrisEditKeepsOld(old)
+		}
+		errorMsg := template.BuildValidationErrors("Order belum bisa diproses", check, hint)
 		h.sendErrorReply(ctx, msg, errorMsg)
 		// Also send to group
-		if _, err := h.messaging.SendTextToGroup(ctx, errorMsg); err != nil {
+		if _, err := h.messaging.SendTextToGroupJID(ctx, groupJID, errorMsg); err != nil {
 			h.logger.Printf("⚠️ Gagal kirim error ke grup: %v", err)
 		}
 		return
 	}
 
-	// Validate Workspace if provided (for ChatGPT)
-	if cmd.Workspace != "" {
-		if _, errorMsg, err := h.validateFamilyOrWorkspace(ctx, "Workspace", cmd.Workspace); err != nil {
-			h.sendErrorReply(ctx, msg, errorMsg)
-			// Also send to group
-			if _, err := h.messaging.SendTextToGroup(ctx, errorMsg); err != nil {
-				h.logger.Printf("⚠️ Gagal kirim error ke grup: %v", err)
-			}
-			return
-		}
+	if !h.replaceQris(ctx, old) {
+		h.sendErrorReply(ctx, msg, template.BuildQrisEditPaid())
+		return
 	}
 
 	result, err := h.qrisUC.GenerateQRIS(ctx, cmd, msg)
@@ -74,27 +97,21 @@ func (h *Handler) handleQrisForm(ctx context.Context, msg *entity.Message, cmd *
 	}
 
 	// Send QRIS image without caption to group (clean)
-	qrisMsgID, err := h.messaging.SendImage(ctx, result.ImageData, "")
-	if err != nil {
+	qrisMsgID, err := h.messaging.SendImageTo(ctx, groupJID, result.ImageData, "")
+	if errors.Is(err, domain.ErrMessageQueued) {
+		h.logger.Printf("⏳ QRIS %s masih antre, dikirim setelah koneksi pulih", qrisMsgID)
+	} else if err != nil {
 		h.logger.Printf("❌ Gagal kirim QRIS: %v", err)
 		return
 	}
 
 	// Send caption as reply to the QRIS image in group
-	caption := template.BuildQrisFormCaption(cmd)
-	if err := h.messaging.SendTextReplyToGroup(ctx, caption, qrisMsgID); err != nil {
+	caption := template.BuildQrisFormCaption(cmd) + template.BuildDuplicateCaption(cmd, dup) + template.BuildQrisReplacedCaption(old)
+	if err := h.messaging.SendTextReply(ctx, groupJID, caption, qrisMsgID, h.messaging.GetOwnID()); err != nil {
 		h.logger.Printf("⚠️ Gagal kirim caption: %v (QRIS tetap terkirim)", err)
 		// Continue - QRIS already sent successfully
 	}
 
-	// Determine Family field value:
-	// - For ChatGPT: use workspace owner email (stored in cmd.Workspace)
-	// - For Gemini: use family name (stored in cmd.Family)
-	familyValue := cmd.Family
-	if cmd.Workspace != "" {
-		familyValue = cmd.Workspace // ChatGPT uses owner email
-	}
-
 	pending := &entity.PendingPayment{
 		MessageID:         qrisMsgID,
 		OriginalMessageID: msg.ID,
@@ -103,14 +120,19 @@ func (h *Handler) handleQrisForm(ctx context.Context, msg *entity.Message, cmd *
 		SenderPhone:       msg.SenderPhone,
 		Amount:            result.Amount,
 		CreatedAt:         time.Now(),
+		GroupJID:          groupJID,
 		Produk:            cmd.Produk,
 		Nama:              cmd.Nama,
 		Email:             cmd.Email,
-		Family:            familyValue,
-		Paket:             cmd.Paket, // Package duration (20/30 Hari)
+		Family:            cmd.SlotOwner(), // Family / owner email / Email Head (per product)
+		Paket:             cmd.Paket,       // Package duration (20/30 Hari)
 		Deskripsi:         cmd.Deskripsi,
 		Kanal:             cmd.Kanal,
 		Akun:              cmd.Akun,
+		Voucher:           cmd.Voucher,
+		Discount:          cmd.Discount,
+		RenewalRow:        cmd.RenewalRow,
+		ExpiresAt:         cmd.ExpiresAt,
 	}
 
 	h.paymentUC.RegisterPending(pending)
@@ -126,28 +148,35 @@ func (h *Handler) handleQrisSelf(ctx context.Context, msg *entity.Message, text
 		return
 	}
 
+	defer h.lockForm(msg)()
 	h.logger.Printf("💳 Self-QRIS: Rp%d | Ke: %s", cmd.Amount, msg.RecipientPhone)
 
-	// Validate Family if provided (for Gemini)
-	if _, errorMsg, err := h.validateFamilyOrWorkspace(ctx, "Family", cmd.Family); err != nil {
-		// Send error to group
-		errorMsg = "❌ Self-QRIS Gagal: " + errorMsg[2:] // Remove "❌ " prefix and add Self-QRIS prefix
-		if _, err := h.messaging.SendTextToGroup(ctx, errorMsg); err != nil {
-			h.logger.Printf("⚠️ Gagal kirim error ke grup: %v", err)
+	// Notices go to the group subscribed to payment notices
+	groupJID := h.groups.Targets(entity.NotifyPayment)[0]
+
+	// Replies would reach the customer, so edit notices go to the group too
+	old, skip := h.editedQris(msg, cmd)
+	if skip {
+		if msg.IsEdit {
+			h.sendGroupNotice(ctx, groupJID, template.BuildQrisEditPaid())
 		}
 		return
 	}
 
-	// Validate Workspace if provided (for ChatGPT)
-	if cmd.Workspace != "" {
-		if _, errorMsg, err := h.validateFamilyOrWorkspace(ctx, "Workspace", cmd.Workspace); err != nil {
-			// Send error to group
-			errorMsg = "❌ Self-QRIS Gagal: " + errorMsg[2:] // Remove "❌ " prefix and add Self-QRIS prefix
-			if _, err := h.messaging.SendTextToGroup(ctx, errorMsg); err != nil {
-				h.logger.Printf("⚠️ Gagal kirim error ke grup: %v", err)
-			}
-			return
+	// Check email, slot, price, voucher and code stock; report every problem at once
+	dup, check := h.validateOrder(ctx, cmd, old)
+	if !check.Valid() {
+		hint := ""
+		if old != nil {
+			hint = template.BuildQrisEditKeepsOld(old)
 		}
+		h.sendGroupNotice(ctx, groupJID, template.BuildValidationErrors("Self-QRIS Gagal", check, hint))
+		return
+	}
+
+	if !h.replaceQris(ctx, old) {
+		h.sendGroupNotice(ctx, groupJID, template.BuildQrisEditPaid())
+		return
 	}
 
 	result, err := h.qrisUC.GenerateQRIS(ctx, cmd, msg)
@@ -158,21 +187,23 @@ func (h *Handler) handleQrisSelf(ctx context.Context, msg *entity.Message, text
 
 	// Send QRIS image without caption (clean)
 	qrisMsgID, err := h.messaging.SendImageTo(ctx, msg.ChatID, result.ImageData, "")
-	if err != nil {
+	if errors.Is(err, domain.ErrMessageQueued) {
+		h.logger.Printf("⏳ QRIS %s ke customer masih antre, dikirim setelah koneksi pulih", qrisMsgID)
+	} else if err != nil {
 		h.logger.Printf("❌ Gagal kirim QRIS ke customer: %v", err)
 		return
 	}
 
 	// Send caption as reply to the QRIS image
-	caption := template.BuildQRISCaption(result.Amount, result.Deskripsi)
+	caption := template.BuildQRISCaption(result.Amount, result.Deskripsi) + template.BuildVoucherCaption(cmd)
 	if err := h.messaging.SendTextReply(ctx, msg.ChatID, caption, qrisMsgID, msg.SenderID); err != nil {
 		h.logger.Printf("⚠️ Gagal kirim caption: %v (QRIS tetap terkirim)", err)
 		// Continue - QRIS already sent successfully
 	}
 
-	notif := template.BuildSelfQrisNotification(cmd, msg.RecipientPhone)
-	groupNotifMsgID, err := h.messaging.SendTextToGroup(ctx, notif)
-	if err != nil {
+	notif := template.BuildSelfQrisNotification(cmd, msg.RecipientPhone) + template.BuildDuplicateCaption(cmd, dup) + template.BuildQrisReplacedCaption(old)
+	groupNotifMsgID, err := h.messaging.SendTextToGroupJID(ctx, groupJID, notif)
+	if err != nil && !errors.Is(err, domain.ErrMessageQueued) {
 		h.logger.Printf("⚠️ Gagal kirim notifikasi ke grup: %v", err)
 	}
 
@@ -182,14 +213,6 @@ func (h *Handler) handleQrisSelf(ctx context.Context, msg *entity.Message, text
 		akun = msg.RecipientPhone
 	}
 
-	// Determine Family field value:
-	// - For ChatGPT: use workspace owner email (stored in cmd.Workspace)
-	// - For Gemini: use family name (stored in cmd.Family)
-	familyValue := cmd.Family
-	if cmd.Workspace != "" {
-		familyValue = cmd.Workspace // ChatGPT uses owner email
-	}
-
 	pending := &entity.PendingPayment{
 		MessageID:         qrisMsgID,
 		OriginalMessageID: msg.ID,
@@ -200,86 +223,296 @@ func (h *Handler) handleQrisSelf(ctx context.Context, msg *entity.Message, text
 		CreatedAt:         time.Now(),
 		IsSelfQris:        true,
 		GroupNotifMsgID:   groupNotifMsgID,
+		GroupJID:          groupJID,
 		Produk:            cmd.Produk,
 		Nama:              cmd.Nama,
 		Email:             cmd.Email,
-		Family:            familyValue,
-		Paket:             cmd.Paket, // Package duration (20/30 Hari)
+		Family:            cmd.SlotOwner(), // Family / owner email / Email Head (per product)
+		Paket:             cmd.Paket,       // Package duration (20/30 Hari)
 		Deskripsi:         cmd.Deskripsi,
 		Kanal:             cmd.Kanal,
 		Akun:              akun,
+		Voucher:           cmd.Voucher,
+		Discount:          cmd.Discount,
+		RenewalRow:        cmd.RenewalRow,
+		ExpiresAt:         cmd.ExpiresAt,
 	}
 
 	h.paymentUC.RegisterPending(pending)
 	h.logger.Printf("✅ Self-QRIS terkirim ke %s, notif ke grup (ID: %s)", formatter.FormatPhone(msg.RecipientPhone), groupNotifMsgID)
 }
 
-// validateFamilyOrWorkspace validates family or workspace field.
-// Returns validation result and error message if validation fails.
-// For Family: validates against Akun Google (Gemini family emails)
-// For Workspace: validates against Akun ChatGPT (ChatGPT workspace names)
-func (h *Handler) validateFamilyOrWorkspace(ctx context.Context, fieldName, fieldValue string) (interface{}, string, error) {
-	if fieldValue == "" {
-		return nil, "", nil
+// formLock serializes the handling of one #qris message and its edits.
+type formLock struct {
+	mu   sync.Mutex
+	refs int // Handlers holding or waiting for mu
+}
+
+// lockForm waits until no other handler is processing msg (the original form
+// or an earlier edit), so an edit sees the QRIS of the form it corrects.
+// Returns the unlock function.
+func (h *Handler) lockForm(msg *entity.Message) func() {
+	key := msg.ChatID + "/" + msg.ID
+
+	h.formMu.Lock()
+	l := h.formLocks[key]
+	if l == nil {
+		l = &formLock{}
+		h.formLocks[key] = l
+	}
+	l.refs++
+	h.formMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		h.formMu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(h.formLocks, key)
+		}
+		h.formMu.Unlock()
+	}
+}
+
+// editedQris looks up the unpaid QRIS of an edited #qris form, which the
+// corrected form replaces (cmd.Replaces keeps it out of the duplicate check).
+// old is nil for new forms and for edits of forms that never got a QRIS.
+// skip is true if the form must not be handled: an edit after the QRIS was
+// paid, or the original form when its edit was handled first (both can be
+// redelivered together after a reconnect).
+func (h *Handler) editedQris(msg *entity.Message, cmd *entity.QrisCommand) (old *entity.PendingPayment, skip bool) {
+	old, issued := h.paymentUC.FindPendingForMessage(msg.ChatID, msg.ID)
+	if !msg.IsEdit {
+		if issued {
+			h.logger.Printf("✏️ Form %s sudah diproses lewat edit, diabaikan", msg.ID)
+		}
+		return nil, issued
+	}
+	if old == nil {
+		if issued {
+			h.logger.Printf("✏️ Form %s diedit setelah QRIS dibayar, diabaikan", msg.ID)
+		}
+		return nil, issued
+	}
+
+	h.logger.Printf("✏️ Form %s diedit, QRIS %s akan diganti", msg.ID, old.MessageID)
+	cmd.Replaces = old.MessageID
+	return old, false
+}
+
+// replaceQris cancels the unpaid QRIS of an edited form and deletes its image.
+// Returns false if it was paid in the meantime (no new QRIS must be sent).
+func (h *Handler) replaceQris(ctx context.Context, old *entity.PendingPayment) bool {
+	if old == nil {
+		return true
+	}
+	if h.paymentUC.CancelPending(old) == nil {
+		h.logger.Printf("✏️ QRIS %s sudah dibayar sebelum diganti", old.MessageID)
+		return false
+	}
+	if err := h.messaging.RevokeMessage(ctx, old.QrisChatID(), "", old.MessageID); err != nil {
+		h.logger.Printf("⚠️ Gagal hapus QRIS lama %s: %v", old.MessageID, err)
+	}
+	return true
+}
+
+// validateOrder runs the order checks that need the sheets: duplicate email,
+// slot owner, price, voucher, redeem code stock and a unique amount. Every
+// failure is collected in the result. Renewals skip the slot check (the member
+// already holds a slot). old is the unpaid QRIS an edited form replaces, or nil.
+func (h *Handler) validateOrder(ctx context.Context, cmd *entity.QrisCommand, old *entity.PendingPayment) (*entity.DuplicateCheck, *entity.ValidationResult) {
+	result := &entity.ValidationResult{}
+
+	dup := h.checkDuplicate(ctx, cmd, result)
+	if cmd.RenewalRow == 0 {
+		h.validateSlot(ctx, cmd, result)
+	}
+	// The voucher discount needs the catalog price
+	autoFilled := cmd.Amount <= 0
+	if h.resolveAmount(ctx, cmd, result) {
+		h.applyVoucher(ctx, cmd, result)
+	}
+	h.checkRedeemCodeStock(ctx, cmd.Produk, result)
+	// Last, so a rejected order doesn't hold a unique code
+	if result.Valid() {
+		h.reserveAmount(ctx, cmd, autoFilled, old, result)
+	}
+
+	return dup, result
+}
+
+// checkDuplicate checks cmd.Email against the product sheet and unpaid QRIS.
+// Returns the check (for caption notes); a blocked order is added to result.
+// Lookup failures don't block the order.
+func (h *Handler) checkDuplicate(ctx context.Context, cmd *entity.QrisCommand, result *entity.ValidationResult) *entity.DuplicateCheck {
+	if h.duplicateUC == nil {
+		return nil
+	}
+
+	check, err := h.duplicateUC.Check(ctx, cmd, time.Now())
+	if err != nil {
+		h.logger.Printf("⚠️ Gagal cek duplikat email %s: %v", cmd.Email, err)
+		return nil
+	}
+	if check.IsBlocked() {
+		h.logger.Printf("Duplikat email %s (%s), QRIS tidak dibuat", cmd.Email, cmd.Produk)
+		result.Add("Email", template.BuildDuplicateBlocked(cmd, check))
+		return check
+	}
+	if cmd.RenewalRow > 0 {
+		h.logger.Printf("Email %s sudah terdaftar, order jadi perpanjangan baris %d", cmd.Email, cmd.RenewalRow)
+	}
+	return check
+}
+
+// validateSlot validates the slot owner of the ordered product (Family for Gemini,
+// Workspace owner for ChatGPT, Email Head for YouTube) using the registered validator.
+// A failed validation is added to result under the slot label.
+func (h *Handler) validateSlot(ctx context.Context, cmd *entity.QrisCommand, result *entity.ValidationResult) {
+	info := entity.Product(cmd.Produk).Info()
+	owner := cmd.SlotOwner()
+	validator := h.slotValidators[info.Key]
+	if owner == "" || validator == nil {
+		return
 	}
 
-	switch fieldName {
-	case "Family":
-		// Family validation for Gemini products
-		if h.familyUC == nil {
-			return nil, "", nil
+	validation, err := validator.ValidateSlot(ctx, owner)
+	if err != nil || !validation.IsValid {
+		message := "validasi gagal"
+		if validation != nil && validation.ErrorMessage != "" {
+			message = validation.ErrorMessage
 		}
-		validation, err := h.familyUC.ValidateFamily(ctx, fieldValue)
-		if err != nil || !validation.IsValid {
-			errorMsg := fmt.Sprintf("❌ Validasi %s gagal", fieldName)
-			if validation != nil && validation.ErrorMessage != "" {
-				errorMsg = "❌ " + validation.ErrorMessage
-			}
-			h.logger.Printf("Validasi %s gagal: %v", fieldName, err)
-			return validation, errorMsg, err
+		h.logger.Printf("Validasi %s gagal: %s (%v)", info.SlotLabel, message, err)
+		result.Add(info.SlotLabel, message)
+		return
+	}
+	h.logger.Printf("Validasi %s berhasil: %s (%d/%d slots)", info.SlotLabel, owner, validation.UsedSlots, validation.MaxSlots)
+}
+
+// resolveAmount validates cmd.Amount against the price catalog, or fills it
+// from the list price when Nominal was left empty. Returns false (and adds to
+// result) if the amount is missing or out of range.
+func (h *Handler) resolveAmount(ctx context.Context, cmd *entity.QrisCommand, result *entity.ValidationResult) bool {
+	if h.catalogUC == nil {
+		if cmd.Amount <= 0 {
+			result.Add("Nominal", "wajib diisi (katalog harga belum dikonfigurasi)")
+			return false
 		}
-		h.logger.Printf("Validasi %s berhasil: %s (%d/%d slots)", fieldName, fieldValue, validation.UsedSlots, validation.MaxSlots)
-		return validation, "", nil
+		cmd.Price = cmd.Amount
+		return true
+	}
 
-	case "Workspace":
-		// Workspace validation for ChatGPT products
-		if h.workspaceUC == nil {
-			return nil, "", nil
+	check, err := h.catalogUC.ResolveAmount(ctx, cmd)
+	if err != nil || !check.IsValid {
+		h.logger.Printf("Validasi nominal gagal: %v", err)
+		message := "validasi nominal gagal"
+		if check != nil && check.ErrorMessage != "" {
+			message = check.ErrorMessage
 		}
-		validation, err := h.workspaceUC.ValidateWorkspace(ctx, fieldValue)
-		if err != nil || !validation.IsValid {
-			errorMsg := fmt.Sprintf("❌ Validasi %s gagal", fieldName)
-			if validation != nil && validation.ErrorMessage != "" {
-				errorMsg = "❌ " + validation.ErrorMessage
-			}
-			h.logger.Printf("Validasi %s gagal: %v", fieldName, err)
-			return validation, errorMsg, err
+		result.Add("Nominal", message)
+		return false
+	}
+
+	if check.AutoFilled {
+		h.logger.Printf("Nominal diisi dari katalog: %s %s → Rp%d", cmd.Produk, cmd.Paket, cmd.Amount)
+	}
+	cmd.Price = check.Amount
+	return true
+}
+
+// reserveAmount makes cmd.Amount unique among unpaid QRIS (payments are matched
+// by amount only). An auto-filled price gets a unique code that keeps it inside
+// the catalog range. A typed nominal is what the customer was told to pay, so
+// it is reserved as is, and refused while another unpaid QRIS waits for it;
+// an edited form may keep the amount of the QRIS it replaces.
+// Adds to result if the amount (or every code) is taken.
+func (h *Handler) reserveAmount(ctx context.Context, cmd *entity.QrisCommand, autoFilled bool, old *entity.PendingPayment, result *entity.ValidationResult) {
+	if !autoFilled {
+		if old != nil && old.Amount == cmd.Amount {
+			return
+		}
+		if _, err := h.paymentUC.ReserveAmount(cmd.Amount, 0); err != nil {
+			h.logger.Printf("Nominal %s sudah dipakai QRIS lain: %v", formatter.FormatRupiah(cmd.Amount), err)
+			result.Add("Nominal", fmt.Sprintf("%s masih menunggu pembayaran QRIS lain, ubah nominal (mis. +Rp1) atau coba lagi setelah dibayar",
+				formatter.FormatRupiah(cmd.Amount)))
 		}
-		h.logger.Printf("Validasi %s berhasil: %s (%d/%d slots)", fieldName, fieldValue, validation.UsedSlots, validation.MaxSlots)
-		return validation, "", nil
+		return
+	}
+
+	maxCode := 0
+	if h.catalogUC != nil {
+		if item, err := h.catalogUC.Find(ctx, entity.Product(cmd.Produk), cmd.Paket); err == nil && item != nil {
+			maxCode = item.UniqueCodeLimit(constants.PriceTolerancePercent, constants.UniqueCodeMax)
+		}
+	}
 
-	default:
-		return nil, "", nil
+	amount, err := h.paymentUC.ReserveAmount(cmd.Amount, maxCode)
+	if err != nil {
+		h.logger.Printf("Nominal %s - %s habis: %v", formatter.FormatRupiah(cmd.Amount), formatter.FormatRupiah(cmd.Amount+maxCode), err)
+		result.Add("Nominal", fmt.Sprintf("semua nominal %s - %s masih menunggu pembayaran QRIS lain, coba lagi setelah ada yang dibayar",
+			formatter.FormatRupiah(cmd.Amount), formatter.FormatRupiah(cmd.Amount+maxCode)))
+		return
 	}
+
+	if amount != cmd.Amount {
+		h.logger.Printf("Kode unik nominal: Rp%d → Rp%d", cmd.Amount, amount)
+	}
+	cmd.Amount = amount
 }
 
-// sendQrisHelp sends help/template based on product type.
-func (h *Handler) sendQrisHelp(ctx context.Context, msg *entity.Message, productType string) {
-	switch productType {
-	case string(parser.ProductParamGoogle):
-		_ = h.messaging.SendTextReply(ctx, msg.ChatID, template.QrisGeminiFormTemplate, msg.ID, msg.SenderID)
-		_ = h.messaging.SendTextTo(ctx, msg.ChatID, template.QrisGeminiFormHelp)
-	case string(parser.ProductParamChatGPT):
-		_ = h.messaging.SendTextReply(ctx, msg.ChatID, template.QrisChatGPTFormTemplate, msg.ID, msg.SenderID)
-		_ = h.messaging.SendTextTo(ctx, msg.ChatID, template.QrisChatGPTFormHelp)
-	default:
-		_ = h.messaging.SendTextReply(ctx, msg.ChatID, template.QrisGeneralHelp, msg.ID, msg.SenderID)
+// applyVoucher validates the optional Voucher field and subtracts its discount
+// from cmd.Amount. A rejected voucher is added to result.
+func (h *Handler) applyVoucher(ctx context.Context, cmd *entity.QrisCommand, result *entity.ValidationResult) {
+	if cmd.Voucher == "" {
+		return
+	}
+	if h.voucherUC == nil {
+		result.Add("Voucher", "belum bisa dipakai (Google Sheets belum dikonfigurasi)")
+		return
+	}
+
+	check, err := h.voucherUC.Apply(ctx, cmd)
+	if err != nil || !check.IsValid {
+		h.logger.Printf("Validasi voucher gagal: %v", err)
+		message := "validasi voucher gagal"
+		if check != nil && check.ErrorMessage != "" {
+			message = check.ErrorMessage
+		}
+		result.Add("Voucher", message)
+		return
+	}
+
+	h.logger.Printf("Voucher %s dipakai: Rp%d → Rp%d", cmd.Voucher, check.Original, check.Final)
+}
+
+// checkRedeemCodeStock ensures a redeem code is still available before a QRIS
+// for a redeem-code product (Perplexity) is generated. Adds to result if not.
+func (h *Handler) checkRedeemCodeStock(ctx context.Context, produk string, result *entity.ValidationResult) {
+	if !entity.Product(produk).Info().RedeemCode || h.inventoryRepo == nil {
+		return
+	}
+
+	stock, err := h.inventoryRepo.GetRedeemCodeAvailability(ctx, true)
+	if err != nil {
+		h.logger.Printf("⚠️ Gagal cek stok kode redeem: %v", err)
+		result.Add("Produk", "gagal cek stok kode redeem Perplexity")
+		return
 	}
+	if stock.AvailableCodes == 0 {
+		result.Add("Produk", "stok kode redeem Perplexity habis. Isi sheet Kode Perplexity terlebih dahulu.")
+		return
+	}
+
+	h.logger.Printf("Stok kode redeem tersedia: %d/%d", stock.AvailableCodes, stock.TotalCodes)
 }
 
-// sendErrorReply sends an error message as a reply.
-func (h *Handler) sendErrorReply(ctx context.Context, msg *entity.Message, text string) {
-	if err := h.messaging.SendTextReply(ctx, msg.ChatID, text, msg.ID, msg.SenderID); err != nil {
-		h.logger.Printf("Gagal kirim error reply: %v", err)
+// sendQrisHelp sends help/template based on product type.
+func (h *Handler) sendQrisHelp(ctx context.Context, msg *entity.Message, productType string) {
+	info, ok := entity.ProductByParam(productType)
+	if !ok {
+		_ = h.messaging.SendTextReply(ctx, msg.ChatID, template.BuildQrisGeneralHelp(), msg.ID, msg.SenderID)
+		return
 	}
+	_ = h.messaging.SendTextReply(ctx, msg.ChatID, template.BuildQrisFormTemplate(info), msg.ID, msg.SenderID)
+	_ = h.messaging.SendTextTo(ctx, msg.ChatID, template.BuildQrisFormHelp(info, h.slotCapacity.SlotCapacity(ctx, info.Key)))
 }
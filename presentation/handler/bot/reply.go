@@ -0,0 +1,43 @@
+// Package bot provides WhatsApp bot message parsing and handling.
+package bot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+)
+
+// sendErrorReply sends an error message as a reply.
+func (h *Handler) sendErrorReply(ctx context.Context, msg *entity.Message, text string) {
+	if err := h.messaging.SendTextReply(ctx, msg.ChatID, text, msg.ID, msg.SenderID); err != nil {
+		h.logger.Printf("Gagal kirim error reply: %v", err)
+	}
+}
+
+// sendGroupNotice sends a notice to a group instead of replying (self-QRIS
+// commands are sent in the customer's chat).
+func (h *Handler) sendGroupNotice(ctx context.Context, groupJID, text string) {
+	if _, err := h.messaging.SendTextToGroupJID(ctx, groupJID, text); err != nil {
+		h.logger.Printf("⚠️ Gagal kirim error ke grup: %v", err)
+	}
+}
+
+// sendLongReply replies with text split into parts of at most MaxMessageLength.
+// The first part quotes the command, the rest follow as plain messages.
+func (h *Handler) sendLongReply(ctx context.Context, msg *entity.Message, text string) {
+	for i, part := range formatter.SplitMessage(text, constants.MaxMessageLength) {
+		if i == 0 {
+			h.sendErrorReply(ctx, msg, part)
+			continue
+		}
+		// A queued part keeps its place in the chat queue, so the rest can follow
+		if err := h.messaging.SendTextTo(ctx, msg.ChatID, part); err != nil && !errors.Is(err, domain.ErrMessageQueued) {
+			h.logger.Printf("Gagal kirim lanjutan pesan (%d): %v", i+1, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Package bot provides WhatsApp bot message parsing and handling.
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/exernia/botjanweb/internal/domain"
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+	"github.com/exernia/botjanweb/pkg/helper/parser"
+	"github.com/exernia/botjanweb/presentation/template"
+)
+
+// handleRoleCommand handles the #role command (owner only, checked in HandleMessage).
+// Format: #role | #role beri <nomor> <role> | #role hapus <nomor>
+func (h *Handler) handleRoleCommand(ctx context.Context, msg *entity.Message, text string) {
+	cmd, err := parser.ParseRoleCommand(text)
+	if err != nil {
+		h.sendErrorReply(ctx, msg, "❌ "+err.Error())
+		return
+	}
+	if cmd.IsHelpMode {
+		h.sendErrorReply(ctx, msg, template.RoleHelp)
+		return
+	}
+
+	by := formatter.NormalizePhone(msg.SenderPhone)
+
+	switch cmd.Action {
+	case entity.RoleList:
+		list, err := h.roleUC.List(ctx)
+		if err != nil {
+			h.logger.Printf("Gagal membaca daftar role: %v", err)
+			h.sendErrorReply(ctx, msg, fmt.Sprintf("❌ Gagal membaca daftar role: %v", err))
+			return
+		}
+		h.sendLongReply(ctx, msg, template.BuildRoleList(list, h.roleUC.DefaultRole()))
+
+	case entity.RoleGrant:
+		err := h.roleUC.Grant(ctx, by, cmd.Phone, cmd.Role)
+		switch {
+		case errors.Is(err, domain.ErrRoleConfigOwner):
+			h.sendErrorReply(ctx, msg, "⚠️ Nomor ini owner dari OWNER_PHONES, ubah lewat konfigurasi.")
+		case err != nil:
+			h.logger.Printf("Gagal memberi role %s ke %s: %v", cmd.Role, cmd.Phone, err)
+			h.sendErrorReply(ctx, msg, fmt.Sprintf("❌ Gagal menyimpan role: %v", err))
+		default:
+			h.sendErrorReply(ctx, msg, template.BuildRoleGranted(cmd.Phone, cmd.Role))
+		}
+
+	case entity.RoleRevoke:
+		removed, err := h.roleUC.Revoke(ctx, by, cmd.Phone)
+		switch {
+		case errors.Is(err, domain.ErrRoleConfigOwner):
+			h.sendErrorReply(ctx, msg, "⚠️ Nomor ini owner dari OWNER_PHONES, ubah lewat konfigurasi.")
+		case errors.Is(err, domain.ErrRoleNotAssigned):
+			h.sendErrorReply(ctx, msg, "ℹ️ Nomor ini belum punya role: "+formatter.FormatPhone(cmd.Phone))
+		case err != nil:
+			h.logger.Printf("Gagal menghapus role %s: %v", cmd.Phone, err)
+			h.sendErrorReply(ctx, msg, fmt.Sprintf("❌ Gagal menghapus role: %v", err))
+		default:
+			h.sendErrorReply(ctx, msg, template.BuildRoleRevoked(cmd.Phone, removed, h.roleUC.DefaultRole()))
+		}
+	}
+}
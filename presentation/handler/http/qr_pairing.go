@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,20 +13,31 @@ import (
 	"time"
 
 	"github.com/exernia/botjanweb/internal/infrastructure/messaging/whatsapp"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
 	"github.com/exernia/botjanweb/pkg/logger"
 	"github.com/exernia/botjanweb/presentation/view"
 	"github.com/skip2/go-qrcode"
+	"go.mau.fi/whatsmeow"
 )
 
-// QRPairingController handles WhatsApp QR code pairing via web interface.
+// Phone-number pairing limits.
+const (
+	pairCodeCooldown = 30 * time.Second // Minimum time between pairing code requests (WhatsApp throttles them)
+	pairQRWait       = 10 * time.Second // How long a code request waits for the pairing websocket
+)
+
+// QRPairingController handles WhatsApp pairing via web interface:
+// QR code scanning or a phone-number pairing code.
 type QRPairingController struct {
-	waClient     *whatsapp.Client
-	view         *view.PairingView
-	logger       *log.Logger
-	mu           sync.RWMutex
-	currentQR    string
-	lastUpdate   time.Time
-	pairingToken string // Simple auth token
+	waClient      *whatsapp.Client
+	view          *view.PairingView
+	logger        *log.Logger
+	mu            sync.RWMutex
+	currentQR     string
+	lastUpdate    time.Time
+	sessionActive bool      // Pairing websocket is running (QR codes rotating)
+	lastPairCode  time.Time // Last phone-number pairing code request
+	pairingToken  string    // Simple auth token
 
 	// Simple rate limiting for security (personal project, not production-grade)
 	rateLimitMu     sync.Mutex
@@ -146,18 +158,7 @@ func (c *QRPairingController) HandlePairingPage(w http.ResponseWriter, r *http.R
 	}
 
 	// Start pairing process if not already started
-	c.mu.Lock()
-	pairingActive := c.currentQR != "" || !c.lastUpdate.IsZero()
-	c.mu.Unlock()
-
-	if !pairingActive {
-		c.logger.Println("Starting QR pairing on-demand...")
-		// Use background context - pairing must survive beyond this HTTP request
-		go func() {
-			if err := c.StartPairing(context.Background()); err != nil {
-				c.logger.Printf("Failed to start pairing: %v", err)
-			}
-		}()
+	if c.startPairingSession() {
 		// Give pairing a moment to start before rendering page
 		time.Sleep(100 * time.Millisecond)
 	}
@@ -220,6 +221,186 @@ func (c *QRPairingController) HandleQRCodeAPI(w http.ResponseWriter, r *http.Req
 	json.NewEncoder(w).Encode(response)
 }
 
+// HandlePairCodeAPI requests a phone-number pairing code (POST JSON {"phone": "628xxx"}).
+// Useful when the bot's phone is the device showing the pairing page and cannot scan it.
+func (c *QRPairingController) HandlePairCodeAPI(w http.ResponseWriter, r *http.Request) {
+	ip := r.RemoteAddr
+
+	// Check rate limiting first (protect from brute force)
+	if c.checkRateLimit(ip) {
+		c.logger.Printf("Rate limit exceeded for %s - request blocked", ip)
+		http.Error(w, "Too many failed attempts - please try again later", http.StatusTooManyRequests)
+		return
+	}
+
+	// Security: Authenticate via header (not URL to prevent exposure in logs)
+	// Supports X-Pairing-Token (recommended) or Authorization header
+	token := r.Header.Get("X-Pairing-Token")
+	if token == "" {
+		token = r.Header.Get("Authorization")
+	}
+
+	if token != c.pairingToken {
+		c.logger.Printf("Unauthorized pairing code request from %s", ip)
+		c.recordFailedAttempt(ip)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	c.resetFailedAttempts(ip)
+
+	if c.waClient.IsLoggedIn() {
+		writePairingJSON(w, http.StatusOK, map[string]interface{}{
+			"status":    "success",
+			"device_id": c.waClient.GetOwnID(),
+		})
+		return
+	}
+
+	var req struct {
+		Phone string `json:"phone"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req); err != nil {
+		writePairingJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid JSON body"})
+		return
+	}
+
+	// Accepts 08xx / +62 8xx / 628xx (other countries in international format)
+	phone := formatter.NormalizePhone(req.Phone)
+	if !isPairablePhone(phone) {
+		writePairingJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error": "phone must be the bot's WhatsApp number in international format, e.g. 628123456789",
+		})
+		return
+	}
+
+	// WhatsApp only accepts code requests on a live pairing websocket
+	if err := c.waitForPairingSession(r.Context()); err != nil {
+		c.logger.Printf("Pairing session not ready for code request: %v", err)
+		writePairingJSON(w, http.StatusServiceUnavailable, map[string]interface{}{"error": "pairing session not ready, try again"})
+		return
+	}
+
+	// Start the cooldown only for requests that reach WhatsApp, so a rejected
+	// request doesn't block the real pairing attempt
+	if wait := c.reservePairCode(); wait > 0 {
+		writePairingJSON(w, http.StatusTooManyRequests, map[string]interface{}{
+			"error":       fmt.Sprintf("please wait %d seconds before requesting a new code", int(wait.Seconds())+1),
+			"retry_after": int(wait.Seconds()) + 1,
+		})
+		return
+	}
+
+	code, err := c.waClient.PairPhone(r.Context(), phone)
+	if err != nil {
+		c.logger.Printf("Failed to get pairing code for %s: %v", formatter.MaskPhone(phone), err)
+		status := http.StatusBadGateway
+		if errors.Is(err, whatsmeow.ErrPhoneNumberTooShort) || errors.Is(err, whatsmeow.ErrPhoneNumberIsNotInternational) {
+			status = http.StatusBadRequest
+		}
+		writePairingJSON(w, status, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	c.logger.Printf("✅ Pairing code generated for %s", formatter.MaskPhone(phone))
+	writePairingJSON(w, http.StatusOK, map[string]interface{}{
+		"status":    "code",
+		"code":      code,
+		"phone":     formatter.MaskPhone(phone),
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+// isPairablePhone checks a normalized phone: digits only, international, not too short.
+func isPairablePhone(phone string) bool {
+	if len(phone) < 8 || len(phone) > 15 || phone[0] == '0' {
+		return false
+	}
+	for _, r := range phone {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// reservePairCode enforces the cooldown between pairing code requests.
+// Returns the remaining wait, or 0 if a new code may be requested now.
+func (c *QRPairingController) reservePairCode() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if wait := pairCodeCooldown - time.Since(c.lastPairCode); wait > 0 {
+		return wait
+	}
+	c.lastPairCode = time.Now()
+	return 0
+}
+
+// startPairingSession starts the pairing websocket unless one is running.
+// Returns true if a new session was started.
+func (c *QRPairingController) startPairingSession() bool {
+	c.mu.Lock()
+	if c.sessionActive {
+		c.mu.Unlock()
+		return false
+	}
+	c.sessionActive = true
+	c.mu.Unlock()
+
+	c.logger.Println("Starting QR pairing on-demand...")
+	// Use background context - pairing must survive beyond this HTTP request
+	go func() {
+		if err := c.StartPairing(context.Background()); err != nil {
+			c.logger.Printf("Failed to start pairing: %v", err)
+			c.endPairingSession()
+		}
+	}()
+	return true
+}
+
+// endPairingSession marks the pairing websocket as finished so the next request starts a new one.
+func (c *QRPairingController) endPairingSession() {
+	c.mu.Lock()
+	c.currentQR = ""
+	c.sessionActive = false
+	c.mu.Unlock()
+}
+
+// waitForPairingSession starts pairing if needed and waits for the first QR code,
+// after which the websocket is ready for pairing code requests.
+func (c *QRPairingController) waitForPairingSession(ctx context.Context) error {
+	c.startPairingSession()
+
+	deadline := time.NewTimer(pairQRWait)
+	defer deadline.Stop()
+	tick := time.NewTicker(200 * time.Millisecond)
+	defer tick.Stop()
+
+	for {
+		c.mu.RLock()
+		ready := c.currentQR != ""
+		c.mu.RUnlock()
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("no QR code after %s", pairQRWait)
+		case <-tick.C:
+		}
+	}
+}
+
+// writePairingJSON writes a JSON response with the given status code.
+func writePairingJSON(w http.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
 // StartPairing initiates the QR code pairing process.
 // This should be called BEFORE WAClient.Run() to avoid race condition.
 func (c *QRPairingController) StartPairing(ctx context.Context) error {
@@ -254,20 +435,17 @@ func (c *QRPairingController) StartPairing(ctx context.Context) error {
 
 			case "success":
 				c.logger.Println("✅ Pairing successful")
-				c.mu.Lock()
-				c.currentQR = ""
-				c.mu.Unlock()
+				c.endPairingSession()
 				return
 
 			case "timeout":
 				log.Println("[PAIRING] ⏱️ QR code expired/timeout")
-				c.mu.Lock()
-				c.currentQR = ""
-				c.mu.Unlock()
+				c.endPairingSession()
 				return
 			}
 		}
 		c.logger.Println("QR channel closed")
+		c.endPairingSession()
 	}()
 
 	c.logger.Println("Connecting WhatsApp websocket...")
@@ -21,7 +21,7 @@ func RegisterRoutes(router *Router, webhook *WebhookController, qrPairing *QRPai
 	router.GET("/health", "Health check endpoint (liveness probe)", webhook.handleHealth)
 
 	// /ready - Readiness probe: Is the app ready to serve traffic?
-	// Returns 200 only if all critical dependencies are healthy
+	// Returns 200 only if all critical dependencies are healthy (incl. the WhatsApp session)
 	router.GET("/ready", "Readiness check endpoint (readiness probe)", webhook.handleReadiness)
 
 	// Aliases for compatibility
@@ -34,6 +34,7 @@ func RegisterRoutes(router *Router, webhook *WebhookController, qrPairing *QRPai
 	if qrPairing != nil {
 		router.GET("/pairing", "WhatsApp QR code pairing page", qrPairing.HandlePairingPage)
 		router.GET("/pairing/qr", "Get current QR code (AJAX endpoint)", qrPairing.HandleQRCodeAPI)
+		router.POST("/pairing/code", "Request a phone-number pairing code (AJAX endpoint)", qrPairing.HandlePairCodeAPI)
 	}
 
 	// ============================================================
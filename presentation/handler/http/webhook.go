@@ -18,11 +18,17 @@ import (
 // PaymentConfirmHandler is called when a payment is matched to a pending QRIS.
 type PaymentConfirmHandler func(ctx context.Context, pending *entity.PendingPayment, notification *entity.DANANotification)
 
+// ConnectionChecker reports the WhatsApp connection state (implemented by the WhatsApp client).
+type ConnectionChecker interface {
+	ConnectionStatus() entity.ConnectionStatus
+}
+
 // WebhookController processes incoming webhook requests.
 type WebhookController struct {
 	secret         string
 	paymentUC      *paymentuc.UseCase
 	onPaymentMatch PaymentConfirmHandler
+	connection     ConnectionChecker // Optional, set after the WhatsApp client is created
 	logger         *log.Logger
 	ready          bool // Readiness status
 }
@@ -61,12 +67,17 @@ func (c *WebhookController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // This is for liveness probe - checks if application is running.
 func (c *WebhookController) handleHealth(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	body := map[string]interface{}{
 		"status":        "ok",
 		"pending_count": c.paymentUC.GetPendingCount(),
 		"timestamp":     time.Now().Format(time.RFC3339),
-	})
+	}
+	if c.connection != nil {
+		body["whatsapp"] = connectionPayload(c.connection.ConnectionStatus())
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(body)
 }
 
 // handleReadiness checks if the application is ready to serve traffic.
@@ -84,12 +95,43 @@ func (c *WebhookController) handleReadiness(w http.ResponseWriter, _ *http.Reque
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	body := map[string]interface{}{
 		"status":        "ready",
 		"pending_count": c.paymentUC.GetPendingCount(),
 		"timestamp":     time.Now().Format(time.RFC3339),
-	})
+	}
+
+	// Without a WhatsApp session no QRIS or payment confirmation reaches customers
+	if c.connection != nil {
+		status := c.connection.ConnectionStatus()
+		body["whatsapp"] = connectionPayload(status)
+		if !status.IsReady() {
+			body["status"] = "not_ready"
+			body["message"] = "whatsapp is " + string(status.State)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(body)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(body)
+}
+
+// connectionPayload formats the WhatsApp connection state for health responses.
+func connectionPayload(status entity.ConnectionStatus) map[string]interface{} {
+	payload := map[string]interface{}{
+		"state":  status.State,
+		"since":  status.Since.Format(time.RFC3339),
+		"queued": status.Queued,
+	}
+	if status.Attempts > 0 {
+		payload["attempts"] = status.Attempts
+	}
+	if status.LastError != "" {
+		payload["last_error"] = status.LastError
+	}
+	return payload
 }
 
 // SetReady sets the readiness status.
@@ -97,6 +139,11 @@ func (c *WebhookController) SetReady(ready bool) {
 	c.ready = ready
 }
 
+// SetConnectionChecker makes /ready depend on the WhatsApp connection state.
+func (c *WebhookController) SetConnectionChecker(checker ConnectionChecker) {
+	c.connection = checker
+}
+
 // handlePaymentWebhook processes incoming payment notifications.
 func (c *WebhookController) handlePaymentWebhook(w http.ResponseWriter, r *http.Request) {
 	// Validate secret
@@ -4,6 +4,7 @@ package template
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
 )
@@ -47,6 +48,15 @@ Email: john@example.com
 Sandi: password123
 ───────────────────`
 
+// BuildAddAkunFormHelp appends how the password is stored to a form help.
+// Without ENCRYPTION_KEY the sheet keeps it as plain text, so the note says so.
+func BuildAddAkunFormHelp(help string, encrypted bool) string {
+	if encrypted {
+		return help + "\n\n🔐 Sandi disimpan terenkripsi dan pesan form dihapus otomatis setelah diproses. Lihat sandi dengan *#sandi <email>*."
+	}
+	return help + "\n\n⚠️ Sandi disimpan *tanpa enkripsi* di sheet (ENCRYPTION_KEY belum diatur). Pesan form tetap dihapus otomatis setelah diproses. Lihat sandi dengan *#sandi <email>*."
+}
+
 // AddAkunChatGPTFormTemplate is the template for adding ChatGPT accounts.
 const AddAkunChatGPTFormTemplate = `#addakun chatgpt
 ───────────────────
@@ -80,10 +90,27 @@ Workspace: TeamAlpha
 const ListAkunHelp = `📋 *PANDUAN LIST AKUN*
 
 ━━━━━━━━━━━━━━━━━━━━
-Perintah ini menampilkan daftar akun yang terdaftar.
+Perintah ini menampilkan daftar akun yang terdaftar (%d akun per halaman).
+
+🔎 *Filter (opsional, urutan bebas):*
+• *google* / *chatgpt* → tipe akun
+• *tersedia* / *kekunci* / *banned* / *berakhir* → status akun
+• *family <nama>* / *workspace <nama>* → nama family/workspace
+• *cari <teks>* → cari email
+• *urut* → urutkan tanggal berakhir terdekat
+• *hal <n>* → halaman
 
 📌 *Contoh:*
-#listakun`
+#listakun
+#listakun google tersedia
+#listakun google berakhir urut
+#listakun chatgpt workspace alpha
+#listakun cari john hal 2`
+
+// BuildListAkunHelp builds the #listakun help with the page size.
+func BuildListAkunHelp(pageSize int) string {
+	return fmt.Sprintf(ListAkunHelp, pageSize)
+}
 
 // ============================================================================
 // ADD AKUN RESPONSE TEMPLATES
@@ -115,62 +142,185 @@ func BuildAddAkunSuccess(cmd *entity.AddAkunCommand) string {
 • %s: %s
 
 📊 Data telah tersimpan di spreadsheet`, cmd.Tipe, cmd.Email, detailField, detailValue)
-} // BuildListAkunResult builds the account list message.
-func BuildListAkunResult(result *entity.AccountListResult, filter entity.AccountType) string {
-	var msg string
-
-	msg += "📋 *DAFTAR AKUN*\n\n"
-	msg += "━━━━━━━━━━━━━━━━━━━━\n"
-
-	// Show Google accounts
-	if filter == "" || filter == entity.AccountTypeGoogle {
-		msg += "🔵 *AKUN GOOGLE*\n"
-		msg += fmt.Sprintf("Total: %d | Tersedia: %d | Tidak Tersedia: %d\n",
-			result.TotalGoogle, result.AvailableGoogle, result.TotalGoogle-result.AvailableGoogle)
-		msg += "───────────────────\n"
-
-		if len(result.GoogleAccounts) == 0 {
-			msg += "_Belum ada akun_\n"
-		} else {
-			for _, acc := range result.GoogleAccounts {
-				status := "✅"
-				if !acc.IsAvailable() {
-					status = "❌"
-				}
-				msg += fmt.Sprintf("%s %s\n", status, acc.Email)
-				if acc.Keterangan != "" {
-					msg += fmt.Sprintf("   └ %s\n", acc.Keterangan)
-				}
-			}
-		}
-		msg += "\n"
-	}
-
-	// Show ChatGPT accounts
-	if filter == "" || filter == entity.AccountTypeChatGPT {
-		msg += "🟢 *AKUN CHATGPT*\n"
-		msg += fmt.Sprintf("Total: %d | Tersedia: %d | Tidak Tersedia: %d\n",
-			result.TotalChatGPT, result.AvailableChatGPT, result.TotalChatGPT-result.AvailableChatGPT)
-		msg += "───────────────────\n"
-
-		if len(result.ChatGPTAccounts) == 0 {
-			msg += "_Belum ada akun_\n"
-		} else {
-			for _, acc := range result.ChatGPTAccounts {
-				status := "✅"
-				if !acc.IsAvailable() {
-					status = "❌"
-				}
-				msg += fmt.Sprintf("%s %s (%s)\n", status, acc.Email, acc.Workspace)
-				if acc.Status != "" && !acc.IsAvailable() {
-					msg += fmt.Sprintf("   └ %s\n", acc.Status)
-				}
+}
+
+// BuildListAkunResult builds one page of the account list message.
+func BuildListAkunResult(page *entity.AccountListPage, cmd *entity.ListAkunCommand) string {
+	var b strings.Builder
+
+	b.WriteString("📋 *DAFTAR AKUN*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	if cmd.Tipe == "" || cmd.Tipe == entity.AccountTypeGoogle {
+		b.WriteString(fmt.Sprintf("🔵 Google: %d total | %d tersedia\n", page.TotalGoogle, page.AvailableGoogle))
+	}
+	if cmd.Tipe == "" || cmd.Tipe == entity.AccountTypeChatGPT {
+		b.WriteString(fmt.Sprintf("🟢 ChatGPT: %d total | %d tersedia\n", page.TotalChatGPT, page.AvailableChatGPT))
+	}
+	if filters := listAkunFilterLabel(cmd); filters != "" {
+		b.WriteString(fmt.Sprintf("🔎 Filter: %s → %d akun\n", filters, page.Matched))
+	}
+
+	if len(page.Items) == 0 {
+		b.WriteString("\n_Tidak ada akun yang cocok_")
+		return b.String()
+	}
+
+	if cmd.SortByExpiry {
+		b.WriteString("\n")
+	}
+	var lastType entity.AccountType
+	for i := range page.Items {
+		item := &page.Items[i]
+		if item.Tipe != lastType && !cmd.SortByExpiry {
+			if item.Tipe == entity.AccountTypeGoogle {
+				b.WriteString("\n🔵 *AKUN GOOGLE*\n")
+			} else {
+				b.WriteString("\n🟢 *AKUN CHATGPT*\n")
 			}
+			b.WriteString("───────────────────\n")
+			lastType = item.Tipe
 		}
+		b.WriteString(buildListAkunItem(item, cmd.SortByExpiry && cmd.Tipe == ""))
+	}
+
+	b.WriteString("\n━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("📄 Halaman %d/%d", page.Page, page.Pages))
+	if page.Page < page.Pages {
+		b.WriteString(fmt.Sprintf(" • berikutnya: tambah *hal %d*", page.Page+1))
+	}
+	b.WriteString("\n✅ = Tersedia | ❌ = Tidak Tersedia")
+	return b.String()
+}
+
+// buildListAkunItem builds the lines of one account.
+// The type tag is shown when sorting mixes Google and ChatGPT accounts.
+func buildListAkunItem(item *entity.AccountListItem, showType bool) string {
+	var b strings.Builder
+
+	status := "✅"
+	if !item.Available {
+		status = "❌"
+	}
+	b.WriteString(status + " " + item.Email)
+	if item.Group != "" {
+		b.WriteString(" (" + item.Group + ")")
+	}
+	if showType {
+		b.WriteString(" [" + string(item.Tipe) + "]")
+	}
+	b.WriteString("\n")
+
+	if item.EndText != "" {
+		b.WriteString(fmt.Sprintf("   └ Berakhir: %s\n", item.EndText))
+	}
+	if item.Note != "" && (item.Tipe == entity.AccountTypeGoogle || !item.Available) {
+		b.WriteString(fmt.Sprintf("   └ %s\n", item.Note))
+	}
+	return b.String()
+}
+
+// listAkunFilterLabel describes the active #listakun filters, or "" if none.
+func listAkunFilterLabel(cmd *entity.ListAkunCommand) string {
+	var parts []string
+	if cmd.Filter != "" {
+		parts = append(parts, string(cmd.Filter))
+	}
+	if cmd.Group != "" {
+		parts = append(parts, "family/workspace \""+cmd.Group+"\"")
+	}
+	if cmd.Search != "" {
+		parts = append(parts, "email \""+cmd.Search+"\"")
+	}
+	if cmd.SortByExpiry {
+		parts = append(parts, "urut berakhir")
 	}
+	return strings.Join(parts, ", ")
+}
+
+// ============================================================================
+// SANDI TEMPLATES
+// ============================================================================
+
+// SandiHelp is the help message for #sandi command.
+const SandiHelp = `🔐 *PANDUAN LIHAT SANDI*
+
+━━━━━━━━━━━━━━━━━━━━
+Sandi akun dikirim lewat chat pribadi ke pengirim perintah.
+
+📌 *Contoh:*
+#sandi john@example.com`
+
+// SandiSentPrivately is the reply in the chat where #sandi was sent.
+const SandiSentPrivately = "🔐 Sandi dikirim lewat chat pribadi."
+
+// BuildSandiReveal builds the private message with a decrypted account login.
+func BuildSandiReveal(cred *entity.AccountCredential) string {
+	group := cred.Group
+	if group == "" {
+		group = "-"
+	}
+	groupLabel := "Family"
+	if cred.Tipe == entity.AccountTypeChatGPT {
+		groupLabel = "Workspace"
+	}
+
+	return fmt.Sprintf(`🔐 *SANDI AKUN %s*
+
+━━━━━━━━━━━━━━━━━━━━
+• Email: %s
+• Sandi: %s
+• %s: %s
+
+⚠️ Hapus pesan ini setelah dipakai.`, strings.ToUpper(string(cred.Tipe)), cred.Email, cred.Sandi, groupLabel, group)
+}
+
+// ============================================================================
+// ACCOUNT MONITOR TEMPLATES
+// ============================================================================
+
+// healthIcon returns the alert icon for an account health level.
+func healthIcon(h entity.AccountHealth) string {
+	switch h {
+	case entity.HealthBanned:
+		return "🔴"
+	case entity.HealthLocked:
+		return "🟠"
+	}
+	return "🟡"
+}
 
-	msg += "\n━━━━━━━━━━━━━━━━━━━━\n"
-	msg += "✅ = Tersedia | ❌ = Tidak Tersedia"
+// BuildAccountHealthAlert builds the account monitor alert, most urgent first.
+// New (or worsened) issues since the previous scan are marked 🆕.
+func BuildAccountHealthAlert(issues []entity.AccountIssue, newCount int) string {
+	var b strings.Builder
+
+	b.WriteString("🩺 *MONITOR AKUN*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("%d akun bermasalah (%d baru)\n", len(issues), newCount))
+
+	var current entity.AccountHealth = -1
+	for _, issue := range issues {
+		if issue.Health != current {
+			current = issue.Health
+			b.WriteString(fmt.Sprintf("\n%s *%s*\n", healthIcon(current), current.Label()))
+		}
+
+		marker := ""
+		if issue.IsNew {
+			marker = "🆕 "
+		}
+		name := issue.Email
+		if issue.Name != "" {
+			name = fmt.Sprintf("%s (%s)", issue.Name, issue.Email)
+		}
+		b.WriteString(fmt.Sprintf("• %s[%s] %s\n", marker, issue.Type, name))
+		b.WriteString(fmt.Sprintf("   └ %d member aktif", issue.Members))
+		if issue.Detail != "" {
+			b.WriteString(" | " + issue.Detail)
+		}
+		b.WriteString("\n")
+	}
 
-	return msg
+	b.WriteString("\n⚠️ *Tindakan:* Pindahkan member dari akun bermasalah")
+	return b.String()
 }
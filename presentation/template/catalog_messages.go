@@ -0,0 +1,69 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains price catalog message templates.
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+)
+
+// ============================================================================
+// CATALOG TEMPLATES
+// ============================================================================
+
+// BuildPriceList builds the #harga response grouped by product.
+// filter limits the list to one product (empty = all products).
+func BuildPriceList(items []entity.CatalogItem, filter entity.Product) string {
+	var b strings.Builder
+
+	b.WriteString("💰 *DAFTAR HARGA*\n")
+
+	found := 0
+	for _, product := range entity.AllProducts() {
+		if filter != "" && product != filter {
+			continue
+		}
+
+		var lines []string
+		slots := 0
+		for _, item := range items {
+			if item.Produk != product {
+				continue
+			}
+			paket := item.Paket
+			if paket == "" {
+				paket = "Reguler"
+			}
+			minHarga, maxHarga := item.AmountRange(constants.PriceTolerancePercent)
+			lines = append(lines, fmt.Sprintf("• %s: *%s* (%s - %s)",
+				paket, formatter.FormatRupiah(item.Harga),
+				formatter.FormatRupiah(minHarga), formatter.FormatRupiah(maxHarga)))
+			if item.MaxSlots > 0 {
+				slots = item.MaxSlots
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		found++
+		b.WriteString("\n━━━━━━━━━━━━━━━━━━━━\n")
+		b.WriteString(fmt.Sprintf("📦 *%s*\n", product.FullName()))
+		b.WriteString(strings.Join(lines, "\n"))
+		b.WriteString("\n")
+		if slots > 0 {
+			b.WriteString(fmt.Sprintf("👥 Maks %d slot\n", slots))
+		}
+	}
+
+	if found == 0 {
+		return "💰 Belum ada harga di katalog untuk produk ini."
+	}
+
+	b.WriteString("\n💡 Kosongkan *Nominal* di form #qris untuk memakai harga katalog.")
+	return b.String()
+}
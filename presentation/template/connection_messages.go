@@ -0,0 +1,69 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains WhatsApp connection alert templates (sent via webhook/email, not WhatsApp).
+package template
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// ============================================================================
+// CONNECTION ALERT TEMPLATES
+// ============================================================================
+
+// connectionAlertTime formats alert timestamps in WIB.
+func connectionAlertTime(t time.Time) string {
+	return t.In(time.FixedZone("WIB", 7*60*60)).Format("2006-01-02 15:04:05 MST")
+}
+
+// BuildLoggedOutAlert builds the alert sent when the WhatsApp session is logged out.
+// pairingURL is shown without the token (empty = pairing page disabled).
+func BuildLoggedOutAlert(status entity.ConnectionStatus, pairingURL string) (subject, body string) {
+	pairingHint := "Pairing ulang: aktifkan WEBHOOK_ENABLED lalu buka halaman /pairing."
+	if pairingURL != "" {
+		pairingHint = fmt.Sprintf("Pairing ulang lewat %s?token=<WEBHOOK_SECRET>", pairingURL)
+	}
+
+	subject = "[BotJanWeb] WhatsApp logout - bot berhenti"
+	body = fmt.Sprintf(`Sesi WhatsApp BotJanWeb sudah logout sejak %s.
+
+Alasan: %s
+
+Selama belum dipairing ulang, bot tidak bisa membalas perintah, mengirim QRIS, maupun konfirmasi pembayaran.
+
+%s`, connectionAlertTime(status.Since), orDash(status.LastError), pairingHint)
+	return subject, body
+}
+
+// BuildReconnectFailingAlert builds the alert sent when reconnecting keeps failing.
+func BuildReconnectFailingAlert(status entity.ConnectionStatus) (subject, body string) {
+	subject = "[BotJanWeb] WhatsApp terputus - gagal reconnect"
+	body = fmt.Sprintf(`Koneksi WhatsApp BotJanWeb terputus sejak %s dan belum tersambung kembali.
+
+Percobaan reconnect: %d
+Error terakhir: %s
+Pesan tertahan: %d
+
+Bot terus mencoba reconnect otomatis. Pesan yang tertahan lebih dari beberapa menit akan dibuang.`,
+		connectionAlertTime(status.Since), status.Attempts, orDash(status.LastError), status.Queued)
+	return subject, body
+}
+
+// BuildConnectionRecoveredAlert builds the alert sent when the connection is back after an alert.
+func BuildConnectionRecoveredAlert(status entity.ConnectionStatus, downSince time.Time) (subject, body string) {
+	subject = "[BotJanWeb] WhatsApp tersambung kembali"
+	body = fmt.Sprintf(`Koneksi WhatsApp BotJanWeb tersambung kembali pada %s.
+
+Durasi gangguan: %s`, connectionAlertTime(status.Since), status.Since.Sub(downSince).Round(time.Second))
+	return subject, body
+}
+
+// orDash returns "-" for empty values.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains #export message templates.
+package template
+
+import (
+	"fmt"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// ============================================================================
+// EXPORT TEMPLATES
+// ============================================================================
+
+// ExportHelp is the help message for #export command.
+const ExportHelp = `📤 *PANDUAN EXPORT DATA*
+
+━━━━━━━━━━━━━━━━━━━━
+Data dikirim sebagai file CSV (tambahkan *xlsx* untuk Excel).
+
+• *#export orders [produk] [periode]* → Pesanan dari sheet produk
+• *#export slots [produk] [tersedia]* → Ketersediaan slot
+• *#export kode [tersedia]* → Kode Perplexity
+• *#export akun [google|chatgpt]* → Akun Google / ChatGPT (tanpa sandi)
+
+📅 *Periode* (Tanggal Pesanan):
+• 2026-10 → satu bulan
+• 2026-10-05 → satu hari
+• 2026-10-01..2026-10-15 → rentang tanggal
+• 7h → 7 hari terakhir
+
+📌 *Contoh:*
+#export orders gemini 2026-10
+#export slots chatgpt tersedia xlsx`
+
+// BuildExportCaption builds the caption of an export document.
+func BuildExportCaption(cmd *entity.ExportCommand, file *entity.ExportFile) string {
+	caption := fmt.Sprintf("📤 *EXPORT %s*\n📄 %d baris", exportTitle(cmd.Kind), file.Rows)
+	if cmd.Product != "" {
+		caption += fmt.Sprintf("\n🏷️ Produk: %s", cmd.Product)
+	}
+	if cmd.AccountType != "" {
+		caption += fmt.Sprintf("\n🏷️ Tipe: %s", cmd.AccountType)
+	}
+	if cmd.Range.Label != "" {
+		caption += fmt.Sprintf("\n📅 Periode: %s", cmd.Range.Label)
+	}
+	if cmd.AvailableOnly {
+		caption += "\n✅ Hanya yang tersedia"
+	}
+	return caption
+}
+
+// BuildExportEmpty builds the reply when an export has no data rows.
+func BuildExportEmpty(cmd *entity.ExportCommand) string {
+	return fmt.Sprintf("ℹ️ Tidak ada data %s untuk diexport dengan filter tersebut.", exportTitle(cmd.Kind))
+}
+
+// exportTitle returns the display name of an export kind.
+func exportTitle(kind entity.ExportKind) string {
+	switch kind {
+	case entity.ExportOrders:
+		return "PESANAN"
+	case entity.ExportSlots:
+		return "SLOT"
+	case entity.ExportKode:
+		return "KODE PERPLEXITY"
+	case entity.ExportAkun:
+		return "AKUN"
+	}
+	return string(kind)
+}
@@ -0,0 +1,14 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains per-group (multi-group) templates.
+package template
+
+import "fmt"
+
+// ============================================================================
+// GROUP TEMPLATES
+// ============================================================================
+
+// BuildCommandDisabled builds the reply for a command not enabled in the current group.
+func BuildCommandDisabled(cmd string) string {
+	return fmt.Sprintf("❌ Perintah %s tidak tersedia di grup ini.", cmd)
+}
@@ -0,0 +1,52 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains the generated #help templates (from entity.Commands).
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// ============================================================================
+// HELP TEMPLATES
+// ============================================================================
+
+// BuildHelp builds the #help overview from the commands the sender can use here.
+func BuildHelp(specs []entity.CommandSpec, role entity.Role) string {
+	var sb strings.Builder
+	sb.WriteString("📖 *DAFTAR PERINTAH*\n")
+	fmt.Fprintf(&sb, "Role kamu: *%s*\n\n━━━━━━━━━━━━━━━━━━━━\n", roleLabels[role])
+	for _, spec := range specs {
+		fmt.Fprintf(&sb, "• *%s* → %s\n", spec.Name, spec.Description)
+	}
+	sb.WriteString("\nKetik *#help <perintah>* untuk panduan lengkap, contoh: #help qris")
+	return sb.String()
+}
+
+// BuildCommandHelp builds the #help <command> details.
+// allowed reports whether the sender may use the command in this chat.
+func BuildCommandHelp(spec entity.CommandSpec, allowed bool) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📖 *%s*\n%s\n\n", strings.ToUpper(strings.TrimPrefix(spec.Name, "#")), spec.Description)
+	if len(spec.Aliases) > 0 {
+		fmt.Fprintf(&sb, "Alias: %s\n", strings.Join(spec.Aliases, ", "))
+	}
+	fmt.Fprintf(&sb, "Role minimal: *%s*\n", spec.Role)
+	if len(spec.Usage) > 0 {
+		sb.WriteString("\n📌 *Contoh:*\n")
+		for _, u := range spec.Usage {
+			sb.WriteString(u + "\n")
+		}
+	}
+	if !allowed {
+		sb.WriteString("\n🚫 Kamu tidak bisa memakai perintah ini di sini.")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// BuildUnknownCommand builds the reply for #help with an unknown command.
+func BuildUnknownCommand(name string) string {
+	return fmt.Sprintf("❌ Perintah tidak dikenal: %s\nKetik *#help* untuk daftar perintah.", name)
+}
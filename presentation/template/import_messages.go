@@ -0,0 +1,86 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains #import (bulk import) message templates.
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// ============================================================================
+// IMPORT TEMPLATES
+// ============================================================================
+
+// ImportHelp is the help message for #import command.
+const ImportHelp = `📥 *PANDUAN IMPORT MASSAL*
+
+━━━━━━━━━━━━━━━━━━━━
+Kirim file *.csv* atau *.xlsx* dengan caption:
+
+• *#import akun google* → kolom: Email, Sandi
+• *#import akun chatgpt* → kolom: Email, Sandi, Workspace
+• *#import kode* → kolom: Email, Kode
+
+📌 *Catatan:*
+• Baris pertama boleh berisi judul kolom (urutan bebas)
+• Tanpa judul kolom, urutan kolom seperti di atas
+• Maks. %d baris, ukuran file maks. 2 MB
+• File akun berisi sandi, jadi dihapus otomatis setelah dibaca
+
+1️⃣ Bot menampilkan ringkasan (baris valid / tidak valid)
+2️⃣ Balas *#import ok* untuk menyimpan, atau *#import batal*`
+
+// BuildImportHelp builds the #import help with the row limit.
+func BuildImportHelp(maxRows int) string {
+	return fmt.Sprintf(ImportHelp, maxRows)
+}
+
+// BuildImportPreview builds the dry-run summary shown before confirmation.
+// Only the first maxInvalid invalid rows are listed.
+func BuildImportPreview(preview *entity.ImportPreview, maxInvalid, validMinutes int) string {
+	var b strings.Builder
+
+	b.WriteString("📥 *PREVIEW IMPORT*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("📄 File: %s\n", preview.FileName))
+	b.WriteString(fmt.Sprintf("📋 Sheet: %s\n", preview.Kind.Label()))
+	b.WriteString(fmt.Sprintf("✅ Valid: %d baris\n", len(preview.Valid)))
+	b.WriteString(fmt.Sprintf("❌ Tidak valid: %d baris\n", len(preview.Invalid)))
+
+	if len(preview.Invalid) > 0 {
+		b.WriteString("\n⚠️ *Baris dilewati:*\n")
+		for i, row := range preview.Invalid {
+			if i == maxInvalid {
+				b.WriteString(fmt.Sprintf("  ... dan %d baris lainnya\n", len(preview.Invalid)-maxInvalid))
+				break
+			}
+			b.WriteString(fmt.Sprintf("  • Baris %d: %s\n", row.Number, row.Error))
+		}
+	}
+
+	b.WriteString("\n━━━━━━━━━━━━━━━━━━━━\n")
+	if len(preview.Valid) > 0 {
+		b.WriteString(fmt.Sprintf("Balas *#import ok* untuk menyimpan %d baris atau *#import batal* (berlaku %d menit).",
+			len(preview.Valid), validMinutes))
+	} else {
+		b.WriteString("Tidak ada baris yang bisa disimpan. Perbaiki file lalu kirim ulang.")
+	}
+	return b.String()
+}
+
+// BuildImportResult builds the confirmation after the rows were appended.
+func BuildImportResult(preview *entity.ImportPreview) string {
+	var b strings.Builder
+
+	b.WriteString("✅ *IMPORT BERHASIL*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("📄 File: %s\n", preview.FileName))
+	b.WriteString(fmt.Sprintf("📋 Sheet: %s\n", preview.Kind.Label()))
+	b.WriteString(fmt.Sprintf("➕ Ditambahkan: %d baris", len(preview.Valid)))
+	if len(preview.Invalid) > 0 {
+		b.WriteString(fmt.Sprintf("\n⏭️ Dilewati: %d baris", len(preview.Invalid)))
+	}
+	return b.String()
+}
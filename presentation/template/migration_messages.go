@@ -0,0 +1,148 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains #pindah (member migration) message templates.
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// ============================================================================
+// MIGRATION TEMPLATES
+// ============================================================================
+
+// PindahHelp is the help message for #pindah command.
+const PindahHelp = `🔀 *PANDUAN PINDAH MEMBER*
+
+━━━━━━━━━━━━━━━━━━━━
+Pindahkan semua member dari workspace ChatGPT yang kena ban ke workspace lain yang masih ada slot.
+
+📌 *Format:*
+#pindah <email owner / nama workspace>
+
+1️⃣ Bot menampilkan rencana pindah (member → workspace tujuan)
+2️⃣ Balas *#pindah ok* untuk menjalankan, atau *#pindah batal*
+3️⃣ Sheet ChatGPT diupdate dan customer dikabari via WA`
+
+// workspaceLabel returns "Workspace (email)" or just the email if the workspace has no name.
+func workspaceLabel(akun entity.AkunChatGPT) string {
+	if akun.Workspace == "" {
+		return akun.Email
+	}
+	return fmt.Sprintf("%s (%s)", akun.Workspace, akun.Email)
+}
+
+// BuildBannedWorkspaceList builds the #pindah help with banned workspaces that still have members.
+func BuildBannedWorkspaceList(issues []entity.AccountIssue) string {
+	var b strings.Builder
+	b.WriteString(PindahHelp)
+
+	if len(issues) == 0 {
+		b.WriteString("\n\n✅ Tidak ada workspace banned yang masih punya member.")
+		return b.String()
+	}
+
+	b.WriteString("\n\n🚫 *Workspace banned:*\n")
+	for _, issue := range issues {
+		name := issue.Email
+		if issue.Name != "" {
+			name = fmt.Sprintf("%s (%s)", issue.Name, issue.Email)
+		}
+		b.WriteString(fmt.Sprintf("• %s - %d member\n", name, issue.Members))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// BuildMigrationPlan builds the proposed plan shown before confirmation.
+func BuildMigrationPlan(plan *entity.MigrationPlan, validMinutes int) string {
+	var b strings.Builder
+
+	b.WriteString("🔀 *RENCANA PINDAH MEMBER*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("🚫 Dari: %s\n", workspaceLabel(plan.Source)))
+	if plan.Source.TanggalKenaBan != "" {
+		b.WriteString(fmt.Sprintf("📅 Kena ban: %s\n", plan.Source.TanggalKenaBan))
+	}
+
+	if len(plan.Moves) == 0 && len(plan.Unassigned) == 0 {
+		b.WriteString("\n✅ Tidak ada member di workspace ini.")
+		return b.String()
+	}
+
+	// Group moves by target, keeping plan order
+	var order []string
+	byTarget := make(map[string][]entity.MemberMove)
+	for _, move := range plan.Moves {
+		key := move.Target.Email
+		if _, ok := byTarget[key]; !ok {
+			order = append(order, key)
+		}
+		byTarget[key] = append(byTarget[key], move)
+	}
+	for _, key := range order {
+		moves := byTarget[key]
+		b.WriteString(fmt.Sprintf("\n➡️ *%s* (%d member)\n", workspaceLabel(moves[0].Target), len(moves)))
+		for _, move := range moves {
+			b.WriteString(fmt.Sprintf("  • %s - %s\n", move.Member.Nama, move.Member.Email))
+		}
+	}
+
+	if len(plan.Unassigned) > 0 {
+		b.WriteString(fmt.Sprintf("\n⚠️ *Slot tidak cukup* (%d member belum dapat workspace):\n", len(plan.Unassigned)))
+		for _, m := range plan.Unassigned {
+			b.WriteString(fmt.Sprintf("  • %s - %s\n", m.Nama, m.Email))
+		}
+	}
+
+	b.WriteString("\n━━━━━━━━━━━━━━━━━━━━\n")
+	if len(plan.Moves) > 0 {
+		b.WriteString(fmt.Sprintf("Balas *#pindah ok* untuk menjalankan atau *#pindah batal* (berlaku %d menit).", validMinutes))
+	} else {
+		b.WriteString("Tambahkan akun ChatGPT baru (#addakun chatgpt) lalu coba lagi.")
+	}
+	return b.String()
+}
+
+// BuildMigrationResult builds the group summary after a plan was applied.
+func BuildMigrationResult(result *entity.MigrationResult) string {
+	var b strings.Builder
+
+	b.WriteString("✅ *PINDAH MEMBER SELESAI*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("🚫 Dari: %s\n", workspaceLabel(result.Source)))
+	b.WriteString(fmt.Sprintf("🔀 Dipindah: %d member\n", len(result.Moved)))
+	b.WriteString(fmt.Sprintf("📨 Dikabari: %d customer\n", result.Notified))
+
+	if len(result.Failed) > 0 {
+		b.WriteString(fmt.Sprintf("\n❌ *Gagal update sheet* (%d):\n", len(result.Failed)))
+		for _, move := range result.Failed {
+			b.WriteString(fmt.Sprintf("  • %s → %s\n", move.Member.Email, move.Target.Email))
+		}
+	}
+
+	if len(result.Unnotified) > 0 {
+		b.WriteString(fmt.Sprintf("\n📵 *Belum dikabari* (tidak ada nomor WA / gagal kirim, %d):\n", len(result.Unnotified)))
+		for _, move := range result.Unnotified {
+			b.WriteString(fmt.Sprintf("  • %s → %s\n", move.Member.Email, workspaceLabel(move.Target)))
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// BuildMigrationNotice builds the message sent to a customer whose workspace was moved.
+func BuildMigrationNotice(move entity.MemberMove) string {
+	var b strings.Builder
+
+	b.WriteString("🔀 *PEMINDAHAN WORKSPACE CHATGPT*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("Halo %s, workspace ChatGPT kamu sebelumnya sedang bermasalah, jadi kamu kami pindahkan ke workspace baru.\n\n", move.Member.Nama))
+	b.WriteString(fmt.Sprintf("📧 Email: %s\n", move.Member.Email))
+	if move.Target.Workspace != "" {
+		b.WriteString(fmt.Sprintf("🏢 Workspace baru: %s\n", move.Target.Workspace))
+	}
+	b.WriteString("\n📩 Cek email untuk undangan workspace baru, lalu terima undangannya. Masa aktif langganan tetap sama.")
+	return b.String()
+}
@@ -0,0 +1,87 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains the step-by-step order wizard (#order) templates.
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// ============================================================================
+// ORDER WIZARD TEMPLATES
+// ============================================================================
+
+// orderWizardFooter explains the replies every question accepts.
+const orderWizardFooter = "↩️ _kembali_ = pertanyaan sebelumnya • 🚫 _batal_ = batalkan order"
+
+// BuildOrderProductQuestion asks which product to order.
+func BuildOrderProductQuestion() string {
+	var b strings.Builder
+
+	b.WriteString("📝 *ORDER BARU*\n\n")
+	b.WriteString("Produk apa yang mau diorder? Balas dengan nomor atau nama produk:\n\n")
+	for i, p := range entity.AllProducts() {
+		info := p.Info()
+		b.WriteString(fmt.Sprintf("%d. %s (*%s*)\n", i+1, info.FullName, info.Param))
+	}
+	b.WriteString("\n🚫 _batal_ = batalkan order")
+
+	return b.String()
+}
+
+// BuildOrderQuestion asks for the current field of an order wizard.
+func BuildOrderQuestion(s *entity.OrderSession) string {
+	field, _ := s.Current()
+	info := entity.Product(s.Cmd.Produk).Info()
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("📝 *ORDER %s* (%d/%d)\n\n", strings.ToUpper(string(info.Key)), s.Step+1, len(s.Fields())))
+	b.WriteString(fmt.Sprintf("*%s*\n%s\n", field.Label, field.Help))
+	if field.Example != "" {
+		b.WriteString(fmt.Sprintf("Contoh: %s\n", field.Example))
+	}
+	if current := s.Cmd.Field(field.Key); current != "" && field.Key != entity.FieldKanal {
+		b.WriteString(fmt.Sprintf("Jawaban sebelumnya: %s\n", current))
+	}
+
+	b.WriteString("\n")
+	switch {
+	case field.Key == entity.FieldNominal:
+		b.WriteString("⏭️ Balas *-* untuk memakai harga katalog.\n")
+	case field.Key == entity.FieldKanal:
+		b.WriteString(fmt.Sprintf("⏭️ Balas *-* untuk memakai %s.\n", s.DefaultKanal))
+	case !field.Required:
+		b.WriteString("⏭️ Balas *-* atau *skip* untuk melewati.\n")
+	}
+	b.WriteString(orderWizardFooter)
+
+	return b.String()
+}
+
+// BuildOrderAnswerInvalid builds the reply for an answer that failed validation,
+// followed by the same question again.
+func BuildOrderAnswerInvalid(result *entity.ValidationResult, s *entity.OrderSession) string {
+	return BuildValidationErrors("Jawaban belum valid", result, "Silakan jawab lagi.") + "\n\n" + BuildOrderQuestion(s)
+}
+
+// BuildOrderCancelled builds the reply after an order wizard was cancelled.
+func BuildOrderCancelled() string {
+	return "🚫 Order dibatalkan."
+}
+
+// BuildOrderExpired builds the reply for an answer to a timed-out order wizard.
+func BuildOrderExpired(minutes int) string {
+	return fmt.Sprintf("⌛ Sesi order berakhir (tidak ada jawaban selama %d menit). Ketik *#order* untuk mulai lagi.", minutes)
+}
+
+// BuildOrderNoSession builds the reply for "#order batal" without a running wizard.
+func BuildOrderNoSession() string {
+	return "ℹ️ Tidak ada order yang sedang berjalan."
+}
+
+// BuildOrderProcessing builds the reply after the last answer, before the QRIS is generated.
+func BuildOrderProcessing(cmd *entity.QrisCommand) string {
+	return fmt.Sprintf("✅ Data lengkap. Membuat QRIS untuk %s...", cmd.Deskripsi)
+}
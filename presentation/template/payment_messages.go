@@ -16,6 +16,15 @@ import (
 // PAYMENT NOTIFICATION TEMPLATES
 // ============================================================================
 
+// familyLabel returns the display label for PendingPayment.Family by product
+// (from the product registry, e.g. Workspace for ChatGPT, Email Head for YouTube).
+func familyLabel(produk string) string {
+	if label := entity.Product(produk).Info().SlotLabel; label != "" {
+		return label
+	}
+	return "Family"
+}
+
 // BuildPaymentConfirmation builds payment confirmation message for customer.
 func BuildPaymentConfirmation(pending *entity.PendingPayment, notif *entity.DANANotification) string {
 	wib := time.FixedZone("WIB", constants.WIBOffset)
@@ -28,9 +37,12 @@ func BuildPaymentConfirmation(pending *entity.PendingPayment, notif *entity.DANA
 	b.WriteString(fmt.Sprintf("• Nama: %s\n", pending.Nama))
 	b.WriteString(fmt.Sprintf("• Email: %s\n", pending.Email))
 	if pending.Family != "" {
-		b.WriteString(fmt.Sprintf("• Family: %s\n", pending.Family))
+		b.WriteString(fmt.Sprintf("• %s: %s\n", familyLabel(pending.Produk), pending.Family))
 	}
 	b.WriteString(fmt.Sprintf("• Nominal: %s\n", formatter.FormatRupiah(notif.Amount)))
+	if pending.Voucher != "" {
+		b.WriteString(fmt.Sprintf("• Voucher: %s (-%s)\n", pending.Voucher, formatter.FormatRupiah(pending.Discount)))
+	}
 	b.WriteString(fmt.Sprintf("• Waktu: %s\n", notif.Timestamp.In(wib).Format(constants.DateTimeWIBFormat)))
 	b.WriteString("\n🙏 Terima kasih!")
 
@@ -43,12 +55,15 @@ func BuildSelfQrisPaymentNotification(pending *entity.PendingPayment, amount int
 
 	b.WriteString("💰 *PEMBAYARAN DITERIMA*\n\n")
 	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	if pending.RenewalRow > 0 {
+		b.WriteString("🔁 Perpanjangan langganan\n")
+	}
 	b.WriteString(fmt.Sprintf("Nama: %s\n", pending.Nama))
 	b.WriteString(fmt.Sprintf("Produk: %s\n", pending.Produk))
 	b.WriteString(fmt.Sprintf("Nominal: %s\n", formatter.FormatRupiah(amount)))
 	b.WriteString(fmt.Sprintf("📧 Email: %s\n", pending.Email))
 	if pending.Family != "" {
-		b.WriteString(fmt.Sprintf("👨‍👩‍👧‍👦 Family: %s\n", pending.Family))
+		b.WriteString(fmt.Sprintf("👨‍👩‍👧‍👦 %s: %s\n", familyLabel(pending.Produk), pending.Family))
 	}
 	b.WriteString(fmt.Sprintf("📱 WA: %s", formatter.FormatPhone(pending.SenderPhone)))
 
@@ -78,9 +93,12 @@ func BuildSheetErrorNotification(pending *entity.PendingPayment, errorMsg string
 	b.WriteString(fmt.Sprintf("• Nama: %s\n", pending.Nama))
 	b.WriteString(fmt.Sprintf("• Email: %s\n", pending.Email))
 	if pending.Family != "" {
-		b.WriteString(fmt.Sprintf("• Family: %s\n", pending.Family))
+		b.WriteString(fmt.Sprintf("• %s: %s\n", familyLabel(pending.Produk), pending.Family))
 	}
 	b.WriteString(fmt.Sprintf("• Nominal: %s\n", formatter.FormatRupiah(pending.Amount)))
+	if pending.Voucher != "" {
+		b.WriteString(fmt.Sprintf("• Voucher: %s (-%s)\n", pending.Voucher, formatter.FormatRupiah(pending.Discount)))
+	}
 	b.WriteString(fmt.Sprintf("• Kanal: %s\n", pending.Kanal))
 	if pending.Akun != "" {
 		b.WriteString(fmt.Sprintf("• Akun: %s\n", pending.Akun))
@@ -93,3 +111,66 @@ func BuildSheetErrorNotification(pending *entity.PendingPayment, errorMsg string
 
 	return b.String()
 }
+
+// BuildRedeemCodeDelivery builds the private message delivering a redeem code to the customer.
+func BuildRedeemCodeDelivery(pending *entity.PendingPayment, code *entity.RedeemCodeInfo) string {
+	var b strings.Builder
+
+	b.WriteString("🎫 *KODE REDEEM PERPLEXITY*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("• Nama: %s\n", pending.Nama))
+	b.WriteString(fmt.Sprintf("• Email: %s\n", pending.Email))
+	b.WriteString(fmt.Sprintf("• Kode: *%s*\n", code.KodeRedeem))
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n\n")
+	b.WriteString("📌 Redeem kode di perplexity.ai menggunakan email di atas.\n")
+	b.WriteString("⚠️ Jangan bagikan kode ini ke orang lain.")
+
+	return b.String()
+}
+
+// BuildRedeemCodeSentNotification builds group notice after a redeem code was delivered.
+// The code itself is not included to keep it out of the group chat.
+func BuildRedeemCodeSentNotification(pending *entity.PendingPayment, code *entity.RedeemCodeInfo, recipient string) string {
+	var b strings.Builder
+
+	b.WriteString("🎫 *KODE REDEEM TERKIRIM*\n\n")
+	b.WriteString(fmt.Sprintf("• Nama: %s\n", pending.Nama))
+	b.WriteString(fmt.Sprintf("• Email: %s\n", pending.Email))
+	b.WriteString(fmt.Sprintf("• Baris: %d (Kode Perplexity)\n", code.No))
+	b.WriteString(fmt.Sprintf("• Dikirim ke: %s", formatter.FormatPhone(strings.Split(recipient, "@")[0])))
+
+	return b.String()
+}
+
+// BuildRedeemCodeUnavailableNotification builds group alert when no redeem code could be claimed.
+func BuildRedeemCodeUnavailableNotification(pending *entity.PendingPayment, errorMsg string) string {
+	var b strings.Builder
+
+	b.WriteString("⚠️ *KODE REDEEM TIDAK TERSEDIA*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("• Nama: %s\n", pending.Nama))
+	b.WriteString(fmt.Sprintf("• Email: %s\n", pending.Email))
+	b.WriteString(fmt.Sprintf("• Nominal: %s\n", formatter.FormatRupiah(pending.Amount)))
+	b.WriteString("\n❌ *Error:*\n")
+	b.WriteString(fmt.Sprintf("%s\n", formatter.FormatUserFriendlyError(errorMsg)))
+	b.WriteString("\n⚠️ *Tindakan:* Isi stok Kode Perplexity, lalu kirim kode dan catat manual\n")
+
+	return b.String()
+}
+
+// BuildVoucherUsageErrorNotification builds group alert when a confirmed voucher use could not be recorded.
+func BuildVoucherUsageErrorNotification(pending *entity.PendingPayment, errorMsg string) string {
+	var b strings.Builder
+
+	b.WriteString("⚠️ *GAGAL CATAT PEMAKAIAN VOUCHER*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("• Voucher: %s\n", pending.Voucher))
+	b.WriteString(fmt.Sprintf("• Nama: %s\n", pending.Nama))
+	b.WriteString(fmt.Sprintf("• Email: %s\n", pending.Email))
+	b.WriteString(fmt.Sprintf("• Potongan: %s\n", formatter.FormatRupiah(pending.Discount)))
+	b.WriteString("\n❌ *Error:*\n")
+	b.WriteString(fmt.Sprintf("%s\n", formatter.FormatUserFriendlyError(errorMsg)))
+	b.WriteString("\n⚠️ *Tindakan:* Tambah manual ke sheet Pemakaian Voucher dan kolom Terpakai\n")
+
+	return b.String()
+}
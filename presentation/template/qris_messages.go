@@ -5,8 +5,10 @@ package template
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/constants"
 	"github.com/exernia/botjanweb/pkg/helper/formatter"
 )
 
@@ -14,90 +16,103 @@ import (
 // QRIS TEMPLATES - Help & Forms
 // ============================================================================
 
-// QrisGeneralHelp is sent when #qris is called without product parameter.
-const QrisGeneralHelp = `📋 *PANDUAN QRIS*
-
-━━━━━━━━━━━━━━━━━━━━
-Gunakan command sesuai produk:
-
-• *#qris google* → Order Gemini (GDrive 2TB + AI Pro)
-• *#qris chatgpt* → Order ChatGPT Pro
-
-📌 *Contoh:*
-#qris google
-#qris chatgpt`
-
-// QrisGeminiFormTemplate is the template for Gemini/Google orders.
-const QrisGeminiFormTemplate = `#qris google
-───────────────────
-Nama: 
-Email: 
-Family: 
-Nominal: 
-Kanal: 
-Akun: 
-───────────────────`
-
-// QrisGeminiFormHelp is the help for Gemini orders.
-const QrisGeminiFormHelp = `📋 *PANDUAN ORDER GEMINI*
-
-━━━━━━━━━━━━━━━━━━━━
-📦 *Produk:* Gemini (GDrive 2TB + Gemini AI Pro)
-
-📝 *Keterangan:*
-• *Nama* - Nama lengkap (wajib)
-• *Email* - Alamat Gmail (wajib)
-• *Family* - Nama family plan (wajib)
-• *Nominal* - Jumlah pembayaran (wajib)
-• *Kanal* - Channel pembelian (default: Threads)
-• *Akun* - Username/akun (opsional)
-
-📌 *Contoh:*
-#qris google
-───────────────────
-Nama: John Doe
-Email: john@example.com
-Family: Rumah Premium
-Nominal: 49901
-Kanal: Threads
-Akun: @johndoe
-───────────────────`
-
-// QrisChatGPTFormTemplate is the template for ChatGPT orders.
-const QrisChatGPTFormTemplate = `#qris chatgpt
-───────────────────
-Nama: 
-Email: 
-Workspace: 
-Paket: 
-Nominal: 
-Kanal: 
-───────────────────`
-
-// QrisChatGPTFormHelp is the help for ChatGPT orders.
-const QrisChatGPTFormHelp = `📋 *PANDUAN ORDER CHATGPT*
-
-━━━━━━━━━━━━━━━━━━━━
-📦 *Produk:* ChatGPT Pro
-
-📝 *Keterangan:*
-• *Nama* - Nama lengkap (wajib)
-• *Email* - Alamat email (wajib)
-• *Workspace* - Nama workspace (wajib)
-• *Paket* - Paket langganan (wajib)
-• *Nominal* - Jumlah pembayaran (wajib)
-• *Kanal* - Channel pembelian (default: Threads)
-
-📌 *Contoh:*
-#qris chatgpt
-───────────────────
-Nama: John Doe
-Email: john@example.com
-Workspace: TeamAlpha
-Paket: Pro
-Nominal: 75000
-Kanal: Threads
-───────────────────`
+// formDivider frames order form templates.
+const formDivider = "───────────────────"
+
+// BuildQrisGeneralHelp builds the help sent when #qris is called without product parameter.
+// Lists every product in the registry.
+func BuildQrisGeneralHelp() string {
+	var b strings.Builder
+
+	b.WriteString("📋 *PANDUAN QRIS*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString("Gunakan command sesuai produk:\n\n")
+	for _, p := range entity.AllProducts() {
+		info := p.Info()
+		b.WriteString(fmt.Sprintf("• *#qris %s* → Order %s\n", info.Param, info.FullName))
+	}
+	b.WriteString("\n📌 *Contoh:*\n")
+	for _, p := range entity.AllProducts() {
+		b.WriteString(fmt.Sprintf("#qris %s\n", p.Info().Param))
+	}
+	b.WriteString("\n💬 Mau ditanya satu per satu? Ketik *#order*")
+	b.WriteString("\n💰 Cek daftar harga: *#harga*")
+
+	return b.String()
+}
+
+// BuildQrisInvalidFormat builds the error for legacy/unknown #qris format in group.
+func BuildQrisInvalidFormat() string {
+	var b strings.Builder
+
+	b.WriteString("❌ Format tidak valid. Gunakan salah satu:\n\n")
+	for _, p := range entity.AllProducts() {
+		b.WriteString(fmt.Sprintf("#qris %s\n", p.Info().Param))
+	}
+	b.WriteString("\nuntuk melihat form yang benar.")
+
+	return b.String()
+}
+
+// BuildQrisFormTemplate builds the empty order form for a product.
+func BuildQrisFormTemplate(info entity.ProductInfo) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("#qris %s\n", info.Param))
+	b.WriteString(formDivider + "\n")
+	for _, f := range info.Fields {
+		b.WriteString(fmt.Sprintf("%s: \n", f.Label))
+	}
+	b.WriteString(formDivider)
+
+	return b.String()
+}
+
+// BuildQrisFormHelp builds the field guide and filled example for a product form.
+// maxSlots is the slot limit per owner (catalog override or product default).
+func BuildQrisFormHelp(info entity.ProductInfo, maxSlots int) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("📋 *PANDUAN ORDER %s*\n\n", strings.ToUpper(string(info.Key))))
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("📦 *Produk:* %s\n\n", info.FullName))
+
+	b.WriteString("📝 *Keterangan:*\n")
+	for _, f := range info.Fields {
+		b.WriteString(fmt.Sprintf("• *%s* - %s\n", f.Label, f.Help))
+	}
+	if info.HasSlots() {
+		b.WriteString(fmt.Sprintf("\n👥 Maks %d anggota per %s (cek: #cekslot %s)\n", maxSlots, info.SlotLabel, info.Param))
+	}
+	if info.HelpNote != "" {
+		b.WriteString(fmt.Sprintf("\n💡 %s\n", info.HelpNote))
+	}
+
+	b.WriteString("\n📌 *Contoh:*\n")
+	b.WriteString(fmt.Sprintf("#qris %s\n", info.Param))
+	b.WriteString(formDivider + "\n")
+	for _, f := range info.Fields {
+		b.WriteString(fmt.Sprintf("%s: %s\n", f.Label, f.Example))
+	}
+	b.WriteString(formDivider)
+
+	return b.String()
+}
+
+// BuildQrisUnknownFields builds the note for form lines that were ignored.
+func BuildQrisUnknownFields(labels []string, info entity.ProductInfo) string {
+	return fmt.Sprintf("⚠️ Baris berikut tidak dikenali dan diabaikan: %s\nField yang tersedia: %s",
+		strings.Join(labels, ", "), formLabels(info))
+}
+
+// formLabels returns the field labels of a product form.
+func formLabels(info entity.ProductInfo) string {
+	labels := make([]string, 0, len(info.Fields))
+	for _, f := range info.Fields {
+		labels = append(labels, f.Label)
+	}
+	return strings.Join(labels, ", ")
+}
 
 // ============================================================================
 // QRIS IMAGE CAPTION TEMPLATES
@@ -119,7 +134,80 @@ func BuildQRISCaption(amount int, deskripsi string) string {
 
 // BuildQrisFormCaption builds simple caption for form template.
 func BuildQrisFormCaption(cmd *entity.QrisCommand) string {
-	return fmt.Sprintf("📝 *Form Order %s*\n\nIsi form di atas dan kirim ulang.", cmd.Produk)
+	return fmt.Sprintf("📝 *Form Order %s*\n\nIsi form di atas dan kirim ulang.", cmd.Produk) + BuildVoucherCaption(cmd)
+}
+
+// BuildVoucherCaption builds the voucher discount lines appended to QRIS captions.
+// Harga is the price from the price check; a unique code added afterwards is
+// listed separately so the lines add up. Returns "" if no voucher was applied.
+func BuildVoucherCaption(cmd *entity.QrisCommand) string {
+	if cmd.Voucher == "" || cmd.Discount <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n🎟️ *Voucher:* " + cmd.Voucher + "\n")
+	b.WriteString(fmt.Sprintf("• Harga: %s\n", formatter.FormatRupiah(cmd.Price)))
+	b.WriteString(fmt.Sprintf("• Potongan: -%s\n", formatter.FormatRupiah(cmd.Discount)))
+	if code := cmd.Amount - (cmd.Price - cmd.Discount); code > 0 {
+		b.WriteString(fmt.Sprintf("• Kode unik: +%s\n", formatter.FormatRupiah(code)))
+	}
+	b.WriteString(fmt.Sprintf("• Bayar: *%s*", formatter.FormatRupiah(cmd.Amount)))
+	return b.String()
+}
+
+// memberEnd returns the end date of a member row for messages ("-" if unknown).
+func memberEnd(m *entity.WorkspaceMember) string {
+	if !m.EndsAt.IsZero() {
+		return m.EndsAt.Format("02-01-2006")
+	}
+	if m.TanggalBerakhir != "" {
+		return m.TanggalBerakhir
+	}
+	return "-"
+}
+
+// BuildDuplicateBlocked builds the Email field error when an order email already
+// has an unpaid QRIS or an active membership under another Family/Workspace/Head.
+func BuildDuplicateBlocked(cmd *entity.QrisCommand, check *entity.DuplicateCheck) string {
+	var b strings.Builder
+
+	if p := check.Pending; p != nil {
+		b.WriteString(fmt.Sprintf("%s masih punya QRIS %s yang belum dibayar.\n", cmd.Email, p.Produk))
+		b.WriteString(fmt.Sprintf("• Nominal: %s\n", formatter.FormatRupiah(p.Amount)))
+		b.WriteString(fmt.Sprintf("• Dibuat: %s\n", p.CreatedAt.In(time.FixedZone("WIB", constants.WIBOffset)).Format(constants.DateTimeWIBFormat)))
+		b.WriteString("\nTunggu pembayaran QRIS sebelumnya atau minta customer membayar QRIS tersebut.")
+		return b.String()
+	}
+
+	m := check.Conflict
+	b.WriteString(fmt.Sprintf("%s sudah aktif di %s lain.\n", cmd.Email, familyLabel(cmd.Produk)))
+	b.WriteString(fmt.Sprintf("• %s: %s\n", familyLabel(cmd.Produk), m.Owner))
+	b.WriteString(fmt.Sprintf("• Baris: %d (sheet %s)\n", m.Row, m.Produk))
+	b.WriteString(fmt.Sprintf("• Berakhir: %s\n", memberEnd(m)))
+	b.WriteString(fmt.Sprintf("\nUntuk perpanjangan, isi %s: %s.", familyLabel(cmd.Produk), m.Owner))
+	return b.String()
+}
+
+// BuildDuplicateCaption builds the duplicate notes appended to QRIS captions
+// (renewal of an existing row, or ended memberships elsewhere). Returns "" if none.
+func BuildDuplicateCaption(cmd *entity.QrisCommand, check *entity.DuplicateCheck) string {
+	if check == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	if m := check.Renewal; m != nil {
+		if cmd.RenewalRow > 0 {
+			b.WriteString(fmt.Sprintf("\n\n🔁 *Perpanjangan:* email sudah terdaftar (berakhir %s). Setelah bayar, masa aktif baris %d diperpanjang.", memberEnd(m), m.Row))
+		} else {
+			b.WriteString(fmt.Sprintf("\n\n⚠️ Email sudah pernah order %s (baris %d).", m.Produk, m.Row))
+		}
+	}
+	for _, m := range check.Expired {
+		b.WriteString(fmt.Sprintf("\n\nℹ️ Sebelumnya di %s %s (berakhir %s).", familyLabel(cmd.Produk), m.Owner, memberEnd(&m)))
+	}
+	return b.String()
 }
 
 // BuildSelfQrisNotification builds initial notification for self-QRIS (before payment).
@@ -130,12 +218,39 @@ func BuildSelfQrisNotification(cmd *entity.QrisCommand, recipientPhone string) s
 	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
 	b.WriteString(fmt.Sprintf("👤 Nama: %s\n", cmd.Nama))
 	b.WriteString(fmt.Sprintf("📧 Email: %s\n", cmd.Email))
-	if cmd.Family != "" {
-		b.WriteString(fmt.Sprintf("👨‍👩‍👧‍👦 Family: %s\n", cmd.Family))
+	if owner := cmd.SlotOwner(); owner != "" {
+		b.WriteString(fmt.Sprintf("👨‍👩‍👧‍👦 %s: %s\n", familyLabel(cmd.Produk), owner))
 	}
 	b.WriteString(fmt.Sprintf("💰 Nominal: %s\n", formatter.FormatRupiah(cmd.Amount)))
+	if cmd.Voucher != "" {
+		b.WriteString(fmt.Sprintf("🎟️ Voucher: %s (-%s)\n", cmd.Voucher, formatter.FormatRupiah(cmd.Discount)))
+	}
 	b.WriteString(fmt.Sprintf("📱 WA: %s\n", formatter.FormatPhone(recipientPhone)))
 	b.WriteString("\n⏳ Menunggu pembayaran...")
 
 	return b.String()
 }
+
+// ============================================================================
+// EDITED FORM TEMPLATES
+// ============================================================================
+
+// BuildQrisEditPaid builds the reply when a #qris form is edited after its QRIS was paid.
+func BuildQrisEditPaid() string {
+	return "ℹ️ QRIS untuk form ini sudah dibayar, perubahan form tidak diproses.\nKirim form baru dengan *#qris* untuk order lain."
+}
+
+// BuildQrisEditKeepsOld builds the hint under validation errors of an edited form
+// while the previous QRIS is still unpaid.
+func BuildQrisEditKeepsOld(old *entity.PendingPayment) string {
+	return fmt.Sprintf("QRIS sebelumnya (%s) masih berlaku. Edit lagi form-nya untuk mengganti.", formatter.FormatRupiah(old.Amount))
+}
+
+// BuildQrisReplacedCaption builds the note appended to the caption of a QRIS that
+// replaces the unpaid QRIS of an edited form. Returns "" for new forms.
+func BuildQrisReplacedCaption(old *entity.PendingPayment) string {
+	if old == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n\n♻️ Form diedit: menggantikan QRIS sebelumnya (%s), QRIS lama sudah dihapus.", formatter.FormatRupiah(old.Amount))
+}
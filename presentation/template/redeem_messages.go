@@ -0,0 +1,85 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains #kode (redeem code lifecycle) message templates.
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// ============================================================================
+// REDEEM CODE TEMPLATES
+// ============================================================================
+
+// KodeHelp is the help message for #kode command.
+const KodeHelp = `🎫 *PANDUAN KELOLA KODE PERPLEXITY*
+
+━━━━━━━━━━━━━━━━━━━━
+• *#kode pakai <kode> <email customer>* → Tandai dipakai hari ini
+• *#kode tanggal <kode> <aktivasi> [berakhir]* → Atur tanggal (YYYY-MM-DD)
+• *#kode invalid <kode> [alasan]* → Tandai kode tidak bisa dipakai
+• *#kode lepas <kode>* → Kembalikan kode ke stok
+
+📌 *Contoh:*
+#kode pakai PPLX-ABC123 john@gmail.com
+#kode tanggal PPLX-ABC123 2026-10-01 2027-10-01
+#kode invalid PPLX-ABC123 sudah kedaluwarsa
+
+Cek status kode dengan *#cekkode semua*.`
+
+// BuildKodeUpdated builds the reply after a #kode action was applied.
+func BuildKodeUpdated(action entity.KodeAction, code *entity.RedeemCodeInfo) string {
+	var b strings.Builder
+
+	switch action {
+	case entity.KodePakai:
+		b.WriteString("✅ *KODE DITANDAI DIPAKAI*\n\n")
+	case entity.KodeTanggal:
+		b.WriteString("📅 *TANGGAL KODE DIPERBARUI*\n\n")
+	case entity.KodeInvalid:
+		b.WriteString("🚫 *KODE DITANDAI INVALID*\n\n")
+	case entity.KodeLepas:
+		b.WriteString("♻️ *KODE DIKEMBALIKAN KE STOK*\n\n")
+	}
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("🎫 Kode: %s (baris %d)\n", code.KodeRedeem, code.No))
+	b.WriteString(BuildRedeemCodeStatus(code))
+	return b.String()
+}
+
+// BuildRedeemCodeStatus builds the status lines of a code (used by #kode and #cekkode).
+func BuildRedeemCodeStatus(code *entity.RedeemCodeInfo) string {
+	var b strings.Builder
+
+	switch {
+	case code.IsInvalid():
+		b.WriteString(fmt.Sprintf("🚫 Status: %s\n", code.Keterangan))
+	case code.TanggalAktivasi == "":
+		b.WriteString("✅ Status: Tersedia\n")
+	default:
+		b.WriteString(fmt.Sprintf("❌ Status: Dipakai %s\n", code.TanggalAktivasi))
+	}
+	if code.Customer != "" {
+		b.WriteString(fmt.Sprintf("👤 Customer: %s\n", code.Customer))
+	}
+	if code.TanggalBerakhir != "" {
+		b.WriteString(fmt.Sprintf("⏳ Berakhir: %s\n", code.TanggalBerakhir))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// BuildRedeemLowStockAlert builds the group alert when available codes fall below the threshold.
+func BuildRedeemLowStockAlert(available, threshold int) string {
+	var b strings.Builder
+
+	b.WriteString("📉 *STOK KODE PERPLEXITY MENIPIS*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("🎫 Sisa kode tersedia: *%d* (batas: %d)\n", available, threshold))
+	if available == 0 {
+		b.WriteString("\n❌ Stok habis: order Perplexity baru akan ditolak.\n")
+	}
+	b.WriteString("\n⚠️ *Tindakan:* Tambah kode dengan #inputkode atau #import kode")
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains subscription renewal message templates.
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+)
+
+// ============================================================================
+// RENEWAL TEMPLATES
+// ============================================================================
+
+// describeDaysLeft returns "hari ini" / "besok" / "N hari lagi".
+func describeDaysLeft(daysLeft int) string {
+	switch daysLeft {
+	case 0:
+		return "hari ini"
+	case 1:
+		return "besok"
+	}
+	return fmt.Sprintf("%d hari lagi", daysLeft)
+}
+
+// BuildRenewalReminder builds the renewal reminder sent to the customer.
+// amount > 0 means a renewal QRIS is attached (the message is its caption).
+func BuildRenewalReminder(sub entity.Subscription, daysLeft int, amount int) string {
+	var b strings.Builder
+
+	b.WriteString("⏰ *PENGINGAT PERPANJANGAN*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("Halo %s, langganan *%s* kamu berakhir *%s* (%s).\n\n",
+		sub.Nama, sub.Produk.FullName(), describeDaysLeft(daysLeft), sub.TanggalBerakhir.Format("02-01-2006")))
+	b.WriteString(fmt.Sprintf("📧 Email: %s\n", sub.Email))
+	if sub.Paket != "" {
+		b.WriteString(fmt.Sprintf("📦 Paket: %s\n", sub.Paket))
+	}
+
+	if amount > 0 {
+		b.WriteString(fmt.Sprintf("💰 Biaya perpanjangan: %s\n", formatter.FormatRupiah(amount)))
+		b.WriteString("\n📱 Scan QRIS di atas untuk perpanjang. Masa aktif otomatis ditambahkan setelah pembayaran diterima.")
+	} else {
+		b.WriteString("\n💬 Balas pesan ini untuk perpanjang langganan.")
+	}
+
+	return b.String()
+}
+
+// BuildRenewalDigest builds the daily group digest of members ending soon.
+func BuildRenewalDigest(notices []entity.RenewalNotice, reminderDays int) string {
+	var b strings.Builder
+
+	b.WriteString("📅 *MEMBER AKAN BERAKHIR*\n\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(fmt.Sprintf("%d member berakhir dalam %d hari ke depan:\n", len(notices), reminderDays))
+
+	for _, n := range notices {
+		sub := n.Subscription
+		b.WriteString(fmt.Sprintf("\n• *%s* - %s (%s)\n", sub.Produk, sub.Nama, sub.Email))
+		if sub.Owner != "" {
+			b.WriteString(fmt.Sprintf("  %s: %s\n", familyLabel(string(sub.Produk)), sub.Owner))
+		}
+		b.WriteString(fmt.Sprintf("  Berakhir: %s (%s)\n", sub.TanggalBerakhir.Format("02-01-2006"), describeDaysLeft(n.DaysLeft)))
+		if n.Status != "" {
+			b.WriteString(fmt.Sprintf("  Pengingat: %s\n", n.Status))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,98 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains role and permission (#role) templates.
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+	"github.com/exernia/botjanweb/pkg/helper/formatter"
+)
+
+// ============================================================================
+// ROLE TEMPLATES
+// ============================================================================
+
+// RoleHelp is the help message for #role command.
+const RoleHelp = `🔑 *PANDUAN ROLE*
+
+━━━━━━━━━━━━━━━━━━━━
+• *#role* → Daftar role
+• *#role beri <nomor> <role>* → Beri role
+• *#role hapus <nomor>* → Hapus role (kembali ke role default)
+
+Role: *owner*, *admin*, *reseller*, *viewer*
+
+📌 *Contoh:*
+#role beri 081234567890 reseller
+#role hapus 081234567890`
+
+// roleLabels are the role names shown to users.
+var roleLabels = map[entity.Role]string{
+	entity.RoleOwner:    "👑 Owner",
+	entity.RoleAdmin:    "🛠️ Admin",
+	entity.RoleReseller: "🛒 Reseller",
+	entity.RoleViewer:   "👀 Viewer",
+}
+
+// BuildPermissionDenied builds the reply when the sender's role may not use a command.
+func BuildPermissionDenied(cmd string, role entity.Role) string {
+	return fmt.Sprintf("🚫 Perintah %s hanya untuk role *%s* ke atas (role kamu: %s).",
+		cmd, entity.RequiredRole(cmd), role)
+}
+
+// BuildRoleList builds the #role overview: assignments and the permission table.
+func BuildRoleList(assignments []entity.RoleAssignment, defaultRole entity.Role) string {
+	var sb strings.Builder
+	sb.WriteString("🔑 *DAFTAR ROLE*\n\n")
+
+	if len(assignments) == 0 {
+		sb.WriteString("Belum ada role yang diberikan.\n")
+	}
+	var last entity.Role
+	for _, a := range assignments {
+		if a.Role != last {
+			if last != "" {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("*" + roleLabels[a.Role] + "*\n")
+			last = a.Role
+		}
+		sb.WriteString("• " + formatter.FormatPhone(a.Phone))
+		if a.GrantedBy == entity.RoleGrantedByConfig {
+			sb.WriteString(" _(OWNER_PHONES)_")
+		}
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "\nPengirim lain yang diizinkan: *%s*\n", defaultRole)
+
+	sb.WriteString("\n━━━━━━━━━━━━━━━━━━━━\n*Hak akses perintah:*\n")
+	for _, role := range entity.Roles {
+		var cmds []string
+		for _, spec := range entity.Commands {
+			if spec.Role == role {
+				cmds = append(cmds, spec.Name)
+			}
+		}
+		if len(cmds) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "• %s+: %s\n", role, strings.Join(cmds, ", "))
+	}
+
+	sb.WriteString("\nKetik *#role beri <nomor> <role>* atau *#role hapus <nomor>*.")
+	return sb.String()
+}
+
+// BuildRoleGranted builds the reply after a role was granted.
+func BuildRoleGranted(phone string, role entity.Role) string {
+	return fmt.Sprintf("✅ %s sekarang *%s*.", formatter.FormatPhone(phone), roleLabels[role])
+}
+
+// BuildRoleRevoked builds the reply after a role was removed.
+func BuildRoleRevoked(phone string, removed, defaultRole entity.Role) string {
+	return fmt.Sprintf("✅ Role *%s* %s dihapus. Sekarang memakai role default (*%s*) jika termasuk pengirim yang diizinkan.",
+		removed, formatter.FormatPhone(phone), defaultRole)
+}
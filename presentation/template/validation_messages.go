@@ -0,0 +1,30 @@
+// Package template provides all message templates for BotJanWeb.
+// This file contains the field validation checklist template.
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exernia/botjanweb/internal/domain/entity"
+)
+
+// ============================================================================
+// VALIDATION TEMPLATES
+// ============================================================================
+
+// BuildValidationErrors renders all field errors of an input as one checklist.
+// hint (optional) is appended as the last line, e.g. where to get the form.
+func BuildValidationErrors(title string, v *entity.ValidationResult, hint string) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("❌ *%s*\n\nPerbaiki data berikut:\n", title))
+	for _, e := range v.Errors {
+		b.WriteString(fmt.Sprintf("☐ *%s*: %s\n", e.Field, e.Message))
+	}
+	if hint != "" {
+		b.WriteString("\n" + hint)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -34,8 +34,9 @@ const PairingSuccessTemplate = `<!DOCTYPE html>
 </body>
 </html>`
 
-// PairingPageTemplate is the interactive HTML page for WhatsApp QR code pairing.
-// It includes QR code display, polling logic, and step-by-step instructions.
+// PairingPageTemplate is the interactive HTML page for WhatsApp pairing.
+// It includes QR code display, polling logic, step-by-step instructions and
+// a phone-number pairing code form (for when the phone cannot scan this screen).
 const PairingPageTemplate = `<!DOCTYPE html>
 <html>
 <head>
@@ -56,6 +57,11 @@ const PairingPageTemplate = `<!DOCTYPE html>
         .status.waiting { background: #fff3cd; color: #856404; }
         .status.success { background: #d4edda; color: #155724; }
         .status.error { background: #f8d7da; color: #721c24; }
+        .pair-code { margin: 30px 0; padding: 20px; background: #f0f2f5; border-radius: 10px; }
+        .pair-code input { padding: 10px; font-size: 16px; width: 60%; border: 1px solid #ccc; border-radius: 5px; }
+        .pair-code button { padding: 10px 16px; font-size: 16px; border: none; border-radius: 5px; background: #00a884; color: white; cursor: pointer; }
+        .pair-code button:disabled { background: #99d5c7; cursor: default; }
+        #paircode { font-family: monospace; font-size: 32px; letter-spacing: 4px; margin: 15px 0; }
     </style>
 </head>
 <body>
@@ -82,8 +88,22 @@ const PairingPageTemplate = `<!DOCTYPE html>
             </div>
         </div>
 
+        <div class="pair-code">
+            <strong>📞 Can't scan? Link with phone number instead</strong>
+            <p style="color: #667781; font-size: 14px;">
+                Enter the bot's WhatsApp number, then on the phone open <strong>Linked Devices</strong> →
+                <strong>Link a Device</strong> → <strong>Link with phone number instead</strong> and type the code.
+            </p>
+            <form id="pairform">
+                <input id="phone" type="tel" placeholder="628123456789" autocomplete="off" required>
+                <button id="pairbtn" type="submit">Get code</button>
+            </form>
+            <div id="paircode"></div>
+            <div id="pairstatus"></div>
+        </div>
+
         <p style="color: #667781; font-size: 14px;">
-            ⚠️ <strong>Security:</strong> Keep this page private. Anyone with this QR can access your WhatsApp.
+            ⚠️ <strong>Security:</strong> Keep this page private. Anyone with this QR or pairing code can access your WhatsApp.
         </p>
     </div>
 
@@ -125,6 +145,42 @@ const PairingPageTemplate = `<!DOCTYPE html>
             }
         }
 
+        // Phone-number pairing code (token via header, same as QR polling)
+        document.getElementById('pairform').addEventListener('submit', async (e) => {
+            e.preventDefault();
+            const btn = document.getElementById('pairbtn');
+            const status = document.getElementById('pairstatus');
+            btn.disabled = true;
+            status.className = 'status waiting';
+            status.textContent = '⏳ Requesting pairing code...';
+            document.getElementById('paircode').textContent = '';
+            try {
+                const response = await fetch('/pairing/code', {
+                    method: 'POST',
+                    headers: {
+                        'Content-Type': 'application/json',
+                        'X-Pairing-Token': '{{.Token}}'
+                    },
+                    body: JSON.stringify({ phone: document.getElementById('phone').value })
+                });
+                const data = await response.json();
+                if (data.code) {
+                    document.getElementById('paircode').textContent = data.code;
+                    status.className = 'status waiting';
+                    status.textContent = '📲 Enter this code on ' + data.phone + ' within a few minutes';
+                } else if (data.status === 'success') {
+                    location.reload();
+                } else {
+                    status.className = 'status error';
+                    status.textContent = '❌ ' + (data.error || 'Failed to get pairing code');
+                }
+            } catch (err) {
+                status.className = 'status error';
+                status.textContent = '❌ Failed to get pairing code';
+            }
+            btn.disabled = false;
+        });
+
         // Poll for QR code every 2 seconds
         fetchQRCode();
         pollInterval = setInterval(fetchQRCode, 2000);